@@ -178,8 +178,9 @@ type (
 
 	// A FuncLit node represents a function literal.
 	FuncLit struct {
-		Type *FuncType  // function type
-		Body *BlockStmt // function body
+		Async token.Pos  // position of "async" keyword; or NoPos
+		Type  *FuncType  // function type
+		Body  *BlockStmt // function body
 	}
 
 	// A CompositeLit node represents a composite literal.
@@ -357,7 +358,12 @@ func (x *Ident) Pos() token.Pos { return x.NamePos }
 func (x *Ellipsis) Pos() token.Pos { return x.Ellipsis }
 
 // Pos returns position of first character belonging to the node.
-func (x *FuncLit) Pos() token.Pos { return x.Type.Pos() }
+func (x *FuncLit) Pos() token.Pos {
+	if x.Async.IsValid() {
+		return x.Async
+	}
+	return x.Type.Pos()
+}
 
 // Pos returns position of first character belonging to the node.
 func (x *CompositeLit) Pos() token.Pos {
@@ -1039,6 +1045,7 @@ type (
 		Shadow   bool          // is a shadow entry
 		IsClass  bool          // recv set by class
 		Static   bool          // recv is static (class method)
+		Async    token.Pos     // position of "async" keyword; or NoPos
 	}
 )
 
@@ -1051,7 +1058,12 @@ func (d *BadDecl) Pos() token.Pos { return d.From }
 func (d *GenDecl) Pos() token.Pos { return d.TokPos }
 
 // Pos returns position of first character belonging to the node.
-func (d *FuncDecl) Pos() token.Pos { return d.Type.Pos() }
+func (d *FuncDecl) Pos() token.Pos {
+	if d.Async.IsValid() {
+		return d.Async
+	}
+	return d.Type.Pos()
+}
 
 // End returns position of first character immediately after the node.
 func (d *BadDecl) End() token.Pos { return d.To }
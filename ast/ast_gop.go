@@ -59,6 +59,60 @@ func (*OverloadFuncDecl) declNode() {}
 
 // -----------------------------------------------------------------------------
 
+// PropDecl node represents a property declaration in a classfile:
+//
+//	prop Name T {
+//		get => expr
+//		set v => { ... }
+//	}
+type PropDecl struct {
+	Doc    *CommentGroup // associated documentation; or nil
+	Prop   token.Pos     // position of "prop"
+	Name   *Ident        // property name
+	Type   Expr          // property type
+	Lbrace token.Pos     // position of "{"
+	Get    *PropAccessor // getter; or nil
+	Set    *PropAccessor // setter; or nil
+	Rbrace token.Pos     // position of "}"
+}
+
+// Pos - position of first character belonging to the node.
+func (p *PropDecl) Pos() token.Pos {
+	return p.Prop
+}
+
+// End - position of first character immediately after the node.
+func (p *PropDecl) End() token.Pos {
+	return p.Rbrace + 1
+}
+
+func (*PropDecl) declNode() {}
+
+// PropAccessor represents the getter `get => body` or the setter
+// `set v => body` of a property.
+type PropAccessor struct {
+	TokPos token.Pos  // position of "get" or "set"
+	Param  *Ident     // value of setter; or nil (getter)
+	Rarrow token.Pos  // position of "=>"
+	X      Expr       // body of `=> expr`; or nil
+	Body   *BlockStmt // body of `=> { ... }`; or nil
+}
+
+// Pos - position of first character belonging to the node.
+func (p *PropAccessor) Pos() token.Pos {
+	return p.TokPos
+}
+
+// End - position of first character immediately after the node.
+func (p *PropAccessor) End() token.Pos {
+	if p.Body != nil {
+		return p.Body.End()
+	}
+	return p.X.End()
+}
+
+// -----------------------------------------------------------------------------
+
 // A DomainTextLit node represents a domain-specific text literal.
 // https://github.com/goplus/gop/issues/2143
 //
@@ -238,6 +292,69 @@ func (*ElemEllipsis) exprNode() {}
 
 // -----------------------------------------------------------------------------
 
+// A TupleType node represents a tuple type: (T1, T2, ...).
+type TupleType struct {
+	Lparen token.Pos // position of "("
+	Elts   []Expr    // list of element types; len(Elts) >= 2
+	Rparen token.Pos // position of ")"
+}
+
+// Pos - position of first character belonging to the node.
+func (p *TupleType) Pos() token.Pos {
+	return p.Lparen
+}
+
+// End - position of first character immediately after the node.
+func (p *TupleType) End() token.Pos {
+	return p.Rparen + 1
+}
+
+func (*TupleType) exprNode() {}
+
+// -----------------------------------------------------------------------------
+
+// A TupleLit node represents a tuple literal: (x1, x2, ...).
+type TupleLit struct {
+	Lparen token.Pos // position of "("
+	Elts   []Expr    // list of tuple elements; len(Elts) >= 2
+	Rparen token.Pos // position of ")"
+}
+
+// Pos - position of first character belonging to the node.
+func (p *TupleLit) Pos() token.Pos {
+	return p.Lparen
+}
+
+// End - position of first character immediately after the node.
+func (p *TupleLit) End() token.Pos {
+	return p.Rparen + 1
+}
+
+func (*TupleLit) exprNode() {}
+
+// -----------------------------------------------------------------------------
+
+// AwaitExpr represents `await expr`, which waits for the future of an async
+// function call.
+type AwaitExpr struct {
+	Await token.Pos // position of "await"
+	X     Expr      // future
+}
+
+// Pos - position of first character belonging to the node.
+func (p *AwaitExpr) Pos() token.Pos {
+	return p.Await
+}
+
+// End - position of first character immediately after the node.
+func (p *AwaitExpr) End() token.Pos {
+	return p.X.End()
+}
+
+func (*AwaitExpr) exprNode() {}
+
+// -----------------------------------------------------------------------------
+
 // ErrWrapExpr represents `expr!`, `expr?` or `expr?: defaultValue`.
 type ErrWrapExpr struct {
 	X       Expr
@@ -357,9 +474,11 @@ func (*RangeExpr) exprNode() {}
 // -----------------------------------------------------------------------------
 
 // ForPhrase represents `for k, v in container if init; cond` phrase.
+// The value can also be destructured: `for k, (v1, v2) in container`.
 type ForPhrase struct {
 	For        token.Pos // position of "for" keyword
 	Key, Value *Ident    // Key may be nil
+	Tuple      *TupleLit // destructured value (v1, v2, ...); or nil (then Value != nil)
 	TokPos     token.Pos // position of "in" operator
 	X          Expr      // value to range over
 	IfPos      token.Pos // position of if or comma; or NoPos
@@ -386,12 +505,13 @@ func (p *ForPhrase) exprNode() {}
 //	`{vexpr for k1, v1 in container1, cond1 ...}` or
 //	`{kexpr: vexpr for k1, v1 in container1, cond1 ...}` or
 //	`{for k1, v1 in container1, cond1 ...}` or
+//	`(vexpr for k1, v1 in container1, cond1 ...)`
 type ComprehensionExpr struct {
-	Lpos token.Pos   // position of "[" or "{"
-	Tok  token.Token // token.LBRACK '[' or token.LBRACE '{'
+	Lpos token.Pos   // position of "[", "{" or "("
+	Tok  token.Token // token.LBRACK '[', token.LBRACE '{' or token.LPAREN '('
 	Elt  Expr        // *KeyValueExpr or Expr or nil
 	Fors []*ForPhrase
-	Rpos token.Pos // position of "]" or "}"
+	Rpos token.Pos // position of "]", "}" or ")"
 }
 
 // Pos - position of first character belonging to the node.
@@ -389,6 +389,12 @@ func Walk(v Visitor, node Node) {
 	case *SliceLit:
 		walkList(v, n.Elts)
 
+	case *TupleType:
+		walkList(v, n.Elts)
+
+	case *TupleLit:
+		walkList(v, n.Elts)
+
 	case *LambdaExpr:
 		walkList(v, n.Lhs)
 		walkList(v, n.Rhs)
@@ -404,6 +410,9 @@ func Walk(v Visitor, node Node) {
 		if n.Value != nil {
 			Walk(v, n.Value)
 		}
+		if n.Tuple != nil {
+			Walk(v, n.Tuple)
+		}
 		if n.Init != nil {
 			Walk(v, n.Init)
 		}
@@ -433,12 +442,39 @@ func Walk(v Visitor, node Node) {
 			Walk(v, n.Expr3)
 		}
 
+	case *AwaitExpr:
+		Walk(v, n.X)
+
 	case *ErrWrapExpr:
 		Walk(v, n.X)
 		if n.Default != nil {
 			Walk(v, n.Default)
 		}
 
+	case *PropDecl:
+		if n.Doc != nil {
+			Walk(v, n.Doc)
+		}
+		Walk(v, n.Name)
+		Walk(v, n.Type)
+		if n.Get != nil {
+			Walk(v, n.Get)
+		}
+		if n.Set != nil {
+			Walk(v, n.Set)
+		}
+
+	case *PropAccessor:
+		if n.Param != nil {
+			Walk(v, n.Param)
+		}
+		if n.X != nil {
+			Walk(v, n.X)
+		}
+		if n.Body != nil {
+			Walk(v, n.Body)
+		}
+
 	case *OverloadFuncDecl:
 		if n.Doc != nil {
 			Walk(v, n.Doc)
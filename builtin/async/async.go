@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package async implements the futures of Go+ async functions and the
+// awaitAll/awaitAny builtins.
+//
+// An async function
+//
+//	async func fetch(url string) (string, error) {
+//		...
+//	}
+//
+// is compiled into a function which runs its body in a new goroutine and
+// returns a future of the result:
+//
+//	func fetch(url string) *async.Future[string] {
+//		return async.Go(func() (string, error) {
+//			...
+//		})
+//	}
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoFutures is returned by AwaitAny when there are no futures.
+var ErrNoFutures = errors.New("async: awaitAny of no futures")
+
+// PanicError is the error of a future whose goroutine panicked.
+type PanicError struct {
+	Value any // the value passed to panic
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprint("async: panic: ", p.Value)
+}
+
+// Future represents the result of an async function which is running in
+// another goroutine.
+type Future[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+func start[T any](fn func() (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				f.err = &PanicError{Value: e}
+			}
+			close(f.done)
+		}()
+		f.val, f.err = fn()
+	}()
+	return f
+}
+
+// Go runs fn in a new goroutine and returns a future of its result.
+func Go[T any](fn func() (T, error)) *Future[T] {
+	return start(fn)
+}
+
+// GoValue runs fn in a new goroutine and returns a future of its result.
+func GoValue[T any](fn func() T) *Future[T] {
+	return start(func() (T, error) {
+		return fn(), nil
+	})
+}
+
+// GoErr runs fn in a new goroutine and returns a future of its error.
+func GoErr(fn func() error) *Future[struct{}] {
+	return start(func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+}
+
+// GoVoid runs fn in a new goroutine and returns a future which is done when
+// fn returns.
+func GoVoid(fn func()) *Future[struct{}] {
+	return start(func() (struct{}, error) {
+		fn()
+		return struct{}{}, nil
+	})
+}
+
+// Done returns a channel that is closed when the future is done.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await waits for the future and returns its result.
+func (f *Future[T]) Await() (T, error) {
+	<-f.done
+	return f.val, f.err
+}
+
+// AwaitCtx waits for the future until ctx is done. It returns ctx.Err() if
+// ctx is done before the future.
+func (f *Future[T]) AwaitCtx(ctx context.Context) (ret T, err error) {
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		return ret, ctx.Err()
+	}
+}
+
+// Value waits for the future and returns its value. It panics if the future
+// failed.
+func (f *Future[T]) Value() T {
+	<-f.done
+	if f.err != nil {
+		panic(f.err)
+	}
+	return f.val
+}
+
+// -----------------------------------------------------------------------------
+
+// AwaitAll waits for all the futures and returns their values in order. It
+// returns the first error (in the order of fs) if any future failed.
+func AwaitAll[T any](fs ...*Future[T]) ([]T, error) {
+	ret := make([]T, len(fs))
+	for i, f := range fs {
+		v, err := f.Await()
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = v
+	}
+	return ret, nil
+}
+
+// AwaitAny waits for the first future which is done and returns its result.
+func AwaitAny[T any](fs ...*Future[T]) (ret T, err error) {
+	if len(fs) == 0 {
+		return ret, ErrNoFutures
+	}
+	done := make(chan *Future[T], len(fs))
+	for _, f := range fs {
+		go func(f *Future[T]) {
+			<-f.done
+			done <- f
+		}(f)
+	}
+	return (<-done).Await()
+}
+
+// -----------------------------------------------------------------------------
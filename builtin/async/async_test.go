@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package async
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFuture(t *testing.T) {
+	f := GoValue(func() int { return 100 })
+	if v := f.Value(); v != 100 {
+		t.Fatal("Value:", v)
+	}
+	errFail := errors.New("fail")
+	g := Go(func() (string, error) { return "", errFail })
+	if _, err := g.Await(); err != errFail {
+		t.Fatal("Await:", err)
+	}
+	<-g.Done()
+	if _, err := GoErr(func() error { return errFail }).Await(); err != errFail {
+		t.Fatal("GoErr:", err)
+	}
+	done := false
+	GoVoid(func() { done = true }).Value()
+	if !done {
+		t.Fatal("GoVoid: not done")
+	}
+}
+
+func TestPanic(t *testing.T) {
+	f := GoVoid(func() { panic("boom") })
+	_, err := f.Await()
+	if e, ok := err.(*PanicError); !ok || e.Value != "boom" || e.Error() != "async: panic: boom" {
+		t.Fatal("Await:", err)
+	}
+	defer func() {
+		if e := recover(); e != err {
+			t.Fatal("Value:", e)
+		}
+	}()
+	f.Value()
+}
+
+func TestAwaitCtx(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	f := GoValue(func() int { <-block; return 1 })
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := f.AwaitCtx(ctx); err != context.DeadlineExceeded {
+		t.Fatal("AwaitCtx:", err)
+	}
+	if v, err := GoValue(func() int { return 2 }).AwaitCtx(context.Background()); err != nil || v != 2 {
+		t.Fatal("AwaitCtx:", v, err)
+	}
+}
+
+func TestAwaitAll(t *testing.T) {
+	fs := make([]*Future[int], 5)
+	for i := range fs {
+		i := i
+		fs[i] = GoValue(func() int { return i * i })
+	}
+	ret, err := AwaitAll(fs...)
+	if err != nil || !reflect.DeepEqual(ret, []int{0, 1, 4, 9, 16}) {
+		t.Fatal("AwaitAll:", ret, err)
+	}
+	errFail := errors.New("fail")
+	fail := Go(func() (int, error) { return 0, errFail })
+	if _, err = AwaitAll(fs[0], fail); err != errFail {
+		t.Fatal("AwaitAll:", err)
+	}
+}
+
+func TestAwaitAny(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	slow := GoValue(func() string { <-block; return "slow" })
+	fast := GoValue(func() string { return "fast" })
+	if v, err := AwaitAny(slow, fast); err != nil || v != "fast" {
+		t.Fatal("AwaitAny:", v, err)
+	}
+	if _, err := AwaitAny[int](); err != ErrNoFutures {
+		t.Fatal("AwaitAny:", err)
+	}
+}
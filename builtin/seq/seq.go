@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package seq implements the pipeline builtins of Go+ sequences (iterator
+// funcs) and slices: map, filter, take, zip, groupBy, sum and sortBy.
+//
+// Sequences are declared as plain func types instead of iter.Seq, so that
+// the package works with Go versions before Go 1.23.
+package seq
+
+import (
+	"sort"
+)
+
+// Number is the constraint of the element type of Sum.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// Ordered is the constraint of the key type of SortBy.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Pair represents an element of a zipped slice or sequence.
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// -----------------------------------------------------------------------------
+
+// Of returns a sequence of the elements of a slice.
+func Of[T any](a []T) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, v := range a {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns a slice of the elements of a sequence.
+func Collect[T any](seq func(yield func(T) bool)) (ret []T) {
+	seq(func(v T) bool {
+		ret = append(ret, v)
+		return true
+	})
+	return
+}
+
+// Map returns a sequence of f(v) for each element v of seq.
+func Map[T, R any](seq func(yield func(T) bool), f func(T) R) func(yield func(R) bool) {
+	return func(yield func(R) bool) {
+		seq(func(v T) bool {
+			return yield(f(v))
+		})
+	}
+}
+
+// Filter returns a sequence of the elements v of seq that f(v) is true.
+func Filter[T any](seq func(yield func(T) bool), f func(T) bool) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		seq(func(v T) bool {
+			return !f(v) || yield(v)
+		})
+	}
+}
+
+// Take returns a sequence of the first n elements of seq.
+func Take[T any](seq func(yield func(T) bool), n int) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		seq(func(v T) bool {
+			i++
+			return yield(v) && i < n
+		})
+	}
+}
+
+// GroupBy groups the elements of seq by key(v).
+func GroupBy[T any, K comparable](seq func(yield func(T) bool), key func(T) K) map[K][]T {
+	ret := make(map[K][]T)
+	seq(func(v T) bool {
+		k := key(v)
+		ret[k] = append(ret[k], v)
+		return true
+	})
+	return ret
+}
+
+// Sum returns the sum of the elements of seq.
+func Sum[T Number](seq func(yield func(T) bool)) (ret T) {
+	seq(func(v T) bool {
+		ret += v
+		return true
+	})
+	return
+}
+
+// SortBy returns a slice of the elements of seq sorted by key(v). The sort
+// is stable.
+func SortBy[T any, K Ordered](seq func(yield func(T) bool), key func(T) K) []T {
+	return SliceSortBy(Collect(seq), key)
+}
+
+// -----------------------------------------------------------------------------
+
+// SliceMap returns a slice of f(v) for each element v of a.
+func SliceMap[T, R any](a []T, f func(T) R) []R {
+	ret := make([]R, len(a))
+	for i, v := range a {
+		ret[i] = f(v)
+	}
+	return ret
+}
+
+// SliceFilter returns a slice of the elements v of a that f(v) is true.
+func SliceFilter[T any](a []T, f func(T) bool) (ret []T) {
+	for _, v := range a {
+		if f(v) {
+			ret = append(ret, v)
+		}
+	}
+	return
+}
+
+// SliceTake returns the first n elements of a.
+func SliceTake[T any](a []T, n int) []T {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(a) {
+		n = len(a)
+	}
+	return a[:n:n]
+}
+
+// SliceZip returns a slice of pairs of the elements of a and b. Its length is
+// the shorter one of a and b.
+func SliceZip[T, U any](a []T, b []U) []Pair[T, U] {
+	n := len(a)
+	if n > len(b) {
+		n = len(b)
+	}
+	ret := make([]Pair[T, U], n)
+	for i := range ret {
+		ret[i] = Pair[T, U]{a[i], b[i]}
+	}
+	return ret
+}
+
+// SliceGroupBy groups the elements of a by key(v).
+func SliceGroupBy[T any, K comparable](a []T, key func(T) K) map[K][]T {
+	ret := make(map[K][]T)
+	for _, v := range a {
+		k := key(v)
+		ret[k] = append(ret[k], v)
+	}
+	return ret
+}
+
+// SliceSum returns the sum of the elements of a.
+func SliceSum[T Number](a []T) (ret T) {
+	for _, v := range a {
+		ret += v
+	}
+	return
+}
+
+// SliceSortBy returns a copy of a sorted by key(v). The sort is stable.
+func SliceSortBy[T any, K Ordered](a []T, key func(T) K) []T {
+	ret := make([]T, len(a))
+	copy(ret, a)
+	sort.SliceStable(ret, func(i, j int) bool {
+		return key(ret[i]) < key(ret[j])
+	})
+	return ret
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package seq
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func count(n int, pulled *int) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			*pulled = i + 1
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestSeq(t *testing.T) {
+	var pulled int
+	s := Map(Filter(count(100, &pulled), func(v int) bool { return v%2 == 1 }), func(v int) string {
+		return fmt.Sprint(v)
+	})
+	if ret := Collect(Take(s, 3)); !reflect.DeepEqual(ret, []string{"1", "3", "5"}) || pulled != 6 {
+		t.Fatal("Take:", ret, pulled)
+	}
+	if ret := Collect(Take(s, 0)); ret != nil {
+		t.Fatal("Take 0:", ret)
+	}
+	if ret := Sum(Take(count(100, &pulled), 4)); ret != 6 {
+		t.Fatal("Sum:", ret)
+	}
+	if ret := Collect(Zip(count(2, &pulled), Of([]string{"a", "b", "c"}))); !reflect.DeepEqual(ret, []Pair[int, string]{{0, "a"}, {1, "b"}}) {
+		t.Fatal("Zip:", ret)
+	}
+	if ret := Collect(Take(Zip(count(5, &pulled), count(5, new(int))), 2)); len(ret) != 2 || pulled != 2 {
+		t.Fatal("Zip stop:", ret, pulled)
+	}
+	g := GroupBy(count(5, &pulled), func(v int) bool { return v%2 == 0 })
+	if !reflect.DeepEqual(g, map[bool][]int{true: {0, 2, 4}, false: {1, 3}}) {
+		t.Fatal("GroupBy:", g)
+	}
+	if ret := SortBy(Of([]string{"ccc", "a", "bb", "d"}), func(v string) int { return len(v) }); !reflect.DeepEqual(ret, []string{"a", "d", "bb", "ccc"}) {
+		t.Fatal("SortBy:", ret)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	a := []int{3, 1, 4, 1, 5}
+	if ret := SliceMap(a, func(v int) float64 { return float64(v) / 2 }); !reflect.DeepEqual(ret, []float64{1.5, 0.5, 2, 0.5, 2.5}) {
+		t.Fatal("SliceMap:", ret)
+	}
+	if ret := SliceFilter(a, func(v int) bool { return v > 2 }); !reflect.DeepEqual(ret, []int{3, 4, 5}) {
+		t.Fatal("SliceFilter:", ret)
+	}
+	if ret := SliceTake(a, 2); !reflect.DeepEqual(ret, []int{3, 1}) || cap(ret) != 2 {
+		t.Fatal("SliceTake:", ret)
+	}
+	if ret := SliceTake(a, 10); len(ret) != 5 {
+		t.Fatal("SliceTake 10:", ret)
+	}
+	if ret := SliceTake(a, -1); len(ret) != 0 {
+		t.Fatal("SliceTake -1:", ret)
+	}
+	if ret := SliceZip(a, []string{"a", "b"}); !reflect.DeepEqual(ret, []Pair[int, string]{{3, "a"}, {1, "b"}}) {
+		t.Fatal("SliceZip:", ret)
+	}
+	if ret := SliceGroupBy(a, func(v int) int { return v % 2 }); !reflect.DeepEqual(ret, map[int][]int{1: {3, 1, 1, 5}, 0: {4}}) {
+		t.Fatal("SliceGroupBy:", ret)
+	}
+	if ret := SliceSum(a); ret != 14 {
+		t.Fatal("SliceSum:", ret)
+	}
+	if ret := SliceSortBy(a, func(v int) int { return -v }); !reflect.DeepEqual(ret, []int{5, 4, 3, 1, 1}) || a[0] != 3 {
+		t.Fatal("SliceSortBy:", ret)
+	}
+}
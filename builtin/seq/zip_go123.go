@@ -0,0 +1,37 @@
+//go:build go1.23
+// +build go1.23
+
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package seq
+
+import (
+	"iter"
+)
+
+// Zip returns a sequence of pairs of the elements of seq and seq2. It stops
+// when either sequence ends.
+func Zip[T, U any](seq func(yield func(T) bool), seq2 func(yield func(U) bool)) func(yield func(Pair[T, U]) bool) {
+	return func(yield func(Pair[T, U]) bool) {
+		next, stop := iter.Pull(iter.Seq[U](seq2))
+		defer stop()
+		seq(func(v T) bool {
+			v2, ok := next()
+			return ok && yield(Pair[T, U]{v, v2})
+		})
+	}
+}
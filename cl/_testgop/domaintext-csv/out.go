@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/gop/tpl/encoding/csv"
+)
+
+func main() {
+	t, _ := csv.New("name;age\nTom;18\n", ";")
+	fmt.Println(func() (_gop_ret []string) {
+		t.Gop_Enum(func(row csv.Row) {
+			_gop_ret = append(_gop_ret, row.Get("name"))
+		})
+		return
+	}())
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/gop/tpl/encoding/json"
+	"github.com/goplus/gop/tpl/encoding/json5"
+	"github.com/qiniu/x/errors"
+)
+
+func main() {
+	v := func() (_gop_ret *json.Value) {
+		var _gop_err error
+		_gop_ret, _gop_err = json.New("{\n\t// comments\n\tname: 'Ken',\n\ttags: [\"a\", \"b\",],\n}\n", "json5")
+		if _gop_err != nil {
+			_gop_err = errors.NewFrame(_gop_err, "json`> \"json5\"\n{\n\t// comments\n\tname: 'Ken',\n\ttags: [\"a\", \"b\",],\n}\n`", "cl/_testgop/domaintext-json5/in.gop", 1, "main.main")
+			panic(_gop_err)
+		}
+		return
+	}()
+	fmt.Println(v.Get("/tags/1"), v.Get("name"))
+	fmt.Println(json5.New(`{age: 15,}`))
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/gop/tpl/encoding/xml"
+)
+
+func main() {
+	doc, _ := xml.New(`<a><b id="1">hi</b></a>`)
+	fmt.Println(doc.Find("a/b")[0].Attr("id"))
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cl
+
+import (
+	"go/types"
+
+	"github.com/goplus/gogen"
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// asyncStart returns the function which starts an async function body with
+// the specified results, and the type of the future it returns:
+//
+//	()         => async.GoVoid:  *async.Future[struct{}]
+//	(error)    => async.GoErr:   *async.Future[struct{}]
+//	(T)        => async.GoValue: *async.Future[T]
+//	(T, error) => async.Go:      *async.Future[T]
+func asyncStart(ctx *blockCtx, results *types.Tuple, pos token.Pos) (start gogen.Ref, future types.Type) {
+	async := ctx.pkg.TryImport(asyncPkgPath)
+	if async.Types == nil {
+		panic(ctx.newCodeErrorf(pos, "async function requires package %s", asyncPkgPath))
+	}
+	var name string
+	var val types.Type = types.NewStruct(nil, nil)
+	switch n := results.Len(); {
+	case n == 0:
+		name = "GoVoid"
+	case n == 1 && isErrorType(results.At(0).Type()):
+		name = "GoErr"
+	case n == 1:
+		name, val = "GoValue", results.At(0).Type()
+	case n == 2 && isErrorType(results.At(1).Type()):
+		name, val = "Go", results.At(0).Type()
+	default:
+		panic(ctx.newCodeErrorf(pos, "invalid results of async function: want (), T, error or (T, error)"))
+	}
+	typ, err := types.Instantiate(nil, async.Ref("Future").Type(), []types.Type{val}, true)
+	if err != nil {
+		panic(ctx.newCodeErrorf(pos, "%v", err))
+	}
+	return async.Ref(name), types.NewPointer(typ)
+}
+
+func isErrorType(typ types.Type) bool {
+	return types.Identical(typ, tyError)
+}
+
+// toAsyncResults returns the results of an async function whose body has the
+// specified results, that is, a future of them.
+func toAsyncResults(ctx *blockCtx, results *types.Tuple, pos token.Pos) *types.Tuple {
+	_, future := asyncStart(ctx, results, pos)
+	return types.NewTuple(ctx.pkg.NewParam(token.NoPos, "", future))
+}
+
+// asyncOf returns the position of "async" keyword and the results of an async
+// function. It returns token.NoPos if fn isn't an async function.
+func asyncOf(fn ast.Node) (async token.Pos, results *ast.FieldList) {
+	switch fn := fn.(type) {
+	case *ast.FuncDecl:
+		return fn.Async, fn.Type.Results
+	case *ast.FuncLit:
+		return fn.Async, fn.Type.Results
+	}
+	return
+}
+
+// compileAsyncBody compiles the body of an async function:
+//
+//	async func f(...) (T, error) {
+//		...
+//	}
+//
+// into:
+//
+//	func f(...) *async.Future[T] {
+//		return async.Go(func() (T, error) {
+//			...
+//		})
+//	}
+func compileAsyncBody(ctx *blockCtx, pos token.Pos, in *ast.FieldList, body *ast.BlockStmt) {
+	pkg, cb := ctx.pkg, ctx.cb
+	results := toResults(ctx, in)
+	start, _ := asyncStart(ctx, results, pos)
+	cb.Val(start)
+	cb.NewClosure(nil, results, false).BodyStart(pkg, body)
+	compileStmts(ctx, body.List)
+	cb.End().Call(1).Return(1)
+}
+
+// isFuture checks if typ is a future (*async.Future[T]) of async functions.
+func isFuture(typ types.Type) bool {
+	if t, ok := typ.(*types.Pointer); ok {
+		if named, ok := t.Elem().(*types.Named); ok {
+			obj := named.Obj()
+			return obj.Name() == "Future" && obj.Pkg() != nil && obj.Pkg().Path() == asyncPkgPath
+		}
+	}
+	return false
+}
+
+// compileAwaitExpr compiles `await x` into x.Value(), or x.Await() if two
+// values are required:
+//
+//	v := await x        => v := x.Value()
+//	v, err := await x   => v, err := x.Await()
+//	v := await x?       => v := x.Await()?
+func compileAwaitExpr(ctx *blockCtx, v *ast.AwaitExpr, twoValue bool) {
+	cb := ctx.cb
+	compileExpr(ctx, v.X)
+	if typ := cb.Get(-1).Type; !isFuture(typ) {
+		panic(ctx.newCodeErrorf(v.X.Pos(), "cannot await %v (type %v): not a future", ctx.LoadExpr(v.X), typ))
+	}
+	name := "Value"
+	if twoValue {
+		name = "Await"
+	}
+	cb.MemberVal(name).CallWith(0, 0, v)
+}
+
+// -----------------------------------------------------------------------------
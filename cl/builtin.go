@@ -78,7 +78,9 @@ func initBuiltin(_ *gogen.Package, builtin *types.Package, os, fmt, ng, osx, bui
 }
 
 const (
-	osxPkgPath = "github.com/qiniu/x/gop/osx"
+	osxPkgPath   = "github.com/qiniu/x/gop/osx"
+	seqPkgPath   = "github.com/goplus/gop/builtin/seq"
+	asyncPkgPath = "github.com/goplus/gop/builtin/async"
 )
 
 func newBuiltinDefault(pkg *gogen.Package, conf *gogen.Config) *types.Package {
@@ -91,6 +93,8 @@ func newBuiltinDefault(pkg *gogen.Package, conf *gogen.Config) *types.Package {
 	ng := pkg.TryImport("github.com/qiniu/x/gop/ng")
 	strx := pkg.TryImport("github.com/qiniu/x/stringutil")
 	stringslice := pkg.TryImport("github.com/qiniu/x/stringslice")
+	seq := pkg.TryImport(seqPkgPath)
+	async := pkg.TryImport(asyncPkgPath)
 	pkg.TryImport("strconv")
 	pkg.TryImport("strings")
 	if ng.Types != nil {
@@ -102,6 +106,11 @@ func newBuiltinDefault(pkg *gogen.Package, conf *gogen.Config) *types.Package {
 		}
 	}
 	initBuiltin(pkg, builtin, os, fmt, ng, osx, buil, reflect)
+	if async.Types != nil {
+		initBuiltinFns(builtin, builtin.Scope(), async, []string{
+			"awaitAll", "awaitAny",
+		})
+	}
 	gogen.InitBuiltin(pkg, builtin, conf)
 	if strx.Types != nil {
 		ti := pkg.BuiltinTI(types.Typ[types.String])
@@ -127,7 +136,24 @@ func newBuiltinDefault(pkg *gogen.Package, conf *gogen.Config) *types.Package {
 			&gogen.BuiltinMethod{Name: "TrimSuffix", Fn: stringslice.Ref("TrimSuffix")},
 		)
 	}
+	if seq.Types != nil {
+		initSeqMethods(pkg.BuiltinTI(types.NewSlice(types.Typ[types.Int])), seq)
+		initSeqMethods(pkg.BuiltinTI(types.NewSlice(types.Typ[types.String])), seq)
+	}
 	return builtin
 }
 
+// seqMethods are builtin methods of sequences (iterator funcs) and slices.
+// A method of sequences is implemented by seq.<Name>, and a method of slices
+// is implemented by seq.Slice<Name>.
+var seqMethods = []string{
+	"Map", "Filter", "Take", "Zip", "GroupBy", "Sum", "SortBy",
+}
+
+func initSeqMethods(ti *gogen.BuiltinTI, seq gogen.PkgRef) {
+	for _, name := range seqMethods {
+		ti.AddMethods(&gogen.BuiltinMethod{Name: name, Fn: seq.Ref("Slice" + name)})
+	}
+}
+
 // -----------------------------------------------------------------------------
@@ -336,6 +336,8 @@ type pkgCtx struct {
 	projs    map[string]*gmxProject // .gmx => project
 	classes  map[*ast.File]*gmxClass
 	overpos  map[string]token.Pos // overload => pos
+	getters  map[token.Pos]bool   // pos of property getter => true
+	setters  map[token.Pos]bool   // pos of property setter => true
 	fset     *token.FileSet
 	syms     map[string]loader
 	lbinames []any // names that should load before initGopPkg (can be string/func or *ast.Ident/type)
@@ -376,6 +378,11 @@ type blockCtx struct {
 	fileScope *types.Scope // available when isGopFile
 	rec       *goxRecorder
 
+	yield     *types.Var               // available when compiling a generator
+	yieldStop *types.Var               // stop flag of yield in for range of iterator funcs
+	iterBrs   map[*ast.BranchStmt]bool // break/continue in for range of iterator funcs
+	iterRet   *iterReturn              // return in for range of iterator funcs
+
 	fileLine  bool
 	isClass   bool
 	isGopFile bool // is Go+ file or not
@@ -503,6 +510,8 @@ func NewPackage(pkgPath string, pkg *ast.Package, conf *Config) (p *gogen.Packag
 		projs:      make(map[string]*gmxProject),
 		classes:    make(map[*ast.File]*gmxClass),
 		overpos:    make(map[string]token.Pos),
+		getters:    make(map[token.Pos]bool),
+		setters:    make(map[token.Pos]bool),
 		syms:       make(map[string]loader),
 		generics:   make(map[string]bool),
 	}
@@ -1113,6 +1122,17 @@ func preloadFile(p *gogen.Package, ctx *blockCtx, f *ast.File, goFile string, ge
 		case *ast.FuncDecl:
 			preloadFuncDecl(d)
 
+		case *ast.PropDecl:
+			if d.Get != nil {
+				ctx.getters[d.Name.Pos()] = true
+			}
+			if d.Set != nil {
+				ctx.setters[d.Set.TokPos] = true
+			}
+			for _, fn := range propFuncs(d) {
+				preloadFuncDecl(fn)
+			}
+
 		case *ast.OverloadFuncDecl:
 			var recv *ast.Ident
 			if ctx.classRecv != nil { // in class file (.spx/.gmx)
@@ -1439,7 +1459,16 @@ func loadFuncBody(ctx *blockCtx, fn *gogen.Func, body *ast.BlockStmt, sigBase *t
 		}
 		cb.Call(n).EndStmt()
 	}
-	compileStmts(ctx, body.List)
+	old, oldRet := ctx.yield, ctx.iterRet
+	ctx.yield, ctx.iterRet = nil, nil
+	if async, results := asyncOf(src); async.IsValid() {
+		compileAsyncBody(ctx, async, results, body)
+	} else if iter, ok := generatorOf(fn, body); ok {
+		compileGenerator(ctx, iter, body)
+	} else {
+		compileStmts(ctx, body.List)
+	}
+	ctx.yield, ctx.iterRet = old, oldRet
 	if rec := ctx.recorder(); rec != nil {
 		switch fn := src.(type) {
 		case *ast.FuncDecl:
@@ -1547,7 +1576,8 @@ func loadVars(ctx *blockCtx, v *ast.ValueSpec, doc *ast.CommentGroup, global boo
 	}
 	varDefs := ctx.pkg.NewVarDefs(scope).SetComments(doc)
 	varDecl := varDefs.New(v.Names[0].Pos(), typ, names...)
-	if nv := len(v.Values); nv > 0 {
+	values := tupleRhs(len(names), v.Values)
+	if nv := len(values); nv > 0 {
 		cb := varDecl.InitStart(ctx.pkg)
 		if enableRecover {
 			defer func() {
@@ -1558,12 +1588,12 @@ func loadVars(ctx *blockCtx, v *ast.ValueSpec, doc *ast.CommentGroup, global boo
 			}()
 		}
 		if nv == 1 && len(names) == 2 {
-			compileExpr(ctx, v.Values[0], clCallWithTwoValue)
+			compileExpr(ctx, values[0], clCallWithTwoValue)
 		} else {
-			for _, val := range v.Values {
+			for _, val := range values {
 				switch e := val.(type) {
 				case *ast.LambdaExpr, *ast.LambdaExpr2:
-					if len(v.Values) == 1 {
+					if len(values) == 1 {
 						sig, err := checkLambdaFuncType(ctx, e, typ, clLambaAssign, v.Names[0])
 						if err != nil {
 							panic(err)
@@ -1576,11 +1606,16 @@ func loadVars(ctx *blockCtx, v *ast.ValueSpec, doc *ast.CommentGroup, global boo
 					compileSliceLit(ctx, e, typ)
 				case *ast.CompositeLit:
 					compileCompositeLit(ctx, e, typ, false)
+				case *ast.TupleLit:
+					compileTupleLit(ctx, e, typ)
 				default:
 					compileExpr(ctx, val)
 				}
 			}
 		}
+		if nv == 1 && len(names) > 1 { // var v0, v1, ... = tuple
+			nv = expandTuple(ctx, len(names))
+		}
 		cb.EndInit(nv)
 	}
 	defNames(ctx, v.Names, scope)
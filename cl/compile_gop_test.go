@@ -1656,3 +1656,863 @@ func main() {
 }
 `)
 }
+
+func TestGenerator(t *testing.T) {
+	gopClTest(t, `
+import "iter"
+
+func count(n int) iter.Seq[int] {
+	for i := 0; i < n; i++ {
+		yield i
+	}
+}
+
+func pairs() func(yield func(string, int) bool) {
+	yield "a", 1
+	yield "b", 2
+}
+
+for x in count(10) if x%2 == 1 {
+	if x > 5 {
+		break
+	}
+	echo x
+}
+for k, v := range pairs() {
+	if k == "a" {
+		continue
+	}
+	echo k, v
+}
+`, `package main
+
+import (
+	"fmt"
+	"iter"
+)
+
+func count(n int) iter.Seq[int] {
+	return func(_gop_yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !_gop_yield(i) {
+				return
+			}
+		}
+	}
+}
+func pairs() func(yield func(string, int) bool) {
+	return func(_gop_yield func(string, int) bool) {
+		if !_gop_yield("a", 1) {
+			return
+		}
+		if !_gop_yield("b", 2) {
+			return
+		}
+	}
+}
+func main() {
+	count(10)(func(x int) bool {
+		if x%2 == 1 {
+			if x > 5 {
+				return false
+			}
+			fmt.Println(x)
+		}
+		return true
+	})
+	pairs()(func(k string, v int) bool {
+		if k == "a" {
+			return true
+		}
+		fmt.Println(k, v)
+		return true
+	})
+}
+`)
+}
+
+func TestGeneratorRangeIter(t *testing.T) {
+	gopClTest(t, `
+func count(n int) func(yield func(int) bool) {
+	for i := 0; i < n; i++ {
+		yield i
+	}
+}
+
+func evens(n int) func(yield func(int) bool) {
+	for x in count(n) if x%2 == 0 {
+		for y in count(2) {
+			yield x + y
+		}
+	}
+	for x in count(1) {
+		yield x
+	}
+}
+
+for x in evens(10) {
+	if x > 4 {
+		break
+	}
+	echo x
+}
+`, `package main
+
+import "fmt"
+
+func count(n int) func(yield func(int) bool) {
+	return func(_gop_yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !_gop_yield(i) {
+				return
+			}
+		}
+	}
+}
+func evens(n int) func(yield func(int) bool) {
+	return func(_gop_yield func(int) bool) {
+		var _gop_stop bool
+		count(n)(func(x int) bool {
+			if x%2 == 0 {
+				count(2)(func(y int) bool {
+					if !_gop_yield(x + y) {
+						_gop_stop = true
+						return false
+					}
+					return true
+				})
+				if _gop_stop {
+					return false
+				}
+			}
+			return true
+		})
+		if _gop_stop {
+			return
+		}
+		count(1)(func(x int) bool {
+			if !_gop_yield(x) {
+				_gop_stop = true
+				return false
+			}
+			return true
+		})
+		if _gop_stop {
+			return
+		}
+	}
+}
+func main() {
+	evens(10)(func(x int) bool {
+		if x > 4 {
+			return false
+		}
+		fmt.Println(x)
+		return true
+	})
+}
+`)
+}
+
+func TestIterReturn(t *testing.T) {
+	gopClTest(t, `
+func count(n int) func(yield func(int) bool) {
+	for i := 0; i < n; i++ {
+		yield i
+	}
+}
+
+func find(n int) (int, bool) {
+	for x in count(n) {
+		for y in count(x) {
+			if x*y > 6 {
+				return x, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func first(n int) (ret int, err error) {
+	for x in count(n) {
+		ret = x
+		return
+	}
+	return
+}
+
+func evens(n int) func(yield func(int) bool) {
+	for x in count(n) {
+		if x > 6 {
+			return
+		}
+		yield x * 2
+	}
+}
+
+for x in count(3) {
+	if x == 1 {
+		return
+	}
+	echo x, func() int {
+		for y in count(x) {
+			return y
+		}
+		return -1
+	}()
+}
+`, `package main
+
+import "fmt"
+
+func count(n int) func(yield func(int) bool) {
+	return func(_gop_yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !_gop_yield(i) {
+				return
+			}
+		}
+	}
+}
+func find(n int) (int, bool) {
+	var _gop_return bool
+	var _gop_r0 int
+	var _gop_r1 bool
+	count(n)(func(x int) bool {
+		count(x)(func(y int) bool {
+			if x*y > 6 {
+				_gop_r0, _gop_r1 = x, true
+				_gop_return = true
+				return false
+			}
+			return true
+		})
+		if _gop_return {
+			return false
+		}
+		return true
+	})
+	if _gop_return {
+		return _gop_r0, _gop_r1
+	}
+	return 0, false
+}
+func first(n int) (ret int, err error) {
+	var _gop_return bool
+	count(n)(func(x int) bool {
+		ret = x
+		_gop_return = true
+		return false
+		return true
+	})
+	if _gop_return {
+		return
+	}
+	return
+}
+func evens(n int) func(yield func(int) bool) {
+	return func(_gop_yield func(int) bool) {
+		var _gop_return bool
+		var _gop_stop bool
+		count(n)(func(x int) bool {
+			if x > 6 {
+				_gop_return = true
+				return false
+			}
+			if !_gop_yield(x * 2) {
+				_gop_stop = true
+				return false
+			}
+			return true
+		})
+		if _gop_stop {
+			return
+		}
+		if _gop_return {
+			return
+		}
+	}
+}
+func main() {
+	var _gop_return bool
+	count(3)(func(x int) bool {
+		if x == 1 {
+			_gop_return = true
+			return false
+		}
+		fmt.Println(x, func() int {
+			var _gop_return bool
+			var _gop_r0 int
+			count(x)(func(y int) bool {
+				_gop_r0 = y
+				_gop_return = true
+				return false
+				return true
+			})
+			if _gop_return {
+				return _gop_r0
+			}
+			return -1
+		}())
+		return true
+	})
+	if _gop_return {
+		return
+	}
+}
+`)
+}
+
+func TestIterComprehension(t *testing.T) {
+	gopClTest(t, `
+func count(n int) func(yield func(int) bool) {
+	for i := 0; i < n; i++ {
+		yield i
+	}
+}
+
+echo [x*x for x in count(10) if x%2 == 1]
+y, ok := {x for x in count(10) if x > 3}
+echo y, ok
+echo {x for x in count(10) if x > 3}
+echo {x+y for y in count(x) if y > 0 for x in count(3)}
+
+for x in count(3) {
+outer:
+	for y := 0; y < 3; y++ {
+		for z := 0; z < 3; z++ {
+			if z > y {
+				continue outer
+			}
+		}
+		echo x, y
+	}
+}
+`, `package main
+
+import "fmt"
+
+func count(n int) func(yield func(int) bool) {
+	return func(_gop_yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !_gop_yield(i) {
+				return
+			}
+		}
+	}
+}
+func main() {
+	fmt.Println(func() (_gop_ret []int) {
+		count(10)(func(x int) bool {
+			if x%2 == 1 {
+				_gop_ret = append(_gop_ret, x*x)
+			}
+			return true
+		})
+		return
+	}())
+	y, ok := func() (_gop_ret int, _gop_ok bool) {
+		count(10)(func(x int) bool {
+			if x > 3 {
+				_gop_ret = x
+				_gop_ok = true
+				return false
+			}
+			return true
+		})
+		return
+	}()
+	fmt.Println(y, ok)
+	fmt.Println(func() (_gop_ret int) {
+		count(10)(func(x int) bool {
+			if x > 3 {
+				_gop_ret = x
+				return false
+			}
+			return true
+		})
+		return
+	}())
+	fmt.Println(func() (_gop_ret int) {
+		count(3)(func(x int) bool {
+			var _gop_found bool
+			count(x)(func(y int) bool {
+				if y > 0 {
+					_gop_ret = x + y
+					_gop_found = true
+					return false
+				}
+				return true
+			})
+			if _gop_found {
+				return false
+			}
+			return true
+		})
+		return
+	}())
+	count(3)(func(x int) bool {
+	outer:
+		for y := 0; y < 3; y++ {
+			for z := 0; z < 3; z++ {
+				if z > y {
+					continue outer
+				}
+			}
+			fmt.Println(x, y)
+		}
+		return true
+	})
+}
+`)
+}
+
+func TestLazyComprehension(t *testing.T) {
+	gopClTest(t, `
+a := [1, 2, 3, 4]
+s := (x*x for x in a if x > 1)
+for v in s {
+	echo v
+}
+`, `package main
+
+import "fmt"
+
+func main() {
+	a := []int{1, 2, 3, 4}
+	s := func(_gop_yield func(int) bool) {
+		for _, x := range a {
+			if x > 1 {
+				if !_gop_yield(x * x) {
+					return
+				}
+			}
+		}
+	}
+	s(func(v int) bool {
+		fmt.Println(v)
+		return true
+	})
+}
+`)
+}
+
+func TestSeqMethods(t *testing.T) {
+	gopClTest(t, `
+a := [1, 2, 3, 4]
+echo a.filter(x => x > 1).map(x => x*2).sum()
+echo a.take(2), a.zip(["a", "b"]), a.groupBy(x => x%2), a.sortBy(x => -x)
+
+s := (x*x for x in a)
+echo s.filter(x => x > 4).map(x => float64(x)/2).take(1).sortBy(x => -x)
+echo s.sum
+echo s.zip(s.map(x => x > 4)).take(2).sortBy(p => -p.Key)
+`, `package main
+
+import (
+	"fmt"
+	"github.com/goplus/gop/builtin/seq"
+)
+
+func main() {
+	a := []int{1, 2, 3, 4}
+	fmt.Println(seq.SliceSum(seq.SliceMap(seq.SliceFilter(a, func(x int) bool {
+		return x > 1
+	}), func(x int) int {
+		return x * 2
+	})))
+	fmt.Println(seq.SliceTake(a, 2), seq.SliceZip(a, []string{"a", "b"}), seq.SliceGroupBy(a, func(x int) int {
+		return x % 2
+	}), seq.SliceSortBy(a, func(x int) int {
+		return -x
+	}))
+	s := func(_gop_yield func(int) bool) {
+		for _, x := range a {
+			if !_gop_yield(x * x) {
+				return
+			}
+		}
+	}
+	fmt.Println(seq.SortBy(seq.Take(seq.Map(seq.Filter(s, func(x int) bool {
+		return x > 4
+	}), func(x int) float64 {
+		return float64(x) / 2
+	}), 1), func(x float64) float64 {
+		return -x
+	}))
+	fmt.Println(seq.Sum(s))
+	fmt.Println(seq.SortBy(seq.Take(seq.Zip(s, seq.Map(s, func(x int) bool {
+		return x > 4
+	})), 2), func(p seq.Pair[int, bool]) int {
+		return -p.Key
+	}))
+}
+`)
+}
+
+func TestTupleType(t *testing.T) {
+	gopClTest(t, `
+type Pair (string, int)
+
+func newPair(k string, v int) Pair {
+	return (k, v)
+}
+
+var m map[string](float64, bool)
+
+pairs := [](string, int){("a", 1), ("b", 2)}
+pairs = append(pairs, ("c", 3))
+m = {"x": (1, true)}
+p := ("x", 1.5)
+println pairs, m, p, newPair("y", 2)
+`, `package main
+
+import "fmt"
+
+type Pair struct {
+	X_0 string
+	X_1 int
+}
+
+func newPair(k string, v int) Pair {
+	return Pair{k, v}
+}
+
+var m map[string]struct {
+	X_0 float64
+	X_1 bool
+}
+
+func main() {
+	pairs := []struct {
+		X_0 string
+		X_1 int
+	}{struct {
+		X_0 string
+		X_1 int
+	}{"a", 1}, struct {
+		X_0 string
+		X_1 int
+	}{"b", 2}}
+	pairs = append(pairs, struct {
+		X_0 string
+		X_1 int
+	}{"c", 3})
+	m = map[string]struct {
+		X_0 float64
+		X_1 bool
+	}{"x": struct {
+		X_0 float64
+		X_1 bool
+	}{1, true}}
+	p := struct {
+		X_0 string
+		X_1 float64
+	}{"x", 1.5}
+	fmt.Println(pairs, m, p, newPair("y", 2))
+}
+`)
+}
+
+func TestTupleDestructure(t *testing.T) {
+	gopClTest(t, `
+func newPair() (string, int) {
+	return "a", 1
+}
+
+func split(p (string, int)) (string, int) {
+	k, v := p
+	return k, v
+}
+
+var a, b = (1, "x")
+
+pairs := [("a", 1)]
+k, v := pairs[0]
+k, v = newPair()
+var x, y = split(pairs[0])
+println a, b, k, v, x, y
+`, `package main
+
+import "fmt"
+
+func newPair() (string, int) {
+	return "a", 1
+}
+func split(p struct {
+	X_0 string
+	X_1 int
+}) (string, int) {
+	k, v := p.X_0, p.X_1
+	return k, v
+}
+
+var a, b = 1, "x"
+
+func main() {
+	pairs := []struct {
+		X_0 string
+		X_1 int
+	}{struct {
+		X_0 string
+		X_1 int
+	}{"a", 1}}
+	var _autoGo_1 string
+	var _autoGo_2 int
+	{
+		var _autoGo_3 struct {
+			X_0 string
+			X_1 int
+		} = pairs[0]
+		_autoGo_2 = _autoGo_3.X_1
+		_autoGo_1 = _autoGo_3.X_0
+		goto _autoGo_4
+	_autoGo_4:
+	}
+	k, v := _autoGo_1, _autoGo_2
+	k, v = newPair()
+	var x, y = split(pairs[0])
+	fmt.Println(a, b, k, v, x, y)
+}
+`)
+}
+
+func TestTupleForPhrase(t *testing.T) {
+	gopClTest(t, `
+import "iter"
+
+func all(pairs [](string, int)) iter.Seq[(string, int)] {
+	for p in pairs {
+		yield p
+	}
+}
+
+pairs := [("a", 1), ("b", 2)]
+for i, (k, v) in pairs if v > 1 {
+	println i, k, v
+}
+for (k, v) in all(pairs) {
+	println k, v
+}
+println {k: v for (k, v) in pairs}
+`, `package main
+
+import (
+	"fmt"
+	"iter"
+)
+
+func all(pairs []struct {
+	X_0 string
+	X_1 int
+}) iter.Seq[struct {
+	X_0 string
+	X_1 int
+}] {
+	return func(_gop_yield func(struct {
+		X_0 string
+		X_1 int
+	}) bool) {
+		for _, p := range pairs {
+			if !_gop_yield(p) {
+				return
+			}
+		}
+	}
+}
+func main() {
+	pairs := []struct {
+		X_0 string
+		X_1 int
+	}{struct {
+		X_0 string
+		X_1 int
+	}{"a", 1}, struct {
+		X_0 string
+		X_1 int
+	}{"b", 2}}
+	for i, _gop_v := range pairs {
+		k, v := _gop_v.X_0, _gop_v.X_1
+		if v > 1 {
+			fmt.Println(i, k, v)
+		}
+	}
+	all(pairs)(func(_gop_v struct {
+		X_0 string
+		X_1 int
+	}) bool {
+		k, v := _gop_v.X_0, _gop_v.X_1
+		fmt.Println(k, v)
+		return true
+	})
+	fmt.Println(func() (_gop_ret map[string]int) {
+		_gop_ret = map[string]int{}
+		for _, _gop_v := range pairs {
+			k, v := _gop_v.X_0, _gop_v.X_1
+			_gop_ret[k] = v
+		}
+		return
+	}())
+}
+`)
+}
+
+func TestAsyncFunc(t *testing.T) {
+	gopClTest(t, `
+import "strconv"
+
+async func parse(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+async func square(x int) int {
+	return x * x
+}
+
+async func work() {
+	println "working"
+}
+
+func sum(a, b string) (int, error) {
+	x, y := parse(a), parse(b)
+	return await x? + await y?, nil
+}
+
+f := async func(s string) error {
+	_, err := await parse(s)
+	return err
+}
+v, err := await parse("12")
+println v, err, await square(3)
+await work()
+println sum("1", "2")!, await f("1")
+println awaitAll(square(1), square(2))!, awaitAny(parse("3"))?:0
+`, `package main
+
+import (
+	"fmt"
+	"github.com/goplus/gop/builtin/async"
+	"github.com/qiniu/x/errors"
+	"strconv"
+)
+
+func parse(s string) *async.Future[int] {
+	return async.Go(func() (int, error) {
+		return strconv.Atoi(s)
+	})
+}
+func square(x int) *async.Future[int] {
+	return async.GoValue(func() int {
+		return x * x
+	})
+}
+func work() *async.Future[struct {
+}] {
+	return async.GoVoid(func() {
+		fmt.Println("working")
+	})
+}
+func sum(a string, b string) (int, error) {
+	x, y := parse(a), parse(b)
+	var _autoGo_1 int
+	{
+		var _gop_err error
+		_autoGo_1, _gop_err = x.Await()
+		if _gop_err != nil {
+			_gop_err = errors.NewFrame(_gop_err, "await x", "/foo/bar.gop", 18, "main.sum")
+			return 0, _gop_err
+		}
+		goto _autoGo_2
+	_autoGo_2:
+	}
+	var _autoGo_3 int
+	{
+		var _gop_err error
+		_autoGo_3, _gop_err = y.Await()
+		if _gop_err != nil {
+			_gop_err = errors.NewFrame(_gop_err, "await y", "/foo/bar.gop", 18, "main.sum")
+			return 0, _gop_err
+		}
+		goto _autoGo_4
+	_autoGo_4:
+	}
+	return _autoGo_1 + _autoGo_3, nil
+}
+func main() {
+	f := func(s string) *async.Future[struct {
+	}] {
+		return async.GoErr(func() error {
+			_, err := parse(s).Await()
+			return err
+		})
+	}
+	v, err := parse("12").Await()
+	fmt.Println(v, err, square(3).Value())
+	work().Value()
+	fmt.Println(func() (_gop_ret int) {
+		var _gop_err error
+		_gop_ret, _gop_err = sum("1", "2")
+		if _gop_err != nil {
+			_gop_err = errors.NewFrame(_gop_err, "sum(\"1\", \"2\")", "/foo/bar.gop", 28, "main.main")
+			panic(_gop_err)
+		}
+		return
+	}(), f("1").Value())
+	fmt.Println(func() (_gop_ret []int) {
+		var _gop_err error
+		_gop_ret, _gop_err = async.AwaitAll(square(1), square(2))
+		if _gop_err != nil {
+			_gop_err = errors.NewFrame(_gop_err, "awaitAll(square(1), square(2))", "/foo/bar.gop", 29, "main.main")
+			panic(_gop_err)
+		}
+		return
+	}(), func() (_gop_ret int) {
+		var _gop_err error
+		_gop_ret, _gop_err = async.AwaitAny(parse("3"))
+		if _gop_err != nil {
+			return 0
+		}
+		return
+	}())
+}
+`)
+}
+
+func TestAsyncMethod(t *testing.T) {
+	gopClTest(t, `
+type T struct {
+	n int
+}
+
+async func (p *T) incr(d int) (n int) {
+	p.n += d
+	n = p.n
+	return
+}
+
+p := &T{}
+println await p.incr(2)
+`, `package main
+
+import (
+	"fmt"
+	"github.com/goplus/gop/builtin/async"
+)
+
+type T struct {
+	n int
+}
+
+func (p *T) incr(d int) *async.Future[int] {
+	return async.GoValue(func() (n int) {
+		p.n += d
+		n = p.n
+		return
+	})
+}
+func main() {
+	p := &T{}
+	fmt.Println(p.incr(2).Value())
+}
+`)
+}
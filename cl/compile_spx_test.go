@@ -1156,3 +1156,91 @@ func (this *Rect) Test() {
 }
 `, "Rect.gox")
 }
+
+func TestClassFileProp(t *testing.T) {
+	gopClTestFile(t, `
+import "strings"
+
+var (
+	first, last string
+	age         int
+)
+
+// FullName is the full name of the user.
+prop FullName string {
+	get => first + " " + last
+	set v => {
+		first, last, _ = strings.cut(v, " ")
+	}
+}
+
+prop Age int {
+	get => age
+	set v => setAge(v)
+}
+
+prop adult bool {
+	get => age >= 18
+}
+
+func setAge(v int) {
+	if v < 0 {
+		v = 0
+	}
+	age = v
+}
+
+func Test() {
+	u := &User{}
+	u.fullName = "Ken Thompson"
+	u.Age = 82
+	u.age = 1
+	echo u.fullName, u.adult
+	f := u.FullName
+	echo f(), u.Age(), u.adult()
+}
+`, `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type User struct {
+	first string
+	last  string
+	age   int
+}
+// FullName is the full name of the user.
+func (this *User) FullName() string {
+	return this.first + " " + this.last
+}
+func (this *User) SetFullName(v string) {
+	this.first, this.last, _ = strings.Cut(v, " ")
+}
+func (this *User) Age() int {
+	return this.age
+}
+func (this *User) SetAge(v int) {
+	this.setAge(v)
+}
+func (this *User) adult() bool {
+	return this.age >= 18
+}
+func (this *User) setAge(v int) {
+	if v < 0 {
+		v = 0
+	}
+	this.age = v
+}
+func (this *User) Test() {
+	u := &User{}
+	u.SetFullName("Ken Thompson")
+	u.SetAge(82)
+	u.age = 1
+	fmt.Println(u.FullName(), u.adult())
+	f := u.FullName
+	fmt.Println(f(), u.Age(), u.adult())
+}
+`, "User.gox")
+}
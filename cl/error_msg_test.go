@@ -914,6 +914,29 @@ n, err := fmt.println
 `)
 }
 
+func TestErrProp(t *testing.T) {
+	codeErrorTestEx(t, "main", "User.gox", `User.gox:14:8: operator += not supported on property u.Age
+User.gox:15:7: operator ++ not supported on property u.Age
+User.gox:16:2: u.level undefined (type *User has no field or method level)`, `
+var age int
+
+prop Age int {
+	get => age
+	set v => { age = v }
+}
+
+func SetLevel(v int) {
+}
+
+func Test() {
+	u := &User{}
+	u.Age += 1
+	u.Age++
+	u.level = 1
+}
+`)
+}
+
 func TestFiledsNameRedecl(t *testing.T) {
 	codeErrorTest(t, `bar.gop:6:2: Id redeclared
 	bar.gop:5:2 other declaration of Id
@@ -1117,3 +1140,87 @@ var a = struct{v int}{(x => x)}
 var a = struct{v int}{v: (x => x)}
 `)
 }
+
+func TestErrRangeIterFunc(t *testing.T) {
+	codeErrorTest(t, `bar.gop:5:3: not enough arguments to return
+	have ()
+	want (int)`, `
+var seq func(yield func(int) bool)
+func f() int {
+	for x in seq {
+		return
+	}
+	return 0
+}
+`)
+	codeErrorTest(t, `bar.gop:5:3: too many arguments to return
+	have (int, untyped int)
+	want (int)`, `
+var seq func(yield func(int) bool)
+func f() int {
+	for x in seq {
+		return x, 1
+	}
+	return 0
+}
+`)
+	codeErrorTest(t, `bar.gop:3:1: range over seq permits only one iteration variable`, `
+var seq func(yield func(int) bool)
+for k, v := range seq {
+}
+`)
+	codeErrorTest(t, `bar.gop:6:3: labeled continue across for range of iterator func not supported`, `
+var seq func(yield func(int) bool)
+outer:
+for i := 0; i < 3; i++ {
+	for x in seq {
+		continue outer
+	}
+	break outer
+}
+`)
+}
+
+func TestErrTuple(t *testing.T) {
+	codeErrorTest(t, `bar.gop:2:10: use of untyped nil in tuple literal`, `
+t := (1, nil)
+`)
+	codeErrorTest(t, `bar.gop:3:5: cannot destructure a (type int) into 2 variables`, `
+a := [1, 2]
+for (x, y) in a {
+}
+`)
+}
+
+func TestErrAsync(t *testing.T) {
+	codeErrorTest(t, `bar.gop:2:1: invalid results of async function: want (), T, error or (T, error)`, `
+async func f() (int, string) {
+	return 1, "a"
+}
+`)
+	codeErrorTest(t, `bar.gop:3:7: cannot await ch (type chan int): not a future`, `
+ch := make(chan int)
+await ch
+`)
+}
+
+func TestErrXmlLit(t *testing.T) {
+	codeErrorTest(t, `bar.gop:3:1: invalid xml literal: unexpected end element </b>`, `
+doc, _ := xml`+"`<a>\n</b>`"+`
+`)
+	codeErrorTest(t, `bar.gop:2:15: invalid xml literal: undefined namespace prefix: x`, `
+doc, _ := xml`+"`<x:a/>`"+`
+`)
+}
+
+func TestErrCsvLit(t *testing.T) {
+	codeErrorTest(t, `bar.gop:4:1: invalid csv literal: wrong number of fields`, `
+t, _ := csv`+"`name,age\nTom,18\nJerry\n`"+`
+`)
+	codeErrorTest(t, `bar.gop:5:1: invalid csv literal: wrong number of fields`, `
+t, _ := csv`+"`> \";\"\nname;age\nTom;18\nJerry\n`"+`
+`)
+	codeErrorTest(t, `bar.gop:2:15: invalid csv literal: csv: invalid delimiter ";;", should be a single character`, `
+t, _ := csv`+"`> \";;\"\nname\n`"+`
+`)
+}
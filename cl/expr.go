@@ -33,6 +33,8 @@ import (
 	"github.com/goplus/gop/printer"
 	"github.com/goplus/gop/token"
 	tpl "github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/encoding/csv"
+	"github.com/goplus/gop/tpl/encoding/xml"
 )
 
 /*-----------------------------------------------------------------------------
@@ -258,6 +260,9 @@ func compileMember(ctx *blockCtx, v ast.Node, name string, flags int) error {
 		mflag = gogen.MemberFlagMethodAlias
 	}
 	_, err := ctx.cb.Member(name, mflag, v)
+	if err != nil && mflag != gogen.MemberFlagRef && compileSeqMember(ctx, v, name, mflag) {
+		return nil
+	}
 	return err
 }
 
@@ -268,7 +273,7 @@ func compileExprLHS(ctx *blockCtx, expr ast.Expr) {
 	case *ast.IndexExpr:
 		compileIndexExprLHS(ctx, v)
 	case *ast.SelectorExpr:
-		compileSelectorExprLHS(ctx, v)
+		compileSelectorExprLHS(ctx, v, false)
 	case *ast.StarExpr:
 		compileStarExprLHS(ctx, v)
 	default:
@@ -358,6 +363,8 @@ func compileExpr(ctx *blockCtx, expr ast.Expr, inFlags ...int) {
 		compileCompositeLit(ctx, v, nil, false)
 	case *ast.SliceLit:
 		compileSliceLit(ctx, v, nil)
+	case *ast.TupleLit:
+		compileTupleLit(ctx, v, nil)
 	case *ast.RangeExpr:
 		compileRangeExpr(ctx, v)
 	case *ast.IndexExpr:
@@ -374,18 +381,26 @@ func compileExpr(ctx *blockCtx, expr ast.Expr, inFlags ...int) {
 		ctx.cb.Typ(toMapType(ctx, v), v)
 	case *ast.StructType:
 		ctx.cb.Typ(toStructType(ctx, v), v)
+	case *ast.TupleType:
+		ctx.cb.Typ(toTupleType(ctx, v), v)
 	case *ast.ChanType:
 		ctx.cb.Typ(toChanType(ctx, v), v)
 	case *ast.InterfaceType:
 		ctx.cb.Typ(toInterfaceType(ctx, v), v)
 	case *ast.ComprehensionExpr:
-		compileComprehensionExpr(ctx, v, twoValue(inFlags))
+		if v.Tok == token.LPAREN {
+			compileLazyComprehension(ctx, v)
+		} else {
+			compileComprehensionExpr(ctx, v, twoValue(inFlags))
+		}
 	case *ast.TypeAssertExpr:
 		compileTypeAssertExpr(ctx, v, twoValue(inFlags))
 	case *ast.ParenExpr:
 		compileExpr(ctx, v.X, inFlags...)
 	case *ast.ErrWrapExpr:
 		compileErrWrapExpr(ctx, v, 0)
+	case *ast.AwaitExpr:
+		compileAwaitExpr(ctx, v, twoValue(inFlags))
 	case *ast.FuncType:
 		ctx.cb.Typ(toFuncType(ctx, v, nil, nil), v)
 	case *ast.EnvExpr:
@@ -449,7 +464,11 @@ func compileTypeAssertExpr(ctx *blockCtx, v *ast.TypeAssertExpr, twoValue bool)
 func compileIndexExpr(ctx *blockCtx, v *ast.IndexExpr, inFlags ...int) { // x[i]
 	compileExpr(ctx, v.X, inFlags...)
 	compileExpr(ctx, v.Index)
-	ctx.cb.Index(1, twoValue(inFlags), v)
+	twoValue := twoValue(inFlags)
+	if twoValue && isTupleList(ctx.cb.Get(-2).Type) { // v0, v1 := tuples[i]
+		twoValue = false
+	}
+	ctx.cb.Index(1, twoValue, v)
 }
 
 func compileIndexListExpr(ctx *blockCtx, v *ast.IndexListExpr, inFlags ...int) { // fn[t1,t2]
@@ -471,7 +490,10 @@ func compileSliceExpr(ctx *blockCtx, v *ast.SliceExpr) { // x[i:j:k]
 	ctx.cb.Slice(v.Slice3, v)
 }
 
-func compileSelectorExprLHS(ctx *blockCtx, v *ast.SelectorExpr) {
+// compileSelectorExprLHS compiles v as the left side of an assignment. If
+// allowSetter is true and v is a property with a setter (see propSetter), it
+// pushes the setter instead and returns true.
+func compileSelectorExprLHS(ctx *blockCtx, v *ast.SelectorExpr, allowSetter bool) (setter bool) {
 	switch x := v.X.(type) {
 	case *ast.Ident:
 		if at, kind := compileIdent(ctx, x, clIdentLHS|clIdentSelectorExpr); kind != objNormal {
@@ -481,7 +503,15 @@ func compileSelectorExprLHS(ctx *blockCtx, v *ast.SelectorExpr) {
 	default:
 		compileExpr(ctx, v.X)
 	}
-	ctx.cb.MemberRef(v.Sel.Name, v)
+	cb := ctx.cb
+	if allowSetter {
+		if name, ok := propSetter(ctx, cb.Get(-1).Type, v.Sel.Name); ok {
+			cb.MemberVal(name, v)
+			return true
+		}
+	}
+	cb.MemberRef(v.Sel.Name, v)
+	return
 }
 
 func compileSelectorExpr(ctx *blockCtx, v *ast.SelectorExpr, flags int) {
@@ -499,9 +529,13 @@ func compileSelectorExpr(ctx *blockCtx, v *ast.SelectorExpr, flags int) {
 	default:
 		compileExpr(ctx, v.X)
 	}
+	getter := flags&clIdentCanAutoCall != 0 && isPropGetter(ctx, ctx.cb.Get(-1).Type, v.Sel.Name)
 	if err := compileMember(ctx, v, v.Sel.Name, flags); err != nil {
 		panic(err)
 	}
+	if getter { // x.prop => x.Prop()
+		ctx.cb.CallWith(0, 0, v)
+	}
 }
 
 func compileFuncAlias(ctx *blockCtx, scope *types.Scope, x *ast.Ident, flags int) bool {
@@ -594,6 +628,8 @@ type fnType struct {
 	typetype     bool
 	typeparam    bool
 	typeAsParams bool
+	this         *gogen.Element // receiver of builtin methods of slices or sequences
+	thisArg      bool           // receiver is passed as an argument (builtin methods of sequences)
 }
 
 func (p *fnType) arg(i int, ellipsis bool) types.Type {
@@ -621,6 +657,19 @@ func (p *fnType) init(base int, t *types.Signature, typeAsParams bool) {
 	}
 }
 
+// initRecv inits a builtin method of slices or sequences. Its receiver this is
+// passed as the first argument of fn.
+func (p *fnType) initRecv(fn, this *gogen.Element) bool {
+	sig, ok := fn.Type.(*types.Signature)
+	if !ok || sig.Params().Len() == 0 {
+		return false
+	}
+	p.init(1, sig, false)
+	_, p.thisArg = iterFunc(this.Type)
+	p.this = &gogen.Element{Val: this.Val, Type: this.Type.Underlying(), Src: this.Src}
+	return true
+}
+
 func (p *fnType) initTypeType(t *gogen.TypeType) {
 	param := types.NewParam(0, nil, "", t.Type())
 	p.params, p.typetype = types.NewTuple(param), true
@@ -665,6 +714,8 @@ func (p *fnType) initFuncs(base int, funcs []types.Object, typeAsParams bool) {
 
 func compileCallExpr(ctx *blockCtx, v *ast.CallExpr, inFlags int) {
 	var ifn *ast.Ident
+	var recv bool
+	var stk = ctx.cb.InternalStack()
 	switch fn := v.Fun.(type) {
 	case *ast.Ident:
 		if v.IsCommand() { // for support Gop_Exec, see TestSpxGopExec
@@ -679,7 +730,9 @@ func compileCallExpr(ctx *blockCtx, v *ast.CallExpr, inFlags int) {
 			ifn = fn
 		}
 	case *ast.SelectorExpr:
+		n := stk.Len()
 		compileSelectorExpr(ctx, fn, 0)
+		recv = stk.Len() == n+2 // builtin methods: fn, this
 	case *ast.ErrWrapExpr:
 		if v.IsCommand() {
 			callExpr := *v
@@ -694,20 +747,23 @@ func compileCallExpr(ctx *blockCtx, v *ast.CallExpr, inFlags int) {
 		compileExpr(ctx, fn, clInCallExpr)
 	}
 	var err error
-	var stk = ctx.cb.InternalStack()
 	var base = stk.Len()
 	var flags gogen.InstrFlags
 	var ellipsis = v.Ellipsis != gotoken.NoPos
 	if ellipsis {
 		flags = gogen.InstrFlagEllipsis
 	}
-	if (inFlags & clCallWithTwoValue) != 0 {
+	if (inFlags&clCallWithTwoValue) != 0 && !returnsTuple(stk.Get(-1).Type) {
 		flags |= gogen.InstrFlagTwoValue
 	}
 	pfn := stk.Get(-1)
 	fnt := pfn.Type
 	fn := &fnType{}
-	fn.load(fnt)
+	if !recv || !fn.initRecv(stk.Get(-2), pfn) {
+		fn.load(fnt)
+	} else {
+		pfn = stk.Get(-2)
+	}
 	for fn != nil {
 		if err = compileCallArgs(ctx, pfn, fn, v, ellipsis, flags); err == nil {
 			if rec := ctx.recorder(); rec != nil {
@@ -830,6 +886,8 @@ func compileCallArgs(ctx *blockCtx, pfn *gogen.Element, fn *fnType, v *ast.CallE
 			if err = compileCompositeLitEx(ctx, expr, t, true); err != nil {
 				return
 			}
+		case *ast.TupleLit:
+			compileTupleLit(ctx, expr, t)
 		case *ast.SliceLit:
 			switch t.(type) {
 			case *types.Slice:
@@ -864,17 +922,27 @@ func compileCallArgs(ctx *blockCtx, pfn *gogen.Element, fn *fnType, v *ast.CallE
 	}
 	if needInferFunc {
 		args := ctx.cb.InternalStack().GetArgs(len(v.Args))
+		if fn.this != nil {
+			args = append([]*gogen.Element{fn.this}, args...)
+		}
 		typ, err := gogen.InferFunc(ctx.pkg, pfn, fn.sig, nil, args, flags)
 		if err != nil {
-			return err
+			var ok bool
+			if typ, ok = inferLambdaFunc(ctx, fn, v, args); !ok {
+				return err
+			}
 		}
-		next := &fnType{}
+		next := &fnType{this: fn.this, thisArg: fn.thisArg}
 		next.init(fn.base, typ.(*types.Signature), false)
 		next.next = fn.next
 		fn.next = next
 		return errCallNext
 	}
-	return ctx.cb.CallWithEx(len(v.Args), flags, v)
+	n := len(v.Args)
+	if fn.thisArg {
+		n++
+	}
+	return ctx.cb.CallWithEx(n, flags, v)
 }
 
 var (
@@ -1028,7 +1096,10 @@ func compileLambdaExpr2(ctx *blockCtx, v *ast.LambdaExpr2, sig *types.Signature)
 	if len(v.Lhs) > 0 {
 		defNames(ctx, v.Lhs, cb.Scope())
 	}
+	old, oldRet := ctx.yield, ctx.iterRet
+	ctx.yield, ctx.iterRet = nil, nil
 	compileStmts(ctx, v.Body.List)
+	ctx.yield, ctx.iterRet = old, oldRet
 	if rec := ctx.recorder(); rec != nil {
 		rec.Scope(v, ctx.cb.Scope())
 	}
@@ -1041,6 +1112,10 @@ func compileFuncLit(ctx *blockCtx, v *ast.FuncLit) {
 	cb := ctx.cb
 	comments, once := cb.BackupComments()
 	sig := toFuncType(ctx, v.Type, nil, nil)
+	if v.Async.IsValid() {
+		results := toAsyncResults(ctx, sig.Results(), v.Async)
+		sig = types.NewSignatureType(nil, nil, nil, sig.Params(), results, sig.Variadic())
+	}
 	if rec := ctx.recorder(); rec != nil {
 		rec.recordFuncLit(v, sig)
 	}
@@ -1206,6 +1281,13 @@ func compileDomainTextLit(ctx *blockCtx, v *ast.DomainTextLit) {
 			}
 		}
 	} else {
+		if lit, ok := v.Extra.(*ast.DomainTextLitEx); ok {
+			if args, ok := domainTextArgs(lit.Args); ok {
+				checkDomainText(ctx, path, v.Domain.Name, lit.Raw, lit.RawPos, lit.Args, args)
+			}
+		} else {
+			checkDomainText(ctx, path, v.Domain.Name, v.Value[1:len(v.Value)-1], v.ValuePos+1, nil, nil)
+		}
 		cb.Val(imp.Ref("New"))
 		if lit, ok := v.Extra.(*ast.DomainTextLitEx); ok {
 			cb.Val(lit.Raw)
@@ -1220,6 +1302,56 @@ func compileDomainTextLit(ctx *blockCtx, v *ast.DomainTextLit) {
 	cb.CallWith(n, 0, v)
 }
 
+// checkDomainText reports syntax errors of a domain text literal at compile
+// time, where text starts at pos, and args are the values of the arguments
+// exprs of the form domain`> args...`.
+func checkDomainText(ctx *blockCtx, path, domain, text string, pos token.Pos, exprs []ast.Expr, args []string) {
+	var line int
+	var err error
+	switch path {
+	case tplPkgPath + "/encoding/xml":
+		if _, err = xml.New(text); err != nil {
+			if e, ok := err.(*xml.SyntaxError); ok {
+				line, err = e.Line, errors.New(e.Msg)
+			}
+		}
+	case tplPkgPath + "/encoding/csv":
+		if _, err = csv.New(text, args...); err != nil {
+			if e, ok := err.(*csv.ParseError); ok {
+				line, err = e.Line, e.Err
+			} else if len(exprs) > 0 { // invalid delimiter
+				pos = exprs[0].Pos()
+			}
+		}
+	default:
+		return
+	}
+	if err != nil {
+		for ; line > 1; line-- {
+			i := strings.IndexByte(text, '\n') + 1
+			pos, text = pos+token.Pos(i), text[i:]
+		}
+		ctx.handleErrorf(pos, "invalid %s literal: %v", domain, err)
+	}
+}
+
+// domainTextArgs returns the values of the arguments of a domain text literal
+// if they are all string literals.
+func domainTextArgs(exprs []ast.Expr) (args []string, ok bool) {
+	for _, expr := range exprs {
+		lit, ok := expr.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return nil, false
+		}
+		v, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, false
+		}
+		args = append(args, v)
+	}
+	return args, true
+}
+
 func lambdaRetFunc(expr *ast.LambdaExpr2) *ast.LambdaExpr2 {
 	v := *expr
 	v.Lhs = []*ast.Ident{
@@ -1298,6 +1430,8 @@ func compileCompositeLitElt(ctx *blockCtx, e ast.Expr, typ types.Type, flag clLa
 		compileSliceLit(ctx, v, typ)
 	case *ast.CompositeLit:
 		compileCompositeLit(ctx, v, typ, false)
+	case *ast.TupleLit:
+		compileTupleLit(ctx, v, typ)
 	default:
 		compileExpr(ctx, v)
 	}
@@ -1546,6 +1680,40 @@ func comprehensionKind(v *ast.ComprehensionExpr) int {
 	panic("TODO: invalid comprehensionExpr")
 }
 
+const (
+	comprehensionEndBlock = iota // end of a for range or if statement
+	comprehensionEndIter         // end of a yield callback
+)
+
+// comprehensionFound returns the stop flag of {expr for ...} over iterator
+// funcs. It's _gop_ok if there is one, or else a local variable _gop_found,
+// which is only declared when there is an outer loop to stop (outer is true).
+func comprehensionFound(ctx *blockCtx, v *ast.ComprehensionExpr, results *types.Tuple, twoValue, outer bool) *types.Var {
+	if v.Elt == nil || twoValue {
+		return results.At(results.Len() - 1) // _gop_ok
+	}
+	if !outer {
+		return nil
+	}
+	cb := ctx.cb
+	cb.NewVar(types.Typ[types.Bool], "_gop_found")
+	return cb.Scope().Lookup("_gop_found").(*types.Var)
+}
+
+// comprehensionStop stops {expr for ...} after calling an iterator func:
+//
+//	if found { return false } // in a yield callback
+//	if found { return }
+func comprehensionStop(cb *gogen.CodeBuilder, found *types.Var, iters int) {
+	cb.If().Val(found).Then()
+	if iters > 0 {
+		cb.Val(false).Return(1)
+	} else {
+		cb.Return(0)
+	}
+	cb.End()
+}
+
 // [expr for k, v <- container, cond]
 // {for k, v <- container, cond}
 // {expr for k, v <- container, cond}
@@ -1571,26 +1739,43 @@ func compileComprehensionExpr(ctx *blockCtx, v *ast.ComprehensionExpr, twoValue
 	if kind == comprehensionMap {
 		cb.VarRef(ret).ZeroLit(ret.Type()).Assign(1)
 	}
-	end := 0
+	var found *types.Var // stop flag of {expr for ...} over iterator funcs
+	var iters int        // number of yield callbacks we are in
+	ends := make([]int, 0, 4)
 	for i := len(v.Fors) - 1; i >= 0; i-- {
-		names := make([]string, 0, 2)
-		defineNames := make([]*ast.Ident, 0, 2)
 		forStmt := v.Fors[i]
-		if forStmt.Key != nil {
-			names = append(names, forStmt.Key.Name)
-			defineNames = append(defineNames, forStmt.Key)
+		x, yield, isIter := compileRangeX(ctx, forStmt.X)
+		if isIter {
+			if kind == comprehensionSelect && found == nil {
+				found = comprehensionFound(ctx, v, results, twoValue, len(ends) > 0)
+			}
+			vars := iterVars(ctx, yield, forPhraseVars(forStmt, yield), forStmt)
+			startIterBody(ctx, x, yield, vars, forStmt)
+			ends = append(ends, comprehensionEndIter)
+			iters++
 		} else {
-			names = append(names, "_")
-		}
-		names = append(names, forStmt.Value.Name)
-		defineNames = append(defineNames, forStmt.Value)
-		cb.ForRange(names...)
-		compileExpr(ctx, forStmt.X)
-		cb.RangeAssignThen(forStmt.TokPos)
-		defNames(ctx, defineNames, cb.Scope())
-		if rec := ctx.recorder(); rec != nil {
-			rec.Scope(forStmt, cb.Scope())
+			names := make([]string, 0, 2)
+			defineNames := make([]*ast.Ident, 0, 2)
+			if forStmt.Key != nil {
+				names = append(names, forStmt.Key.Name)
+				defineNames = append(defineNames, forStmt.Key)
+			} else {
+				names = append(names, "_")
+			}
+			names = append(names, forPhraseValue(forStmt).Name)
+			if forStmt.Value != nil {
+				defineNames = append(defineNames, forStmt.Value)
+			}
+			cb.ForRange(names...)
+			cb.InternalStack().Push(x)
+			cb.RangeAssignThen(forStmt.TokPos)
+			defNames(ctx, defineNames, cb.Scope())
+			if rec := ctx.recorder(); rec != nil {
+				rec.Scope(forStmt, cb.Scope())
+			}
+			ends = append(ends, comprehensionEndBlock)
 		}
+		compileTupleVars(ctx, forStmt)
 		if forStmt.Cond != nil {
 			cb.If()
 			if forStmt.Init != nil {
@@ -1598,9 +1783,8 @@ func compileComprehensionExpr(ctx *blockCtx, v *ast.ComprehensionExpr, twoValue
 			}
 			compileExpr(ctx, forStmt.Cond)
 			cb.Then()
-			end++
+			ends = append(ends, comprehensionEndBlock)
 		}
-		end++
 	}
 	switch kind {
 	case comprehensionList:
@@ -1619,7 +1803,27 @@ func compileComprehensionExpr(ctx *blockCtx, v *ast.ComprehensionExpr, twoValue
 		compileExpr(ctx, kv.Value)
 		cb.Assign(1)
 	default:
-		if v.Elt == nil {
+		if iters > 0 {
+			// _gop_ret = elt
+			// found = true
+			// return false
+			if v.Elt != nil {
+				cb.VarRef(ret)
+				compileExpr(ctx, v.Elt)
+				cb.Assign(1)
+			}
+			if found != nil {
+				cb.VarRef(found).Val(true).Assign(1)
+			}
+			cb.Val(false).Return(1)
+			if ends[len(ends)-1] == comprehensionEndIter { // return false already
+				cb.End().Call(1).EndStmt()
+				ends, iters = ends[:len(ends)-1], iters-1
+				if len(ends) > 0 && found != nil {
+					comprehensionStop(cb, found, iters)
+				}
+			}
+		} else if v.Elt == nil {
 			// return true
 			cb.Val(true)
 			cb.Return(1)
@@ -1634,8 +1838,15 @@ func compileComprehensionExpr(ctx *blockCtx, v *ast.ComprehensionExpr, twoValue
 			cb.Return(n)
 		}
 	}
-	for i := 0; i < end; i++ {
-		cb.End()
+	for i := len(ends) - 1; i >= 0; i-- {
+		if ends[i] == comprehensionEndBlock {
+			cb.End()
+			continue
+		}
+		endIterBody(ctx)
+		if iters--; found != nil && i > 0 {
+			comprehensionStop(cb, found, iters)
+		}
 	}
 	cb.Return(0).End().Call(0)
 }
@@ -1658,6 +1869,8 @@ func compileErrWrapExpr(ctx *blockCtx, v *ast.ErrWrapExpr, inFlags int) {
 	switch expr.(type) {
 	case *ast.Ident, *ast.SelectorExpr:
 		expr = &ast.CallExpr{Fun: expr}
+	case *ast.AwaitExpr: // await x? => x.Await()?
+		inFlags = clCallWithTwoValue
 	}
 	compileExpr(ctx, expr, inFlags)
 	x := cb.InternalStack().Pop()
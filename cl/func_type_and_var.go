@@ -154,6 +154,8 @@ func toType(ctx *blockCtx, typ ast.Expr) (t types.Type) {
 		return toMapType(ctx, v)
 	case *ast.StructType:
 		return toStructType(ctx, v)
+	case *ast.TupleType:
+		return toTupleType(ctx, v)
 	case *ast.ChanType:
 		return toChanType(ctx, v)
 	case *ast.FuncType:
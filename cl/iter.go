@@ -0,0 +1,671 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cl
+
+import (
+	gotoken "go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/gogen"
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// iterFunc checks if typ is a range-over-func iterator type:
+//
+//	func(yield func() bool)
+//	func(yield func(V) bool)
+//	func(yield func(K, V) bool)
+//
+// It returns the signature of yield if ok.
+func iterFunc(typ types.Type) (yield *types.Signature, ok bool) {
+	t, ok := typ.Underlying().(*types.Signature)
+	if !ok || t.Variadic() || t.Params().Len() != 1 || t.Results().Len() != 0 {
+		return nil, false
+	}
+	yield, ok = t.Params().At(0).Type().Underlying().(*types.Signature)
+	if !ok || yield.Variadic() || yield.Params().Len() > 2 {
+		return nil, false
+	}
+	if ret := yield.Results(); ret.Len() != 1 || !types.Identical(ret.At(0).Type(), types.Typ[types.Bool]) {
+		return nil, false
+	}
+	return yield, true
+}
+
+// yieldCall checks if stmt is a `yield v1, ..., vn` statement.
+func yieldCall(stmt ast.Stmt) (*ast.CallExpr, bool) {
+	if v, ok := stmt.(*ast.ExprStmt); ok {
+		if call, ok := v.X.(*ast.CallExpr); ok {
+			if fn, ok := call.Fun.(*ast.Ident); ok && fn.Name == "yield" {
+				return call, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// hasYield checks if a function body contains `yield` statements.
+// It doesn't check bodies of nested closures.
+func hasYield(body *ast.BlockStmt) (found bool) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncLit, *ast.LambdaExpr, *ast.LambdaExpr2:
+			return false
+		case ast.Stmt:
+			if _, ok := yieldCall(v); ok {
+				found = true
+			}
+		}
+		return !found
+	})
+	return
+}
+
+// generatorOf checks if fn is a generator, that is, a function which returns
+// an iterator func and whose body uses `yield` statements.
+func generatorOf(fn *gogen.Func, body *ast.BlockStmt) (iter *types.Signature, ok bool) {
+	results := fn.Type().(*types.Signature).Results()
+	if results.Len() != 1 {
+		return
+	}
+	if _, ok = iterFunc(results.At(0).Type()); !ok {
+		return
+	}
+	if !hasYield(body) {
+		return nil, false
+	}
+	return results.At(0).Type().Underlying().(*types.Signature), true
+}
+
+// compileGenerator compiles a generator body:
+//
+//	func f(...) iter.Seq[T] {
+//		...
+//		yield v
+//		...
+//	}
+//
+// into:
+//
+//	func f(...) iter.Seq[T] {
+//		return func(_gop_yield func(T) bool) {
+//			...
+//			if !_gop_yield(v) {
+//				return
+//			}
+//			...
+//		}
+//	}
+func compileGenerator(ctx *blockCtx, iter *types.Signature, body *ast.BlockStmt) {
+	pkg, cb := ctx.pkg, ctx.cb
+	yield := pkg.NewParam(token.NoPos, "_gop_yield", iter.Params().At(0).Type())
+	cb.NewClosure(types.NewTuple(yield), nil, false).BodyStart(pkg, body)
+	old, oldStop := ctx.yield, ctx.yieldStop
+	ctx.yield, ctx.yieldStop = yield, nil
+	compileStmts(ctx, body.List)
+	ctx.yield, ctx.yieldStop = old, oldStop
+	cb.End().Return(1)
+}
+
+// yield v1, ..., vn => if !_gop_yield(v1, ..., vn) { return }
+//
+// In for range of iterator funcs, it is compiled into:
+//
+//	if !_gop_yield(v1, ..., vn) {
+//		_gop_stop = true
+//		return false
+//	}
+func compileYieldStmt(ctx *blockCtx, v *ast.CallExpr) {
+	cb := ctx.cb
+	cb.If(v).Val(ctx.yield)
+	for _, arg := range v.Args {
+		compileExpr(ctx, arg)
+	}
+	cb.CallWith(len(v.Args), 0, v).UnaryOp(gotoken.NOT).Then()
+	if stop := ctx.yieldStop; stop != nil {
+		cb.VarRef(stop).Val(true).Assign(1).Val(false).Return(1)
+	} else {
+		cb.Return(0)
+	}
+	cb.End()
+}
+
+// startIterYield prepares the stop flag of yield statements in for range of an
+// iterator func. It returns the old stop flag, which is nil if the loop isn't
+// in another for range of iterator funcs.
+func startIterYield(ctx *blockCtx, body *ast.BlockStmt) (old *types.Var, ok bool) {
+	if ctx.yield == nil || !hasYield(body) {
+		return
+	}
+	old, ok = ctx.yieldStop, true
+	if old == nil {
+		ctx.yieldStop = iterVar(ctx, "_gop_stop", types.Typ[types.Bool])
+	}
+	return
+}
+
+// iterVar returns the variable name of the current scope, and declares it if
+// it doesn't exist.
+func iterVar(ctx *blockCtx, name string, typ types.Type) *types.Var {
+	cb := ctx.cb
+	v := cb.Scope().Lookup(name)
+	if v == nil {
+		cb.NewVar(typ, name)
+		v = cb.Scope().Lookup(name)
+	}
+	return v.(*types.Var)
+}
+
+// endIterYield stops the generator if the yield callback is stopped:
+//
+//	if _gop_stop {
+//		return // or `return false` in another for range of iterator funcs
+//	}
+func endIterYield(ctx *blockCtx, old *types.Var, ok bool) {
+	if !ok {
+		return
+	}
+	cb := ctx.cb
+	cb.If().Val(ctx.yieldStop).Then()
+	if old != nil {
+		cb.Val(false).Return(1)
+	} else {
+		cb.Return(0)
+	}
+	cb.End()
+	ctx.yieldStop = old
+}
+
+// iterReturn is the state of return statements in for range of iterator funcs.
+type iterReturn struct {
+	sig     *types.Signature // signature of the function to return from
+	flag    *types.Var       // _gop_return, set if the function returns
+	results []*types.Var     // named results of the function, or _gop_r0, ...
+	named   bool
+}
+
+// startIterReturn prepares the return flag and results of return statements
+// in for range of an iterator func, if the loop body has any. It returns the
+// old state, which is nil if the loop isn't in another for range of iterator
+// funcs.
+func startIterReturn(ctx *blockCtx, hasReturn bool) (old *iterReturn, ok bool) {
+	if !hasReturn {
+		return
+	}
+	old, ok = ctx.iterRet, true
+	if old == nil {
+		sig := ctx.cb.Func().Type().(*types.Signature)
+		r := &iterReturn{sig: sig, flag: iterVar(ctx, "_gop_return", types.Typ[types.Bool])}
+		results := sig.Results()
+		r.named = results.Len() > 0
+		for i := 0; i < results.Len(); i++ {
+			if name := results.At(i).Name(); name == "" || name == "_" {
+				r.named = false
+			}
+		}
+		r.results = make([]*types.Var, results.Len())
+		for i := range r.results {
+			if r.named {
+				r.results[i] = results.At(i)
+			} else {
+				r.results[i] = iterVar(ctx, "_gop_r"+strconv.Itoa(i), results.At(i).Type())
+			}
+		}
+		ctx.iterRet = r
+	}
+	return
+}
+
+// endIterReturn returns from the function if a return statement in the loop
+// body is executed:
+//
+//	if _gop_return {
+//		return _gop_r0, ... // or `return false` in another for range of iterator funcs
+//	}
+func endIterReturn(ctx *blockCtx, old *iterReturn, ok bool) {
+	if !ok {
+		return
+	}
+	cb, r := ctx.cb, ctx.iterRet
+	cb.If().Val(r.flag).Then()
+	if old != nil {
+		cb.Val(false).Return(1)
+	} else if r.named {
+		cb.Return(0)
+	} else {
+		for _, v := range r.results {
+			cb.Val(v)
+		}
+		cb.Return(len(r.results))
+	}
+	cb.End()
+	ctx.iterRet = old
+}
+
+// compileIterReturn compiles a return statement in for range of iterator
+// funcs:
+//
+//	return v1, ..., vn
+//
+// into:
+//
+//	_gop_r0, ..., _gop_rn = v1, ..., vn // or the named results
+//	_gop_return = true
+//	return false
+func compileIterReturn(ctx *blockCtx, v *ast.ReturnStmt) {
+	cb, r := ctx.cb, ctx.iterRet
+	results := r.sig.Results()
+	if n := len(v.Results); n > 0 {
+		for _, ret := range r.results {
+			cb.VarRef(ret)
+		}
+		compileReturnResults(ctx, v, results)
+		checkIterResults(ctx, v, n, results)
+		cb.AssignWith(len(r.results), n, v)
+	} else if results.Len() > 0 && !r.named {
+		panic(ctx.newCodeErrorf(v.Pos(), "not enough arguments to return\n\thave ()\n\twant %v", results))
+	}
+	cb.VarRef(r.flag).Val(true).Assign(1).Val(false).Return(1)
+}
+
+// checkIterResults reports a mismatch between the n values of a return
+// statement in for range of iterator funcs and the results of the function
+// as `return` would do, rather than as an assignment mismatch.
+func checkIterResults(ctx *blockCtx, v *ast.ReturnStmt, n int, results *types.Tuple) {
+	need := results.Len()
+	args := ctx.cb.InternalStack().GetArgs(n)
+	if n == 1 {
+		t, ok := args[0].Type.(*types.Tuple)
+		if !ok || t.Len() == need {
+			return
+		}
+		panic(ctx.newCodeErrorf(v.Pos(), "too %s arguments to return\n\thave %v\n\twant %v",
+			fewOrMany(t.Len(), need), t, results))
+	}
+	if n != need {
+		typs := make([]string, n)
+		for i, arg := range args {
+			typs[i] = arg.Type.String()
+		}
+		panic(ctx.newCodeErrorf(v.Pos(), "too %s arguments to return\n\thave (%s)\n\twant %v",
+			fewOrMany(n, need), strings.Join(typs, ", "), results))
+	}
+}
+
+func fewOrMany(n, need int) string {
+	if n > need {
+		return "many"
+	}
+	return "few"
+}
+
+// -----------------------------------------------------------------------------
+
+// iterBranches collects break/continue statements which leave a for range
+// loop over an iterator func. Such a loop body is compiled as a yield callback,
+// so `break` becomes `return false` and `continue` becomes `return true`.
+// Labeled ones can only refer to labels in the loop body. It reports whether
+// the loop body has return statements (see compileIterReturn).
+func iterBranches(ctx *blockCtx, body *ast.BlockStmt) (hasReturn bool) {
+	var loops, breaks int
+	var stack []ast.Node
+	labels := make(map[string]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			switch stack[len(stack)-1].(type) {
+			case *ast.ForStmt, *ast.RangeStmt, *ast.ForPhraseStmt:
+				loops--
+				breaks--
+			case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				breaks--
+			}
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		switch v := n.(type) {
+		case *ast.FuncLit, *ast.LambdaExpr, *ast.LambdaExpr2:
+			return false
+		case *ast.ForStmt, *ast.RangeStmt, *ast.ForPhraseStmt:
+			loops++
+			breaks++
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			breaks++
+		case *ast.ReturnStmt:
+			hasReturn = true
+		case *ast.LabeledStmt:
+			labels[v.Label.Name] = true
+		case *ast.BranchStmt:
+			if v.Label != nil {
+				if (v.Tok == token.BREAK || v.Tok == token.CONTINUE) && !labels[v.Label.Name] {
+					panic(ctx.newCodeErrorf(v.Pos(), "labeled %v across for range of iterator func not supported", v.Tok))
+				}
+			} else {
+				if ctx.iterBrs == nil {
+					ctx.iterBrs = make(map[*ast.BranchStmt]bool)
+				}
+				switch v.Tok {
+				case token.BREAK:
+					if breaks == 0 {
+						ctx.iterBrs[v] = false
+					}
+				case token.CONTINUE:
+					if loops == 0 {
+						ctx.iterBrs[v] = true
+					}
+				}
+			}
+		}
+		stack = append(stack, n)
+		return true
+	})
+	return
+}
+
+// iterVars returns the variable names of a for range loop over an iterator
+// func in order of the yield parameters.
+func iterVars(ctx *blockCtx, yield *types.Signature, vars []*ast.Ident, src ast.Node) []*ast.Ident {
+	n := yield.Params().Len()
+	if len(vars) > n {
+		var x ast.Expr
+		switch v := src.(type) {
+		case *ast.RangeStmt:
+			x = v.X
+		case *ast.ForPhraseStmt:
+			x = v.X
+		case *ast.ForPhrase:
+			x = v.X
+		}
+		if n == 0 {
+			panic(ctx.newCodeErrorf(src.Pos(), "range over %v permits no iteration variables", ctx.LoadExpr(x)))
+		}
+		panic(ctx.newCodeErrorf(src.Pos(), "range over %v permits only one iteration variable", ctx.LoadExpr(x)))
+	}
+	return vars
+}
+
+// forPhraseVars returns the variable names of `for k, v in x` in order of the
+// yield parameters. Like ranging over a map, `for v in x` takes the value of
+// an iter.Seq2 iterator.
+func forPhraseVars(fp *ast.ForPhrase, yield *types.Signature) []*ast.Ident {
+	value := forPhraseValue(fp)
+	if fp.Key != nil {
+		return []*ast.Ident{fp.Key, value}
+	}
+	if yield.Params().Len() == 2 {
+		return []*ast.Ident{nil, value}
+	}
+	return []*ast.Ident{value}
+}
+
+// iterParams makes parameters of a yield callback.
+func iterParams(ctx *blockCtx, yield *types.Signature, vars []*ast.Ident) *types.Tuple {
+	in := yield.Params()
+	params := make([]*types.Var, in.Len())
+	for i := range params {
+		name, pos := "_", token.NoPos
+		if i < len(vars) && vars[i] != nil {
+			name, pos = vars[i].Name, vars[i].Pos()
+		}
+		params[i] = ctx.pkg.NewParam(pos, name, in.At(i).Type())
+	}
+	return types.NewTuple(params...)
+}
+
+// startIterBody starts a yield callback of a for range loop over an iterator func:
+//
+//	x(func(k K, v V) bool {
+func startIterBody(ctx *blockCtx, x *gogen.Element, yield *types.Signature, vars []*ast.Ident, src ast.Node) {
+	pkg, cb := ctx.pkg, ctx.cb
+	cb.InternalStack().Push(x)
+	params := iterParams(ctx, yield, vars)
+	cb.NewClosure(params, types.NewTuple(pkg.NewParam(token.NoPos, "", types.Typ[types.Bool])), false).BodyStart(pkg)
+	defineNames := make([]*ast.Ident, 0, 2)
+	for _, v := range vars {
+		if v != nil && v.Name != "_" {
+			defineNames = append(defineNames, v)
+		}
+	}
+	if len(defineNames) > 0 {
+		defNames(ctx, defineNames, cb.Scope())
+	}
+	if rec := ctx.recorder(); rec != nil {
+		rec.Scope(src, cb.Scope())
+	}
+}
+
+// endIterBody ends a yield callback started by startIterBody:
+//
+//		return true
+//	})
+func endIterBody(ctx *blockCtx) {
+	ctx.cb.Val(true).Return(1).End().Call(1).EndStmt()
+}
+
+// compileForIter compiles a for range loop over an iterator func:
+//
+//	for k, v in x if cond {
+//		...
+//	}
+//
+// into:
+//
+//	x(func(k K, v V) bool {
+//		if cond {
+//			...
+//		}
+//		return true
+//	})
+func compileForIter(
+	ctx *blockCtx, x *gogen.Element, yield *types.Signature, vars []*ast.Ident,
+	fp *ast.ForPhrase, body *ast.BlockStmt, src ast.Node) {
+	cb := ctx.cb
+	vars = iterVars(ctx, yield, vars, src)
+	oldRet, returning := startIterReturn(ctx, iterBranches(ctx, body))
+	oldStop, yielding := startIterYield(ctx, body)
+	startIterBody(ctx, x, yield, vars, src)
+	compileTupleVars(ctx, fp)
+	if fp != nil && fp.Cond != nil {
+		cb.If()
+		if fp.Init != nil {
+			compileStmt(ctx, fp.Init)
+		}
+		compileExpr(ctx, fp.Cond)
+		cb.Then()
+		compileStmts(ctx, body.List)
+		if rec := ctx.recorder(); rec != nil {
+			rec.Scope(body, cb.Scope())
+		}
+		cb.End()
+	} else {
+		cb.VBlock()
+		compileStmts(ctx, body.List)
+		if rec := ctx.recorder(); rec != nil {
+			rec.Scope(body, cb.Scope())
+		}
+		cb.End()
+	}
+	endIterBody(ctx)
+	endIterYield(ctx, oldStop, yielding)
+	endIterReturn(ctx, oldRet, returning)
+}
+
+// compileForIterAssign compiles `for k, v = range x { ... }` over an iterator
+// func into:
+//
+//	x(func(_gop_k K, _gop_v V) bool {
+//		k, v = _gop_k, _gop_v
+//		...
+//		return true
+//	})
+func compileForIterAssign(ctx *blockCtx, x *gogen.Element, yield *types.Signature, v *ast.RangeStmt) {
+	cb := ctx.cb
+	var lhs, rhs []ast.Expr
+	var vars []*ast.Ident
+	if v.Key != nil {
+		k := &ast.Ident{NamePos: v.Key.Pos(), Name: "_gop_k"}
+		lhs, rhs, vars = append(lhs, v.Key), append(rhs, k), append(vars, k)
+	}
+	if v.Value != nil {
+		if v.Key == nil {
+			vars = append(vars, &ast.Ident{NamePos: v.Value.Pos(), Name: "_"})
+		}
+		val := &ast.Ident{NamePos: v.Value.Pos(), Name: "_gop_v"}
+		lhs, rhs, vars = append(lhs, v.Value), append(rhs, val), append(vars, val)
+	}
+	vars = iterVars(ctx, yield, vars, v)
+	oldRet, returning := startIterReturn(ctx, iterBranches(ctx, v.Body))
+	oldStop, yielding := startIterYield(ctx, v.Body)
+	startIterBody(ctx, x, yield, vars, v)
+	if len(lhs) > 0 {
+		compileStmt(ctx, &ast.AssignStmt{
+			Lhs:    lhs,
+			TokPos: v.TokPos,
+			Tok:    token.ASSIGN,
+			Rhs:    rhs,
+		})
+	}
+	cb.VBlock()
+	compileStmts(ctx, v.Body.List)
+	if rec := ctx.recorder(); rec != nil {
+		rec.Scope(v.Body, cb.Scope())
+	}
+	cb.End()
+	endIterBody(ctx)
+	endIterYield(ctx, oldStop, yielding)
+	endIterReturn(ctx, oldRet, returning)
+}
+
+// compileRangeX compiles the range expression x of a for range loop and
+// checks if it is an iterator func.
+func compileRangeX(ctx *blockCtx, x ast.Expr) (elem *gogen.Element, yield *types.Signature, ok bool) {
+	compileExpr(ctx, x)
+	elem = ctx.cb.InternalStack().Pop()
+	yield, ok = iterFunc(elem.Type)
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+// compileSeqMember compiles `x.method` of a sequence (iterator func) x, whose
+// builtin methods are implemented by seq.<Method>. See seqMethods.
+//
+// Like builtin methods of slices, it leaves the method and x on the stack, and
+// x will be passed as the first argument of the method. If flag is
+// gogen.MemberFlagAutoProperty, the method is called without arguments.
+func compileSeqMember(ctx *blockCtx, v ast.Node, name string, flag gogen.MemberFlag) bool {
+	cb := ctx.cb
+	x := cb.Get(-1)
+	if _, ok := iterFunc(x.Type); !ok {
+		return false
+	}
+	if c := name[0]; c >= 'a' && c <= 'z' {
+		name = string(rune(c)+('A'-'a')) + name[1:]
+	}
+	if !isSeqMethod(name) {
+		return false
+	}
+	seq := ctx.pkg.TryImport(seqPkgPath)
+	if seq.Types == nil {
+		return false
+	}
+	fn := seq.Ref(name)
+	if flag == gogen.MemberFlagAutoProperty && fn.Type().(*types.Signature).Params().Len() != 1 {
+		return false
+	}
+	stk := cb.InternalStack()
+	stk.Pop()
+	cb.Val(fn, v)
+	stk.Push(x)
+	if flag == gogen.MemberFlagAutoProperty {
+		cb.CallWith(1, 0, v)
+	}
+	return true
+}
+
+func isSeqMethod(name string) bool {
+	for _, m := range seqMethods {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+// compileLazyComprehension compiles `(elt for k, v in container if cond)` into
+// an iterator func:
+//
+//	func(_gop_yield func(T) bool) {
+//		for k, v := range container {
+//			if cond {
+//				if !_gop_yield(elt) {
+//					return
+//				}
+//			}
+//		}
+//	}
+//
+// where T is the type of elt. So it is compiled like a generator which yields
+// elt in for phrases.
+func compileLazyComprehension(ctx *blockCtx, v *ast.ComprehensionExpr) {
+	pkg, cb := ctx.pkg, ctx.cb
+
+	elt := lazyComprehensionElt(ctx, v)
+
+	var body ast.Stmt = &ast.ExprStmt{X: &ast.CallExpr{
+		Fun:    &ast.Ident{NamePos: v.Elt.Pos(), Name: "yield"},
+		Lparen: v.Elt.Pos(),
+		Args:   []ast.Expr{v.Elt},
+		Rparen: v.Elt.End(),
+	}}
+	for _, fp := range v.Fors {
+		body = &ast.ForPhraseStmt{
+			ForPhrase: fp,
+			Body:      &ast.BlockStmt{Lbrace: fp.Pos(), List: []ast.Stmt{body}, Rbrace: v.Rpos},
+		}
+	}
+
+	yieldParam := pkg.NewParam(token.NoPos, "", elt)
+	yieldFn := types.NewSignatureType(nil, nil, nil, types.NewTuple(yieldParam), types.NewTuple(
+		pkg.NewParam(token.NoPos, "", types.Typ[types.Bool])), false)
+	yield := pkg.NewParam(token.NoPos, "_gop_yield", yieldFn)
+	cb.NewClosure(types.NewTuple(yield), nil, false).BodyStart(pkg)
+	old, oldStop := ctx.yield, ctx.yieldStop
+	ctx.yield, ctx.yieldStop = yield, nil
+	compileStmt(ctx, body)
+	ctx.yield, ctx.yieldStop = old, oldStop
+	cb.End()
+}
+
+// lazyComprehensionElt deduces the type of elt by compiling `{elt for ...}` and
+// dropping the result. The recorder is detached meanwhile, since elt and the
+// for phrases are compiled again into the iterator func.
+func lazyComprehensionElt(ctx *blockCtx, v *ast.ComprehensionExpr) types.Type {
+	if rec := ctx.recorder(); rec != nil {
+		defer rec.detach()()
+	}
+	compileComprehensionExpr(ctx, &ast.ComprehensionExpr{
+		Lpos: v.Lpos, Tok: token.LBRACE, Elt: v.Elt, Fors: v.Fors, Rpos: v.Rpos,
+	}, false)
+	return ctx.cb.InternalStack().Pop().Type
+}
+
+// -----------------------------------------------------------------------------
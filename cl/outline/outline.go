@@ -527,4 +527,50 @@ func (p Named) Methods() []Func {
 	return ret
 }
 
+// Prop represents a property of a class, that is, a getter method Name() T and
+// its setter method SetName(v T).
+type Prop struct {
+	Get Func
+	Set Func
+}
+
+func (p Prop) Name() string {
+	return p.Get.Name()
+}
+
+func (p Prop) Type() types.Type {
+	return p.Get.Type().(*types.Signature).Results().At(0).Type()
+}
+
+func (p Prop) Doc() string {
+	return p.Get.Doc()
+}
+
+// Props returns the properties of a class, which are declared by
+//
+//	prop Name T {
+//		get => ...
+//		set v => ...
+//	}
+//
+// Read-only properties are auto-properties, which are listed in Methods.
+func (p Named) Props() []Prop {
+	var ret []Prop
+	for i, n := 0, p.NumMethods(); i < n; i++ {
+		get := p.Method(i)
+		sig := get.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			continue
+		}
+		obj, _, _ := types.LookupFieldOrMethod(p.Named, true, get.Pkg(), cl.SetterName(get.Name()))
+		if set, ok := obj.(*types.Func); ok {
+			if t := set.Type().(*types.Signature); t.Params().Len() == 1 && t.Results().Len() == 0 &&
+				types.Identical(t.Params().At(0).Type(), sig.Results().At(0).Type()) {
+				ret = append(ret, Prop{Func{get, p.docs}, Func{set, p.docs}})
+			}
+		}
+	}
+	return ret
+}
+
 // -----------------------------------------------------------------------------
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cl
+
+import (
+	"go/types"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// A property of a class
+//
+//	prop Name T {
+//		get => expr
+//		set v => { ... }
+//	}
+//
+// is compiled into a getter and a setter method:
+//
+//	func (this *Class) Name() T {
+//		return expr
+//	}
+//
+//	func (this *Class) SetName(v T) {
+//		...
+//	}
+//
+// Reading x.name calls the getter as an auto-property, and assigning to it
+// (x.name = v) calls the setter. Spelled exactly as the getter, x.Name is
+// still a method value.
+
+// SetterName returns the name of the setter method of a property.
+func SetterName(prop string) string {
+	if c := prop[0]; c >= 'a' && c <= 'z' {
+		return "set" + string(rune(c)+('A'-'a')) + prop[1:]
+	}
+	return "Set" + prop
+}
+
+// propFuncs returns the getter and setter methods of a property.
+func propFuncs(d *ast.PropDecl) []*ast.FuncDecl {
+	ret := make([]*ast.FuncDecl, 0, 2)
+	if get := d.Get; get != nil {
+		body := get.Body
+		if body == nil {
+			body = &ast.BlockStmt{
+				Lbrace: get.X.Pos(),
+				List:   []ast.Stmt{&ast.ReturnStmt{Return: get.X.Pos(), Results: []ast.Expr{get.X}}},
+				Rbrace: get.X.End(),
+			}
+		}
+		ret = append(ret, &ast.FuncDecl{
+			Doc:  d.Doc,
+			Name: d.Name,
+			Type: &ast.FuncType{
+				Func:    get.TokPos,
+				Params:  &ast.FieldList{},
+				Results: &ast.FieldList{List: []*ast.Field{{Type: d.Type}}},
+			},
+			Body: body,
+		})
+	}
+	if set := d.Set; set != nil {
+		body := set.Body
+		if body == nil {
+			body = &ast.BlockStmt{
+				Lbrace: set.X.Pos(),
+				List:   []ast.Stmt{&ast.ExprStmt{X: set.X}},
+				Rbrace: set.X.End(),
+			}
+		}
+		ret = append(ret, &ast.FuncDecl{
+			Name: &ast.Ident{NamePos: set.TokPos, Name: SetterName(d.Name.Name)},
+			Type: &ast.FuncType{
+				Func:   set.TokPos,
+				Params: &ast.FieldList{List: []*ast.Field{{Names: []*ast.Ident{set.Param}, Type: d.Type}}},
+			},
+			Body: body,
+		})
+	}
+	return ret
+}
+
+// isPropGetter checks if x.name is the getter of a property declared in this
+// package. Other getters are called by auto-property (x.name => x.Name()),
+// and exported getters spelled exactly (x.Name) are method values.
+func isPropGetter(ctx *blockCtx, x types.Type, name string) bool {
+	if token.IsExported(name) {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(x, true, ctx.pkg.Types, name)
+	if fn, ok := obj.(*types.Func); ok {
+		return ctx.getters[fn.Pos()]
+	}
+	return false
+}
+
+// propSetter returns the setter of x.name if name isn't a field of x but a
+// property declared in this package with a setter. Both x.name and x.Name
+// refer to property Name, whose setter is SetName (or setName if the property
+// is unexported).
+func propSetter(ctx *blockCtx, x types.Type, name string) (setter string, ok bool) {
+	pkg := ctx.pkg.Types
+	names := []string{name}
+	if c := name[0]; c >= 'a' && c <= 'z' {
+		names = append(names, string(rune(c)+('A'-'a'))+name[1:])
+	}
+	for _, name := range names {
+		if obj, _, _ := types.LookupFieldOrMethod(x, true, pkg, name); obj != nil {
+			if _, isVar := obj.(*types.Var); isVar {
+				return
+			}
+		}
+	}
+	for _, name := range names {
+		setter = SetterName(name)
+		obj, _, _ := types.LookupFieldOrMethod(x, true, pkg, setter)
+		if fn, isFunc := obj.(*types.Func); isFunc {
+			return setter, ctx.setters[fn.Pos()]
+		}
+	}
+	return
+}
+
+// compilePropSet compiles `x.prop = v` into `x.SetProp(v)`. The setter
+// x.SetProp is on the top of the stack.
+func compilePropSet(ctx *blockCtx, v ast.Expr, lhs *ast.SelectorExpr, src ast.Node) {
+	cb := ctx.cb
+	param := cb.Get(-1).Type.(*types.Signature).Params().At(0).Type()
+	if err := compileCompositeLitElt(ctx, v, param, clLambaAssign, lhs); err != nil {
+		panic(err)
+	}
+	cb.CallWith(1, 0, src).EndStmt()
+}
+
+// -----------------------------------------------------------------------------
@@ -41,6 +41,26 @@ func newRecorder(rec Recorder) *goxRecorder {
 	return &goxRecorder{rec, types, referDefs, referUses}
 }
 
+// detach makes p drop all events until the returned func is called, so that
+// an expression can be compiled twice but recorded once.
+func (p *goxRecorder) detach() (restore func()) {
+	old := *p
+	*p = goxRecorder{nopRecorder{}, make(map[ast.Expr]types.TypeAndValue), make(map[*ast.Ident]ast.Node), make(map[string][]*ast.Ident)}
+	return func() {
+		*p = old
+	}
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Type(ast.Expr, types.TypeAndValue)          {}
+func (nopRecorder) Instantiate(*ast.Ident, types.Instance)     {}
+func (nopRecorder) Def(id *ast.Ident, obj types.Object)        {}
+func (nopRecorder) Use(id *ast.Ident, obj types.Object)        {}
+func (nopRecorder) Implicit(node ast.Node, obj types.Object)   {}
+func (nopRecorder) Select(*ast.SelectorExpr, *types.Selection) {}
+func (nopRecorder) Scope(ast.Node, *types.Scope)               {}
+
 // Refer uses maps identifiers to name for ast.OverloadFuncDecl.
 func (p *goxRecorder) ReferUse(ident *ast.Ident, name string) {
 	p.referUses[name] = append(p.referUses[name], ident)
@@ -288,6 +308,9 @@ func (rec *goxRecorder) recordExpr(ctx *blockCtx, expr ast.Expr, _ bool) {
 		rec.recordTypeValue(ctx, v, typesutil.TypExpr)
 	case *ast.StructType:
 		rec.recordTypeValue(ctx, v, typesutil.TypExpr)
+	case *ast.TupleType:
+		rec.recordTypeValue(ctx, v, typesutil.TypExpr)
+	case *ast.TupleLit:
 	case *ast.ChanType:
 		rec.recordTypeValue(ctx, v, typesutil.TypExpr)
 	case *ast.InterfaceType:
@@ -298,6 +321,8 @@ func (rec *goxRecorder) recordExpr(ctx *blockCtx, expr ast.Expr, _ bool) {
 	case *ast.ParenExpr:
 		rec.recordTypeValue(ctx, v, typesutil.Value)
 	case *ast.ErrWrapExpr:
+	case *ast.AwaitExpr:
+		rec.recordTypeValue(ctx, v, typesutil.Value)
 	case *ast.FuncType:
 		rec.recordTypeValue(ctx, v, typesutil.TypExpr)
 	case *ast.Ellipsis:
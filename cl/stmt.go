@@ -131,6 +131,12 @@ func compileStmt(ctx *blockCtx, stmt ast.Stmt) {
 	commentStmt(ctx, stmt)
 	switch v := stmt.(type) {
 	case *ast.ExprStmt:
+		if ctx.yield != nil {
+			if call, ok := yieldCall(v); ok {
+				compileYieldStmt(ctx, call)
+				break
+			}
+		}
 		x := v.X
 		inFlags := checkCommandWithoutArgs(x)
 		compileExpr(ctx, x, inFlags)
@@ -195,14 +201,20 @@ retry:
 }
 
 func compileReturnStmt(ctx *blockCtx, expr *ast.ReturnStmt) {
-	var n = -1
-	var results *types.Tuple
+	if ctx.iterRet != nil { // return in for range of iterator funcs
+		compileIterReturn(ctx, expr)
+		return
+	}
+	compileReturnResults(ctx, expr, ctx.cb.Func().Type().(*types.Signature).Results())
+	ctx.cb.Return(len(expr.Results), expr)
+}
+
+// compileReturnResults compiles the results of a return statement, where
+// results are the results of the function to return from.
+func compileReturnResults(ctx *blockCtx, expr *ast.ReturnStmt, results *types.Tuple) {
+	n := results.Len()
 	for i, ret := range expr.Results {
 		if c, ok := ret.(*ast.CompositeLit); ok && c.Type == nil {
-			if n < 0 {
-				results = ctx.cb.Func().Type().(*types.Signature).Results()
-				n = results.Len()
-			}
 			var typ types.Type
 			if i < n {
 				typ = results.At(i).Type()
@@ -211,16 +223,16 @@ func compileReturnStmt(ctx *blockCtx, expr *ast.ReturnStmt) {
 		} else {
 			inFlags := 0
 			if len(expr.Results) == 1 {
-				if _, ok := ret.(*ast.ComprehensionExpr); ok {
-					results = ctx.cb.Func().Type().(*types.Signature).Results()
-					if results.Len() == 2 {
+				switch ret.(type) {
+				case *ast.ComprehensionExpr, *ast.AwaitExpr:
+					if n == 2 {
 						inFlags = clCallWithTwoValue
 					}
 				}
 			}
 			switch v := ret.(type) {
 			case *ast.LambdaExpr, *ast.LambdaExpr2:
-				rtyp := ctx.cb.Func().Type().(*types.Signature).Results().At(i).Type()
+				rtyp := results.At(i).Type()
 				sig, ok := rtyp.(*types.Signature)
 				if !ok {
 					panic(ctx.newCodeErrorf(
@@ -228,18 +240,27 @@ func compileReturnStmt(ctx *blockCtx, expr *ast.ReturnStmt) {
 				}
 				compileLambda(ctx, v, sig)
 			case *ast.SliceLit:
-				rtyp := ctx.cb.Func().Type().(*types.Signature).Results().At(i).Type()
-				compileSliceLit(ctx, v, rtyp)
+				compileSliceLit(ctx, v, results.At(i).Type())
+			case *ast.TupleLit:
+				compileTupleLit(ctx, v, results.At(i).Type())
 			default:
 				compileExpr(ctx, ret, inFlags)
 			}
 		}
 	}
-	ctx.cb.Return(len(expr.Results), expr)
 }
 
 func compileIncDecStmt(ctx *blockCtx, expr *ast.IncDecStmt) {
-	compileExprLHS(ctx, expr.X)
+	if sel, ok := expr.X.(*ast.SelectorExpr); ok {
+		if compileSelectorExprLHS(ctx, sel, true) { // x.prop++
+			panic(ctx.newCodeErrorf(expr.TokPos, "operator %v not supported on property %s", expr.Tok, ctx.LoadExpr(sel)))
+		}
+		if rec := ctx.recorder(); rec != nil {
+			rec.recordExpr(ctx, sel, true)
+		}
+	} else {
+		compileExprLHS(ctx, expr.X)
+	}
 	ctx.cb.IncDec(gotoken.Token(expr.Tok))
 }
 
@@ -295,8 +316,9 @@ normal:
 
 func compileAssignStmt(ctx *blockCtx, expr *ast.AssignStmt) {
 	tok := expr.Tok
+	values := tupleRhs(len(expr.Lhs), expr.Rhs)
 	inFlags := 0
-	if len(expr.Lhs) == 2 && len(expr.Rhs) == 1 {
+	if len(expr.Lhs) == 2 && len(values) == 1 {
 		inFlags = clCallWithTwoValue
 	}
 	if tok == token.DEFINE {
@@ -329,19 +351,36 @@ func compileAssignStmt(ctx *blockCtx, expr *ast.AssignStmt) {
 				}
 			}()
 		}
-		for _, rhs := range expr.Rhs {
+		for _, rhs := range values {
 			compileExpr(ctx, rhs, inFlags)
 		}
-		ctx.cb.EndInit(len(expr.Rhs))
+		n := len(values)
+		if n == 1 && len(expr.Lhs) > 1 { // v0, v1, ... := tuple
+			n = expandTuple(ctx, len(expr.Lhs))
+		}
+		ctx.cb.EndInit(n)
 		return
 	}
-	for _, lhs := range expr.Lhs {
-		compileExprLHS(ctx, lhs)
+	if sel, ok := expr.Lhs[0].(*ast.SelectorExpr); ok && len(expr.Lhs) == 1 && len(values) == 1 {
+		if compileSelectorExprLHS(ctx, sel, true) { // x.prop = v
+			if tok != token.ASSIGN {
+				panic(ctx.newCodeErrorf(expr.TokPos, "operator %v not supported on property %s", tok, ctx.LoadExpr(sel)))
+			}
+			compilePropSet(ctx, values[0], sel, expr)
+			return
+		}
+		if rec := ctx.recorder(); rec != nil {
+			rec.recordExpr(ctx, sel, true)
+		}
+	} else {
+		for _, lhs := range expr.Lhs {
+			compileExprLHS(ctx, lhs)
+		}
 	}
-	for i, rhs := range expr.Rhs {
+	for i, rhs := range values {
 		switch e := unparen(rhs).(type) {
 		case *ast.LambdaExpr, *ast.LambdaExpr2:
-			if len(expr.Lhs) == 1 && len(expr.Rhs) == 1 {
+			if len(expr.Lhs) == 1 && len(values) == 1 {
 				typ := ctx.cb.Get(-1).Type.(interface{ Elem() types.Type }).Elem()
 				sig, err := checkLambdaFuncType(ctx, e, typ, clLambaAssign, expr.Lhs[0])
 				if err != nil {
@@ -353,25 +392,35 @@ func compileAssignStmt(ctx *blockCtx, expr *ast.AssignStmt) {
 			}
 		case *ast.SliceLit:
 			var typ types.Type
-			if len(expr.Lhs) == len(expr.Rhs) {
+			if len(expr.Lhs) == len(values) {
 				typ, _ = gogen.DerefType(ctx.cb.Get(-1 - i).Type)
 			}
 			compileSliceLit(ctx, e, typ)
 		case *ast.CompositeLit:
 			var typ types.Type
-			if len(expr.Lhs) == len(expr.Rhs) {
+			if len(expr.Lhs) == len(values) {
 				typ, _ = gogen.DerefType(ctx.cb.Get(-1 - i).Type)
 			}
 			compileCompositeLit(ctx, e, typ, false)
+		case *ast.TupleLit:
+			var typ types.Type
+			if len(expr.Lhs) == len(values) {
+				typ, _ = gogen.DerefType(ctx.cb.Get(-1 - i).Type)
+			}
+			compileTupleLit(ctx, e, typ)
 		default:
 			compileExpr(ctx, rhs, inFlags)
 		}
 	}
 	if tok == token.ASSIGN {
-		ctx.cb.AssignWith(len(expr.Lhs), len(expr.Rhs), expr)
+		n := len(values)
+		if n == 1 && len(expr.Lhs) > 1 { // v0, v1, ... = tuple
+			n = expandTuple(ctx, len(expr.Lhs))
+		}
+		ctx.cb.AssignWith(len(expr.Lhs), n, expr)
 		return
 	}
-	if len(expr.Lhs) != 1 || len(expr.Rhs) != 1 {
+	if len(expr.Lhs) != 1 || len(values) != 1 {
 		panic("TODO: invalid syntax of assign by operator")
 	}
 	ctx.cb.AssignOp(gotoken.Token(tok), expr)
@@ -396,6 +445,22 @@ func compileRangeStmt(ctx *blockCtx, v *ast.RangeStmt) {
 		compileForStmt(ctx, toForStmt(v.For, v.Key, v.Body, re, tok, nil))
 		return
 	}
+	x, yield, isIter := compileRangeX(ctx, v.X)
+	if isIter {
+		if v.Tok == token.ASSIGN {
+			compileForIterAssign(ctx, x, yield, v)
+			return
+		}
+		vars := make([]*ast.Ident, 0, 2)
+		if v.Key != nil {
+			vars = append(vars, v.Key.(*ast.Ident))
+			if v.Value != nil {
+				vars = append(vars, v.Value.(*ast.Ident))
+			}
+		}
+		compileForIter(ctx, x, yield, vars, nil, v.Body, v)
+		return
+	}
 	cb := ctx.cb
 	comments, once := cb.BackupComments()
 	defineNames := make([]*ast.Ident, 0, 2)
@@ -414,7 +479,7 @@ func compileRangeStmt(ctx *blockCtx, v *ast.RangeStmt) {
 			defineNames = append(defineNames, value)
 		}
 		cb.ForRangeEx(names, v)
-		compileExpr(ctx, v.X)
+		cb.InternalStack().Push(x)
 	} else {
 		cb.ForRangeEx(nil, v)
 		n := 0
@@ -431,7 +496,7 @@ func compileRangeStmt(ctx *blockCtx, v *ast.RangeStmt) {
 			compileExprLHS(ctx, v.Value)
 			n++
 		}
-		compileExpr(ctx, v.X)
+		cb.InternalStack().Push(x)
 	}
 	pos := v.TokPos
 	if pos == 0 {
@@ -460,6 +525,11 @@ func compileForPhraseStmt(ctx *blockCtx, v *ast.ForPhraseStmt) {
 		compileForStmt(ctx, toForStmt(v.For, v.Value, v.Body, re, token.DEFINE, v.ForPhrase))
 		return
 	}
+	x, yield, isIter := compileRangeX(ctx, v.X)
+	if isIter {
+		compileForIter(ctx, x, yield, forPhraseVars(v.ForPhrase, yield), v.ForPhrase, v.Body, v)
+		return
+	}
 	cb := ctx.cb
 	comments, once := cb.BackupComments()
 	names := make([]string, 1, 2)
@@ -473,9 +543,11 @@ func compileForPhraseStmt(ctx *blockCtx, v *ast.ForPhraseStmt) {
 	if v.Value != nil {
 		names = append(names, v.Value.Name)
 		defineNames = append(defineNames, v.Value)
+	} else if v.Tuple != nil {
+		names = append(names, forPhraseValue(v.ForPhrase).Name)
 	}
 	cb.ForRange(names...)
-	compileExpr(ctx, v.X)
+	cb.InternalStack().Push(x)
 	cb.RangeAssignThen(v.TokPos)
 	if len(defineNames) > 0 {
 		defNames(ctx, defineNames, cb.Scope())
@@ -483,6 +555,7 @@ func compileForPhraseStmt(ctx *blockCtx, v *ast.ForPhraseStmt) {
 	if rec := ctx.recorder(); rec != nil {
 		rec.Scope(v, cb.Scope())
 	}
+	compileTupleVars(ctx, v.ForPhrase)
 	if v.Cond != nil {
 		cb.If()
 		compileExpr(ctx, v.Cond)
@@ -893,6 +966,10 @@ func compileSelectStmt(ctx *blockCtx, v *ast.SelectStmt) {
 }
 
 func compileBranchStmt(ctx *blockCtx, v *ast.BranchStmt) {
+	if ret, ok := ctx.iterBrs[v]; ok { // break/continue in for range of iterator func
+		ctx.cb.Val(ret).Return(1)
+		return
+	}
 	label := v.Label
 	switch v.Tok {
 	case token.GOTO:
@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cl
+
+import (
+	goast "go/ast"
+	"go/types"
+	"strconv"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/cl/internal/typesutil"
+	"github.com/goplus/gop/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// A tuple type (T0, T1, ...) is compiled into an anonymous struct:
+//
+//	struct {
+//		X_0 T0
+//		X_1 T1
+//		...
+//	}
+//
+// Field names are exported so that the same tuple type declared in different
+// packages is identical.
+
+const tupleFieldPrefix = "X_"
+
+func tupleFieldName(i int) string {
+	return tupleFieldPrefix + strconv.Itoa(i)
+}
+
+func newTupleType(pkg *types.Package, pos token.Pos, elts []types.Type) *types.Struct {
+	fields := make([]*types.Var, len(elts))
+	for i, elt := range elts {
+		fields[i] = types.NewField(pos, pkg, tupleFieldName(i), elt, false)
+	}
+	return types.NewStruct(fields, nil)
+}
+
+// TupleFields returns the element fields of a tuple type (including named
+// types whose underlying type is a tuple). It returns false if typ isn't a
+// tuple type.
+func TupleFields(typ types.Type) (fields []*types.Var, ok bool) {
+	if typ == nil {
+		return
+	}
+	t, ok := typ.Underlying().(*types.Struct)
+	if !ok || t.NumFields() < 2 {
+		return nil, false
+	}
+	fields = make([]*types.Var, t.NumFields())
+	for i := range fields {
+		f := t.Field(i)
+		if f.Embedded() || f.Name() != tupleFieldName(i) || t.Tag(i) != "" {
+			return nil, false
+		}
+		fields[i] = f
+	}
+	return fields, true
+}
+
+// isTupleList checks if typ is a slice or an array of tuples.
+func isTupleList(typ types.Type) bool {
+	var elem types.Type
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		elem = t.Elem()
+	case *types.Array:
+		elem = t.Elem()
+	default:
+		return false
+	}
+	_, ok := TupleFields(elem)
+	return ok
+}
+
+// returnsTuple checks if fn is a function which returns a single tuple.
+func returnsTuple(fn types.Type) bool {
+	if sig, ok := fn.(*types.Signature); ok {
+		if results := sig.Results(); results.Len() == 1 {
+			_, ok = TupleFields(results.At(0).Type())
+			return ok
+		}
+	}
+	return false
+}
+
+func toTupleType(ctx *blockCtx, v *ast.TupleType) *types.Struct {
+	elts := make([]types.Type, len(v.Elts))
+	for i, elt := range v.Elts {
+		elts[i] = toType(ctx, elt)
+	}
+	return newTupleType(ctx.pkg.Types, v.Pos(), elts)
+}
+
+// compileTupleLit compiles a tuple literal (x0, x1, ...). If expected is a
+// tuple type with the same number of elements, the literal is of that type.
+// Otherwise its type is inferred from the default types of its elements.
+func compileTupleLit(ctx *blockCtx, v *ast.TupleLit, expected types.Type) {
+	cb := ctx.cb
+	n := len(v.Elts)
+	typ := expected
+	fields, ok := TupleFields(expected)
+	if !ok || len(fields) != n {
+		typ, fields = nil, nil
+	}
+	for i, elt := range v.Elts {
+		var t types.Type
+		if fields != nil {
+			t = fields[i].Type()
+		}
+		if err := compileCompositeLitElt(ctx, elt, t, clLambaAssign, nil); err != nil {
+			panic(err)
+		}
+	}
+	if typ == nil {
+		elts := make([]types.Type, n)
+		for i := range elts {
+			t := types.Default(cb.Get(i - n).Type)
+			if t == types.Typ[types.UntypedNil] {
+				panic(ctx.newCodeErrorf(v.Elts[i].Pos(), "use of untyped nil in tuple literal"))
+			}
+			elts[i] = t
+		}
+		typ = newTupleType(ctx.pkg.Types, v.Pos(), elts)
+	}
+	cb.StructLit(typ, n, false, v)
+	if rec := ctx.recorder(); rec != nil {
+		rec.recordTypeValue(ctx, v, typesutil.Value)
+	}
+}
+
+// expandTuple expands the tuple value on the top of the stack into its n
+// elements for a destructuring assignment `v0, v1, ... := x`. It returns the
+// number of values on the stack for the assignment.
+func expandTuple(ctx *blockCtx, n int) int {
+	cb := ctx.cb
+	x := cb.Get(-1)
+	fields, ok := TupleFields(x.Type)
+	if !ok || len(fields) != n {
+		return 1
+	}
+	if _, ok := x.Val.(*goast.Ident); ok { // v0, v1, ... := x.X_0, x.X_1, ...
+		stk := cb.InternalStack()
+		stk.Pop()
+		for _, f := range fields {
+			stk.Push(x)
+			cb.MemberVal(f.Name())
+		}
+		return n
+	}
+	// v0, v1, ... := func(t T) (T0, T1, ...) { return t.X_0, t.X_1, ... }(x)
+	pkg := ctx.pkg
+	param := pkg.NewParam(token.NoPos, "_gop_tup", x.Type)
+	results := make([]*types.Var, n)
+	for i, f := range fields {
+		results[i] = pkg.NewParam(token.NoPos, "", f.Type())
+	}
+	sig := types.NewSignatureType(nil, nil, nil, types.NewTuple(param), types.NewTuple(results...), false)
+	global := cb.Scope().Parent() == types.Universe
+	if global { // can't inline a closure out of functions
+		cb.InternalStack().Pop()
+		cb.NewClosureWith(sig).BodyStart(pkg)
+	} else {
+		cb.CallInlineClosureStart(sig, 1, false)
+	}
+	for _, f := range fields {
+		cb.Val(param).MemberVal(f.Name())
+	}
+	cb.Return(n).End()
+	if global {
+		cb.InternalStack().Push(x)
+		cb.Call(1)
+		return 1
+	}
+	return n
+}
+
+// tupleRhs expands `v0, v1, ... := (x0, x1, ...)` into `v0, v1, ... := x0, x1, ...`.
+func tupleRhs(nlhs int, rhs []ast.Expr) []ast.Expr {
+	if nlhs > 1 && len(rhs) == 1 {
+		if t, ok := rhs[0].(*ast.TupleLit); ok && len(t.Elts) == nlhs {
+			return t.Elts
+		}
+	}
+	return rhs
+}
+
+// forPhraseValue returns the value variable of a for phrase. A destructured
+// value `for (v0, v1, ...) in x` is stored in a hidden variable first.
+func forPhraseValue(fp *ast.ForPhrase) *ast.Ident {
+	if fp.Tuple != nil {
+		return &ast.Ident{NamePos: fp.Tuple.Lparen, Name: "_gop_v"}
+	}
+	return fp.Value
+}
+
+// compileTupleVars destructures the value of `for (v0, v1, ...) in x` into
+// its variables:
+//
+//	v0, v1, ... := _gop_v.X_0, _gop_v.X_1, ...
+func compileTupleVars(ctx *blockCtx, fp *ast.ForPhrase) {
+	if fp == nil || fp.Tuple == nil {
+		return
+	}
+	cb := ctx.cb
+	n := len(fp.Tuple.Elts)
+	idents := make([]*ast.Ident, n)
+	names := make([]string, n)
+	for i, elt := range fp.Tuple.Elts {
+		idents[i] = elt.(*ast.Ident)
+		names[i] = idents[i].Name
+	}
+	v := cb.Scope().Lookup("_gop_v")
+	if fields, ok := TupleFields(v.Type()); !ok || len(fields) != n {
+		panic(ctx.newCodeErrorf(
+			fp.Tuple.Pos(), "cannot destructure %v (type %v) into %d variables", ctx.LoadExpr(fp.X), v.Type(), n))
+	}
+	cb.DefineVarStart(fp.Tuple.Pos(), names...).Val(v)
+	cb.EndInit(expandTuple(ctx, n))
+	defNames(ctx, idents, cb.Scope())
+}
+
+// -----------------------------------------------------------------------------
@@ -19,6 +19,7 @@ package cl
 import (
 	"go/types"
 
+	"github.com/goplus/gogen"
 	"github.com/goplus/gop/ast"
 	"github.com/goplus/gop/token"
 )
@@ -120,6 +121,9 @@ func toFuncType(ctx *blockCtx, typ *ast.FuncType, recv *types.Var, d *ast.FuncDe
 	}
 	params, variadic := toParams(ctx, typ.Params.List)
 	results := toResults(ctx, typ.Results)
+	if d != nil && d.Async.IsValid() {
+		results = toAsyncResults(ctx, results, d.Async)
+	}
 	if recv != nil {
 		return types.NewSignatureType(recv, typeParams, nil, params, results, variadic)
 	}
@@ -305,3 +309,183 @@ func namedIsTypeParams(ctx *blockCtx, t *types.Named) bool {
 	}
 	return t.Obj() != nil && t.TypeArgs() == nil && t.TypeParams() != nil
 }
+
+// inferLambdaFunc infers type arguments of a generic function call whose
+// arguments contain lambda expressions. Unlike gogen.InferFunc, it deduces
+// result types of lambdas from their bodies, eg. R of
+//
+//	func Map[T, R any](a []T, f func(T) R) []R
+//
+// for `Map(a, x => x * 2)`.
+func inferLambdaFunc(ctx *blockCtx, fn *fnType, v *ast.CallExpr, args []*gogen.Element) (types.Type, bool) {
+	sig := fn.sig
+	params, tparams := sig.Params(), sig.TypeParams()
+	targs := make(map[*types.TypeParam]types.Type, tparams.Len())
+	off := fn.base - (len(args) - len(v.Args)) // param index of args[0]
+	for i, arg := range args {
+		j := i + off
+		if j >= params.Len() || (sig.Variadic() && j == params.Len()-1) {
+			break
+		}
+		if k := j - fn.base; k >= 0 {
+			switch v.Args[k].(type) {
+			case *ast.LambdaExpr, *ast.LambdaExpr2:
+				continue
+			}
+		}
+		if t, ok := arg.Type.(*types.Basic); ok && t.Kind() == types.UntypedNil {
+			continue
+		}
+		unifyType(targs, params.At(j).Type(), types.Default(arg.Type))
+	}
+	for k, arg := range v.Args {
+		lambda, ok := arg.(*ast.LambdaExpr)
+		if !ok {
+			if _, ok = arg.(*ast.LambdaExpr2); ok {
+				return nil, false
+			}
+			continue
+		}
+		if k+fn.base >= params.Len() {
+			return nil, false
+		}
+		ftyp, ok := params.At(k + fn.base).Type().Underlying().(*types.Signature)
+		if !ok || ftyp.Params().Len() != len(lambda.Lhs) || ftyp.Results().Len() != 1 || len(lambda.Rhs) != 1 {
+			return nil, false
+		}
+		in := make([]*types.Var, len(lambda.Lhs))
+		for i, name := range lambda.Lhs {
+			t := substType(targs, ftyp.Params().At(i).Type())
+			if t == nil {
+				return nil, false
+			}
+			in[i] = ctx.pkg.NewParam(name.Pos(), name.Name, t)
+		}
+		ret := lambdaResultType(ctx, lambda, types.NewTuple(in...))
+		unifyType(targs, ftyp.Results().At(0).Type(), ret)
+	}
+	list := make([]types.Type, tparams.Len())
+	for i := range list {
+		if list[i] = targs[tparams.At(i)]; list[i] == nil {
+			return nil, false
+		}
+	}
+	ret, err := types.Instantiate(nil, sig, list, true)
+	return ret, err == nil
+}
+
+// lambdaResultType returns type of `x1, ..., xn => expr` by compiling expr in
+// a temporary closure, which is dropped.
+func lambdaResultType(ctx *blockCtx, lambda *ast.LambdaExpr, params *types.Tuple) types.Type {
+	cb := ctx.cb
+	cb.NewClosure(params, nil, false).BodyStart(ctx.pkg)
+	compileExpr(ctx, lambda.Rhs[0])
+	ret := cb.InternalStack().Pop().Type
+	cb.End()
+	cb.InternalStack().Pop()
+	return types.Default(ret)
+}
+
+// unifyType binds type parameters in param to the corresponding parts of arg.
+func unifyType(targs map[*types.TypeParam]types.Type, param, arg types.Type) {
+	switch p := param.(type) {
+	case *types.TypeParam:
+		if targs[p] == nil {
+			targs[p] = arg
+		}
+	case *types.Slice:
+		if a, ok := arg.Underlying().(*types.Slice); ok {
+			unifyType(targs, p.Elem(), a.Elem())
+		}
+	case *types.Pointer:
+		if a, ok := arg.Underlying().(*types.Pointer); ok {
+			unifyType(targs, p.Elem(), a.Elem())
+		}
+	case *types.Map:
+		if a, ok := arg.Underlying().(*types.Map); ok {
+			unifyType(targs, p.Key(), a.Key())
+			unifyType(targs, p.Elem(), a.Elem())
+		}
+	case *types.Signature:
+		if a, ok := arg.Underlying().(*types.Signature); ok {
+			unifyTuple(targs, p.Params(), a.Params())
+			unifyTuple(targs, p.Results(), a.Results())
+		}
+	case *types.Named:
+		if a, ok := arg.(*types.Named); ok && a.Origin() == p.Origin() {
+			pargs, aargs := p.TypeArgs(), a.TypeArgs()
+			for i, n := 0, pargs.Len(); i < n && i < aargs.Len(); i++ {
+				unifyType(targs, pargs.At(i), aargs.At(i))
+			}
+		}
+	}
+}
+
+func unifyTuple(targs map[*types.TypeParam]types.Type, param, arg *types.Tuple) {
+	if param.Len() == arg.Len() {
+		for i, n := 0, param.Len(); i < n; i++ {
+			unifyType(targs, param.At(i).Type(), arg.At(i).Type())
+		}
+	}
+}
+
+// substType substitutes type parameters in typ with their bound types. It
+// returns nil if there are unbound type parameters.
+func substType(targs map[*types.TypeParam]types.Type, typ types.Type) types.Type {
+	switch t := typ.(type) {
+	case *types.TypeParam:
+		return targs[t]
+	case *types.Slice:
+		if elem := substType(targs, t.Elem()); elem != nil {
+			return types.NewSlice(elem)
+		}
+		return nil
+	case *types.Pointer:
+		if elem := substType(targs, t.Elem()); elem != nil {
+			return types.NewPointer(elem)
+		}
+		return nil
+	case *types.Map:
+		key, elem := substType(targs, t.Key()), substType(targs, t.Elem())
+		if key != nil && elem != nil {
+			return types.NewMap(key, elem)
+		}
+		return nil
+	case *types.Signature:
+		params, ok1 := substTuple(targs, t.Params())
+		results, ok2 := substTuple(targs, t.Results())
+		if ok1 && ok2 {
+			return types.NewSignatureType(nil, nil, nil, params, results, t.Variadic())
+		}
+		return nil
+	case *types.Named:
+		if n := t.TypeArgs().Len(); n > 0 {
+			list := make([]types.Type, n)
+			for i := range list {
+				if list[i] = substType(targs, t.TypeArgs().At(i)); list[i] == nil {
+					return nil
+				}
+			}
+			ret, err := types.Instantiate(nil, t.Origin(), list, false)
+			if err != nil {
+				return nil
+			}
+			return ret
+		}
+	}
+	return typ
+}
+
+func substTuple(targs map[*types.TypeParam]types.Type, tuple *types.Tuple) (*types.Tuple, bool) {
+	n := tuple.Len()
+	vars := make([]*types.Var, n)
+	for i := 0; i < n; i++ {
+		v := tuple.At(i)
+		t := substType(targs, v.Type())
+		if t == nil {
+			return nil, false
+		}
+		vars[i] = types.NewParam(v.Pos(), v.Pkg(), v.Name(), t)
+	}
+	return types.NewTuple(vars...), true
+}
@@ -39,7 +39,9 @@ import (
 	"github.com/goplus/gop/cmd/internal/mod"
 	"github.com/goplus/gop/cmd/internal/run"
 	"github.com/goplus/gop/cmd/internal/serve"
+	"github.com/goplus/gop/cmd/internal/spec"
 	"github.com/goplus/gop/cmd/internal/test"
+	"github.com/goplus/gop/cmd/internal/tpl"
 	"github.com/goplus/gop/cmd/internal/version"
 	"github.com/goplus/gop/cmd/internal/watch"
 )
@@ -61,6 +63,8 @@ func init() {
 		gengo.Cmd,
 		mod.Cmd,
 		doc.Cmd,
+		tpl.Cmd,
+		spec.Cmd,
 		clean.Cmd,
 		// list.Cmd,
 		// deps.Cmd,
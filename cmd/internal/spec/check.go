@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package spec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goplus/gop/cmd/internal/base"
+	"github.com/goplus/gop/env"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/x/gopspec"
+)
+
+// gop spec check
+var CmdCheck = &base.Command{
+	UsageLine: "gop spec check [-spec file -ast -v] files or dirs...",
+	Short:     "Report Go+ source files accepted by only one of the parser and the grammar spec",
+}
+
+var (
+	checkFlag = &CmdCheck.Flag
+	checkSpec = checkFlag.String("spec", "", "grammar spec, a .tpl file or a .gop file with a tpl literal (default $GOPROOT/doc/spec/mini/mini.gop).")
+	checkAST  = checkFlag.Bool("ast", false, "dump the ASTs of the files accepted by the spec.")
+	checkV    = checkFlag.Bool("v", false, "print verbose information of matching rules.")
+)
+
+func init() {
+	CmdCheck.Run = runCheck
+}
+
+func runCheck(cmd *base.Command, args []string) {
+	if err := checkFlag.Parse(args); err != nil || checkFlag.NArg() == 0 {
+		cmd.Usage(os.Stderr)
+		os.Exit(2)
+	}
+	specFile := *checkSpec
+	if specFile == "" {
+		specFile = filepath.Join(env.GOPROOT(), "doc", "spec", "mini", "mini.gop")
+	}
+	spec, err := gopspec.Load(nil, specFile)
+	if err != nil {
+		fatal(err)
+	}
+	if *checkV {
+		matcher.SetDebug(matcher.DbgFlagAll)
+	}
+	c := &gopspec.Checker{Spec: spec}
+	if *checkAST {
+		c.Dump = os.Stdout
+	}
+	n := 0
+	for _, arg := range checkFlag.Args() {
+		var ret []*gopspec.Mismatch
+		if strings.HasSuffix(arg, "/...") {
+			ret, err = c.CheckDir(arg[:len(arg)-4], true)
+		} else if fi, e := os.Stat(arg); e == nil && fi.IsDir() {
+			ret, err = c.CheckDir(arg, false)
+		} else {
+			var m *gopspec.Mismatch
+			if m, err = c.CheckFile(arg, nil); m != nil {
+				ret = append(ret, m)
+			}
+		}
+		if err != nil {
+			fatal(err)
+		}
+		for _, m := range ret {
+			fmt.Println(m)
+		}
+		n += len(ret)
+	}
+	if n > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package spec implements the “gop spec” command.
+package spec
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goplus/gop/cmd/internal/base"
+)
+
+// Cmd - gop spec
+var Cmd = &base.Command{
+	UsageLine: "gop spec",
+	Short:     "Go+ grammar spec tools",
+
+	Commands: []*base.Command{
+		CmdCheck,
+	},
+}
+
+func fatal(msg any) {
+	fmt.Fprintln(os.Stderr, msg)
+	os.Exit(1)
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/goplus/gop/cmd/internal/base"
+	"github.com/goplus/gop/tpl/analysis"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// gop tpl check
+var CmdCheck = &base.Command{
+	UsageLine: "gop tpl check [-json] files...",
+	Short:     "Report problems of TPL grammars (.tpl files or tpl literals in .gop files)",
+}
+
+var (
+	checkFlag = &CmdCheck.Flag
+	checkJSON = checkFlag.Bool("json", false, "print diagnostics in JSON.")
+)
+
+func init() {
+	CmdCheck.Run = runCheck
+}
+
+func runCheck(cmd *base.Command, args []string) {
+	if err := checkFlag.Parse(args); err != nil || checkFlag.NArg() == 0 {
+		cmd.Usage(os.Stderr)
+		os.Exit(2)
+	}
+	fset := token.NewFileSet()
+	diags := make([]*analysis.Diagnostic, 0, 8)
+	for _, file := range checkFlag.Args() {
+		ret, err := analysis.CheckFile(fset, file, nil)
+		if err != nil {
+			fatal(err)
+		}
+		diags = append(diags, ret...)
+	}
+	if *checkJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(diags)
+	} else {
+		for _, d := range diags {
+			fmt.Println(d)
+		}
+	}
+	for _, d := range diags {
+		if d.Severity == analysis.Error {
+			os.Exit(1)
+		}
+	}
+}
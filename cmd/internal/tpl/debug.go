@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/goplus/gop/cmd/internal/base"
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/token"
+	"github.com/goplus/gop/tpl/types"
+)
+
+// gop tpl debug
+var CmdDebug = &base.Command{
+	UsageLine: "gop tpl debug [-b rule,...] [-tree|-json] [-expr] [-memo] grammarFile inputFile",
+	Short:     "Debug a TPL grammar by tracing the matching of an input",
+}
+
+var (
+	debugFlag   = &CmdDebug.Flag
+	debugBreaks = debugFlag.String("b", "", "comma-separated rule names to break at.")
+	debugTree   = debugFlag.Bool("tree", false, "print the trace as an indented tree, instead of debugging interactively.")
+	debugJSON   = debugFlag.Bool("json", false, "print the trace in JSON, instead of debugging interactively.")
+	debugExpr   = debugFlag.Bool("expr", false, "parse the input as an expression, which may be followed by newlines.")
+	debugMemo   = debugFlag.Bool("memo", false, "enable packrat memoization.")
+)
+
+func init() {
+	CmdDebug.Run = runDebug
+}
+
+func runDebug(cmd *base.Command, args []string) {
+	if err := debugFlag.Parse(args); err != nil || debugFlag.NArg() != 2 {
+		cmd.Usage(os.Stderr)
+		os.Exit(2)
+	}
+	fset := token.NewFileSet()
+	f, err := parseGrammar(fset, debugFlag.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+	ret, err := cl.NewEx(&cl.Config{
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+	}, fset, f)
+	if err != nil {
+		fatal(err)
+	}
+	c := tpl.Compiler{Result: ret}
+	rec := &matcher.Recorder{Fset: fset}
+	conf := &tpl.Config{Fset: fset, Memo: *debugMemo, Tracer: rec}
+	if !*debugTree && !*debugJSON {
+		d := newDebugger(rec, bufio.NewReader(os.Stdin), os.Stdout)
+		if *debugBreaks == "" {
+			d.step = true // break at the first rule
+		} else {
+			for _, name := range strings.Split(*debugBreaks, ",") {
+				d.breaks[name] = true
+			}
+		}
+		conf.Tracer = d
+	}
+	parse := c.Parse
+	if *debugExpr {
+		parse = c.ParseExprFrom
+	}
+	result, err := parse(debugFlag.Arg(1), nil, conf)
+	switch {
+	case *debugJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(rec)
+	case *debugTree:
+		rec.Fprint(os.Stdout)
+	}
+	if err != nil {
+		fatal(err)
+	}
+	if !*debugJSON {
+		fmt.Println("result:", result)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+const debugHelp = `Commands:
+  s, step       break at the next rule
+  n, next       break at the next rule, stepping over the current one
+  o, out        break at the next rule, stepping out of the enclosing one
+  c, continue   break at the next breakpoint
+  b [rule]      add a breakpoint, or list breakpoints
+  d rule        delete a breakpoint
+  bt            print the stack of rules being matched
+  t             print the trace of the enclosing rule so far
+  q, quit       quit
+`
+
+// debugger is a Tracer which breaks at the entry of rules, and reads commands
+// from the user.
+type debugger struct {
+	*matcher.Recorder
+	breaks map[string]bool
+	in     *bufio.Reader
+	out    io.Writer
+
+	step  bool // break at the next rule
+	depth int  // break at the next rule whose depth <= depth, if depth > 0
+}
+
+func newDebugger(rec *matcher.Recorder, in *bufio.Reader, out io.Writer) *debugger {
+	return &debugger{Recorder: rec, breaks: make(map[string]bool), in: in, out: out}
+}
+
+// rules returns the rules being matched, the innermost one first.
+func (p *debugger) rules() (ret []*matcher.Trace) {
+	for t := p.Current(); t != nil; t = t.Parent {
+		if t.Kind == matcher.TraceVar {
+			ret = append(ret, t)
+		}
+	}
+	return
+}
+
+func (p *debugger) Enter(kind matcher.TraceKind, name string, src []*types.Token) {
+	p.Recorder.Enter(kind, name, src)
+	if kind != matcher.TraceVar {
+		return
+	}
+	depth := len(p.rules())
+	if p.step || p.breaks[name] || depth <= p.depth {
+		p.step, p.depth = false, 0
+		p.prompt(src, depth)
+	}
+}
+
+func (p *debugger) Leave(n int, result any, err error) {
+	t := p.Current()
+	if t.Kind != matcher.TraceVar {
+		p.Recorder.Leave(n, result, err)
+		return
+	}
+	depth := len(p.rules())
+	p.Recorder.Leave(n, result, err)
+	if p.step || depth <= p.depth {
+		fmt.Fprintln(p.out, "<-", t)
+	}
+}
+
+func (p *debugger) prompt(src []*types.Token, depth int) {
+	where := "EOF"
+	if len(src) > 0 {
+		where = fmt.Sprintf("%v `%s`", p.Fset.Position(src[0].Pos), matcher.TokensText(src[:1]))
+	}
+	fmt.Fprintf(p.out, "-> %s at %s\n", p.Current().Name, where)
+	for {
+		fmt.Fprint(p.out, "(debug) ")
+		line, err := p.in.ReadString('\n')
+		if err != nil && line == "" {
+			os.Exit(0)
+		}
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+		switch args[0] {
+		case "s", "step":
+			p.step = true
+			return
+		case "n", "next":
+			p.depth = depth
+			return
+		case "o", "out":
+			p.depth = depth - 1
+			if p.depth == 0 { // the top-level rule
+				p.step = false
+			}
+			return
+		case "c", "continue":
+			return
+		case "b":
+			if len(args) > 1 {
+				p.breaks[args[1]] = true
+			} else {
+				names := make([]string, 0, len(p.breaks))
+				for name := range p.breaks {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				fmt.Fprintln(p.out, "breakpoints:", strings.Join(names, " "))
+			}
+		case "d":
+			if len(args) > 1 {
+				delete(p.breaks, args[1])
+			}
+		case "bt":
+			for _, t := range p.rules() {
+				fmt.Fprintf(p.out, "  %s [%d:]\n", t.Name, t.From)
+			}
+		case "t":
+			if rules := p.rules(); len(rules) > 1 {
+				rules[1].Fprint(p.out, "  ")
+			}
+		case "q", "quit":
+			os.Exit(0)
+		default:
+			fmt.Fprint(p.out, debugHelp)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gopast "github.com/goplus/gop/ast"
+	"github.com/goplus/gop/cmd/internal/base"
+	gopparser "github.com/goplus/gop/parser"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/export"
+	"github.com/goplus/gop/tpl/parser"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// gop tpl export
+var CmdExport = &base.Command{
+	UsageLine: "gop tpl export [-format ebnf|html|svg|textmate] [-o output] [-name name] grammarFile",
+	Short:     "Export a TPL grammar to EBNF, railroad diagrams or a TextMate grammar",
+}
+
+var (
+	exportFlag   = &CmdExport.Flag
+	exportFormat = exportFlag.String("format", "ebnf", "output format: ebnf, html, svg or textmate.")
+	exportOutput = exportFlag.String("o", "", "output file (stdout by default).")
+	exportName   = exportFlag.String("name", "", "name of the language (name of grammarFile by default).")
+)
+
+func init() {
+	CmdExport.Run = runExport
+}
+
+func runExport(cmd *base.Command, args []string) {
+	if err := exportFlag.Parse(args); err != nil || exportFlag.NArg() != 1 {
+		cmd.Usage(os.Stderr)
+		os.Exit(2)
+	}
+	file := exportFlag.Arg(0)
+	fset := token.NewFileSet()
+	f, err := parseGrammar(fset, file)
+	if err != nil {
+		fatal(err)
+	}
+	f, _, err = cl.Merge(&cl.Config{ // resolve imports and extensions of rules
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+	}, fset, f)
+	if err != nil {
+		fatal(err)
+	}
+	name := *exportName
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	var b bytes.Buffer
+	switch *exportFormat {
+	case "ebnf":
+		err = export.EBNF(&b, f)
+	case "html":
+		err = export.HTML(&b, name, f)
+	case "svg":
+		err = export.SVG(&b, f)
+	case "textmate":
+		err = export.TextMate(&b, &export.TextMateConfig{Name: name, FileTypes: []string{name}}, f)
+	default:
+		fatal("unknown format: " + *exportFormat)
+	}
+	if err != nil {
+		fatal(err)
+	}
+	if *exportOutput == "" {
+		os.Stdout.Write(b.Bytes())
+	} else if err = os.WriteFile(*exportOutput, b.Bytes(), 0666); err != nil {
+		fatal(err)
+	}
+}
+
+// parseGrammar parses a grammar file, or the first tpl`...` literal in a Go+
+// source file.
+func parseGrammar(fset *token.FileSet, file string) (f *ast.File, err error) {
+	ext := filepath.Ext(file)
+	if ext != ".gop" && ext != ".gox" {
+		return parser.ParseFile(fset, file, nil, nil)
+	}
+	var mode gopparser.Mode
+	if ext == ".gox" {
+		mode = gopparser.ParseGoPlusClass
+	}
+	src, err := gopparser.ParseFile(fset, file, nil, mode)
+	if err != nil {
+		return
+	}
+	gopast.Inspect(src, func(node gopast.Node) bool {
+		if lit, ok := node.(*gopast.DomainTextLit); ok && f == nil {
+			f, _ = lit.Extra.(*ast.File)
+		}
+		return f == nil
+	})
+	if f == nil {
+		err = errors.New(file + ": no tpl grammar found")
+	}
+	return
+}
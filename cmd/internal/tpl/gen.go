@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"os"
+	"strings"
+
+	"github.com/goplus/gop/cmd/internal/base"
+	"github.com/goplus/gop/tpl/gen"
+)
+
+// gop tpl gen
+var CmdGen = &base.Command{
+	UsageLine: "gop tpl gen [-o output] [-pkg name] [-memo] [-imports name=path,...] grammarFile",
+	Short:     "Generate a Go parser from a TPL grammar",
+}
+
+var (
+	genFlag    = &CmdGen.Flag
+	genOutput  = genFlag.String("o", "", "output file of the generated parser (stdout by default).")
+	genPkg     = genFlag.String("pkg", "", "package name of the generated parser (parser by default).")
+	genMemo    = genFlag.Bool("memo", false, "enable packrat memoization of rules without actions.")
+	genImports = genFlag.String("imports", "", "comma-separated `name=path` list of packages referred by actions.")
+)
+
+func init() {
+	CmdGen.Run = runGen
+}
+
+func runGen(cmd *base.Command, args []string) {
+	if err := genFlag.Parse(args); err != nil || genFlag.NArg() != 1 {
+		cmd.Usage(os.Stderr)
+		os.Exit(2)
+	}
+	conf := &gen.Config{Package: *genPkg, Memo: *genMemo}
+	if *genImports != "" {
+		conf.Imports = make(map[string]string)
+		for _, item := range strings.Split(*genImports, ",") {
+			name, path, ok := strings.Cut(item, "=")
+			if !ok {
+				fatal("invalid import: " + item)
+			}
+			conf.Imports[name] = path
+		}
+	}
+	code, err := gen.FromFile(nil, genFlag.Arg(0), nil, conf)
+	if err != nil {
+		fatal(err)
+	}
+	if *genOutput == "" {
+		os.Stdout.Write(code)
+	} else if err = os.WriteFile(*genOutput, code, 0666); err != nil {
+		fatal(err)
+	}
+}
@@ -1559,6 +1559,15 @@ func (p *parser) tryIdentOrType(state int, len ast.Expr) (ast.Expr, int) {
 		lparen := p.pos
 		p.next()
 		typ := p.parseType()
+		if p.tok == token.COMMA { // (T1, T2, ...)
+			elts := []ast.Expr{typ}
+			for p.tok == token.COMMA {
+				p.next()
+				elts = append(elts, p.parseType())
+			}
+			rparen := p.expect(token.RPAREN)
+			return &ast.TupleType{Lparen: lparen, Elts: elts, Rparen: rparen}, resultType
+		}
 		rparen := p.expect(token.RPAREN)
 		return &ast.ParenExpr{Lparen: lparen, X: typ, Rparen: rparen}, resultParenType
 	}
@@ -1798,6 +1807,14 @@ func (p *parser) parseOperand(lhs, allowTuple, allowCmd bool) (x ast.Expr, isTup
 				log.Printf("ast.DomainTextLit{Domain: %s, Value: %s}\n", ident.Name, lit)
 			}
 			p.next()
+		} else if ident.Name == "async" && p.tok == token.FUNC && p.pos != ident.End() {
+			// async func(params) results { ... }
+			x = p.parseFuncTypeOrLit()
+			if lit, ok := x.(*ast.FuncLit); ok {
+				lit.Async = ident.NamePos
+			} else {
+				p.errorExpected(p.pos, "function body", 2)
+			}
 		} else {
 			x = ident
 			if !lhs {
@@ -1840,7 +1857,19 @@ func (p *parser) parseOperand(lhs, allowTuple, allowCmd bool) (x ast.Expr, isTup
 			return &tupleExpr{opening: lparen, closing: p.pos}, true
 		}
 		p.exprLev++
-		x = p.parseRHSOrType() // types may be parenthesized: (some type)
+		x = p.parseRHSOrType()  // types may be parenthesized: (some type)
+		if p.tok == token.FOR { // (expr for k, v in container if cond)
+			phrases := p.parseForPhrases()
+			p.exprLev--
+			rparen := p.expect(token.RPAREN)
+			if debugParseOutput {
+				log.Printf("ast.ComprehensionExpr{Tok: (, Elt: %v, Fors: %v}\n", x, phrases)
+			}
+			return &ast.ComprehensionExpr{
+				Lpos: lparen, Tok: token.LPAREN, Elt: x,
+				Fors: phrases, Rpos: rparen,
+			}, false
+		}
 		if allowTuple && (p.tok == token.COMMA || p.tok == token.ELLIPSIS) {
 			// (x, y, ...) => expr
 			items := make([]ast.Expr, 1, 2)
@@ -2289,12 +2318,14 @@ func (p *parser) checkExpr(x ast.Expr) ast.Expr {
 	case *ast.UnaryExpr:
 	case *ast.BinaryExpr:
 	case *ast.RangeExpr:
+	case *ast.AwaitExpr:
 	case *ast.ErrWrapExpr:
 	case *ast.LambdaExpr:
 	case *ast.LambdaExpr2:
 	case *tupleExpr:
 		p.error(v.opening, msgTupleNotSupported)
 		x = &ast.BadExpr{From: v.opening, To: v.closing}
+	case *ast.TupleLit:
 	case *ast.EnvExpr:
 	case *ast.ElemEllipsis:
 	case *ast.NumberUnitLit:
@@ -2363,8 +2394,8 @@ L:
 				x = p.parseSelector(p.checkExprOrType(x))
 			case token.LPAREN:
 				x = p.parseTypeAssertion(p.checkExpr(x))
-			case token.GOTO, token.BREAK, token.CONTINUE, token.FALLTHROUGH:
-				// Go+: allow goto() as a function
+			case token.GOTO, token.BREAK, token.CONTINUE, token.FALLTHROUGH, token.MAP:
+				// Go+: allow goto() as a function, and a.map(...) as a method
 				p.tok = token.IDENT
 				x = p.parseSelector(p.checkExprOrType(x))
 			default:
@@ -2547,11 +2578,40 @@ func (p *parser) parseUnaryExpr(lhs, allowTuple, allowCmd bool) (ast.Expr, bool)
 		p.next()
 		x, _ := p.parseUnaryExpr(false, false, false)
 		return &ast.StarExpr{Star: pos, X: p.checkExprOrType(x)}, false
+
+	case token.IDENT:
+		if p.lit == "await" {
+			if x, ok := p.tryAwaitExpr(); ok {
+				return x, false
+			}
+		}
 	}
 
 	return p.parseErrWrapExpr(lhs, allowTuple, allowCmd)
 }
 
+// tryAwaitExpr: await expr, await expr? ...
+//
+// `await` is an identifier unless it's followed by an operand, so that
+// `await(x)` is still a call of a function named await.
+func (p *parser) tryAwaitExpr() (ast.Expr, bool) {
+	pos, lit := p.pos, p.lit
+	p.next()
+	if p.pos != pos+token.Pos(len(lit)) {
+		switch p.tok {
+		case token.IDENT, token.LPAREN:
+			x, _ := p.parseErrWrapExpr(false, false, false)
+			if e, ok := x.(*ast.ErrWrapExpr); ok { // await expr? => (await expr)?
+				e.X = &ast.AwaitExpr{Await: pos, X: p.checkExpr(e.X)}
+				return e, true
+			}
+			return &ast.AwaitExpr{Await: pos, X: p.checkExpr(x)}, true
+		}
+	}
+	p.unget(pos, token.IDENT, lit)
+	return nil, false
+}
+
 func (p *parser) tokPrec() (token.Token, int) {
 	tok := p.tok
 	if p.inRHS && tok == token.ASSIGN {
@@ -2620,6 +2680,9 @@ type tupleExpr struct {
 	closing  token.Pos
 }
 
+func (p *tupleExpr) Pos() token.Pos { return p.opening }
+func (p *tupleExpr) End() token.Pos { return p.closing + 1 }
+
 func (p *parser) parseLambdaExpr(allowTuple, allowCmd, allowRangeExpr bool) (x ast.Expr, isTuple bool) {
 	var first = p.pos
 	if p.tok != token.DRARROW {
@@ -2701,12 +2764,24 @@ func (p *parser) parseLambdaExpr(allowTuple, allowCmd, allowRangeExpr bool) (x a
 			RhsHasParen: rhsHasParen,
 		}, false
 	} else if isTuple && !allowTuple {
-		p.error(x.(*tupleExpr).opening, msgTupleNotSupported)
-		p.advance(stmtStart)
+		x, isTuple = p.toTupleLit(x.(*tupleExpr))
 	}
 	return
 }
 
+// toTupleLit converts (x1, x2, ...) which isn't followed by "=>" into a tuple literal.
+func (p *parser) toTupleLit(t *tupleExpr) (ast.Expr, bool) {
+	if len(t.items) < 2 || t.ellipsis.IsValid() {
+		p.error(t.opening, msgTupleNotSupported)
+		p.advance(stmtStart)
+		return t, true
+	}
+	if debugParseOutput {
+		log.Printf("ast.TupleLit{Elts: %v}\n", t.items)
+	}
+	return &ast.TupleLit{Lparen: t.opening, Elts: t.items, Rparen: t.closing}, false
+}
+
 // If lhs is set and the result is an identifier, it is not resolved.
 // The result may be a type or even a raw type ([...]int). Callers must
 // check the result (using checkExpr or checkExprOrType), depending on
@@ -2716,7 +2791,11 @@ func (p *parser) parseExprEx(lhs, allowTuple, allowCmd, allowRangeExpr bool) (as
 		defer un(trace(p, "Expression"))
 	}
 	if lhs {
-		return p.parseBinaryExpr(true, token.LowestPrec+1, false, allowCmd)
+		x, isTuple := p.parseBinaryExpr(true, token.LowestPrec+1, true, allowCmd)
+		if isTuple { // for (v1, v2) in container
+			return p.toTupleLit(x.(*tupleExpr))
+		}
+		return x, false
 	}
 	return p.parseLambdaExpr(allowTuple, allowCmd, allowRangeExpr)
 }
@@ -3056,7 +3135,7 @@ func (p *parser) parseIfHeader() (init ast.Stmt, cond ast.Expr) {
 }
 
 func isForPhraseCondEnd(tok token.Token) bool {
-	return tok == token.RBRACK || tok == token.RBRACE || tok == token.FOR
+	return tok == token.RBRACK || tok == token.RBRACE || tok == token.RPAREN || tok == token.FOR
 }
 
 // parseForPhraseCond is an adjusted version of parseIfHeader
@@ -3371,15 +3450,28 @@ func (p *parser) parseForPhraseStmtPart(lhs []ast.Expr) *ast.ForPhraseStmt {
 	stmt := &ast.ForPhraseStmt{ForPhrase: &ast.ForPhrase{TokPos: tokPos, X: x, IfPos: ifPos, Cond: cond}}
 	switch len(lhs) {
 	case 1:
-		stmt.Value = p.toIdent(lhs[0])
+		stmt.Value, stmt.Tuple = p.toValueIdent(lhs[0])
 	case 2:
-		stmt.Key, stmt.Value = p.toIdent(lhs[0]), p.toIdent(lhs[1])
+		stmt.Key = p.toIdent(lhs[0])
+		stmt.Value, stmt.Tuple = p.toValueIdent(lhs[1])
 	default:
 		log.Panicln("TODO: parseForPhraseStmt - too many variables, 1 or 2 is required")
 	}
 	return stmt
 }
 
+// toValueIdent converts the value of a for phrase, which may be
+// destructured as (v1, v2, ...).
+func (p *parser) toValueIdent(e ast.Expr) (*ast.Ident, *ast.TupleLit) {
+	if t, ok := e.(*ast.TupleLit); ok {
+		for _, elt := range t.Elts {
+			p.toIdent(elt)
+		}
+		return nil, t
+	}
+	return p.toIdent(e), nil
+}
+
 func (p *parser) toIdent(e ast.Expr) *ast.Ident {
 	switch v := e.(type) {
 	case *ast.Ident:
@@ -3404,10 +3496,20 @@ func (p *parser) parseForPhrase() *ast.ForPhrase { // for k, v in container if c
 	defer p.closeScope()
 
 	var k, v *ast.Ident
-	v = p.parseIdent()
-	if p.tok == token.COMMA { // k, v
-		p.next()
-		k, v = v, p.parseIdent()
+	var tuple *ast.TupleLit
+	if p.tok == token.LPAREN { // (v1, v2, ...)
+		tuple = p.parseTupleIdents()
+	} else {
+		v = p.parseIdent()
+		if p.tok == token.COMMA { // k, v
+			p.next()
+			k = v
+			if p.tok == token.LPAREN { // k, (v1, v2, ...)
+				v, tuple = nil, p.parseTupleIdents()
+			} else {
+				v = p.parseIdent()
+			}
+		}
 	}
 
 	tokPos := p.expectIn() // in container
@@ -3420,7 +3522,21 @@ func (p *parser) parseForPhrase() *ast.ForPhrase { // for k, v in container if c
 		p.next()
 		init, cond = p.parseForPhraseCond()
 	}
-	return &ast.ForPhrase{For: pos, Key: k, Value: v, TokPos: tokPos, X: x, IfPos: ifPos, Init: init, Cond: cond}
+	return &ast.ForPhrase{For: pos, Key: k, Value: v, Tuple: tuple, TokPos: tokPos, X: x, IfPos: ifPos, Init: init, Cond: cond}
+}
+
+func (p *parser) parseTupleIdents() *ast.TupleLit { // (v1, v2, ...)
+	lparen := p.expect(token.LPAREN)
+	elts := []ast.Expr{p.parseIdent()}
+	for p.tok == token.COMMA {
+		p.next()
+		elts = append(elts, p.parseIdent())
+	}
+	rparen := p.expect(token.RPAREN)
+	if len(elts) < 2 {
+		p.error(lparen, msgTupleNotSupported)
+	}
+	return &ast.TupleLit{Lparen: lparen, Elts: elts, Rparen: rparen}
 }
 
 func (p *parser) parseForStmt() ast.Stmt {
@@ -4032,20 +4148,104 @@ func (p *parser) parseDecl(sync map[token.Token]bool) ast.Decl {
 	case token.TYPE:
 		f = p.parseTypeSpec
 	case token.FUNC:
-		decl, call := p.parseFuncDeclOrCall()
-		if decl != nil {
-			if p.errors.Len() != 0 {
-				p.advance(sync)
+		return p.parseFuncDecl(sync, pos, token.NoPos)
+	default:
+		if p.tok == token.IDENT && p.lit == "async" { // async func ...
+			doc := p.leadComment
+			p.next()
+			if p.tok == token.FUNC && p.pos != pos+token.Pos(len("async")) {
+				p.leadComment = doc
+				return p.parseFuncDecl(sync, pos, pos)
+			}
+			p.unget(pos, token.IDENT, "async")
+			p.leadComment = doc
+		} else if p.tok == token.IDENT && p.lit == "prop" && p.inClassFile() { // prop Name T { ... }
+			doc := p.leadComment
+			p.next()
+			if p.tok == token.IDENT && p.pos != pos+token.Pos(len("prop")) {
+				decl := p.parsePropDecl(doc, pos)
+				if p.errors.Len() != 0 {
+					p.advance(sync)
+				}
+				return decl
 			}
-			return decl
+			p.unget(pos, token.IDENT, "prop")
+			p.leadComment = doc
 		}
-		return p.parseGlobalStmts(sync, pos, &ast.ExprStmt{X: call})
-	default:
 		return p.parseGlobalStmts(sync, pos)
 	}
 	return p.parseGenDecl(p.tok, f)
 }
 
+// parsePropDecl: prop Name T { get => expr; set v => { ... } }
+func (p *parser) parsePropDecl(doc *ast.CommentGroup, pos token.Pos) *ast.PropDecl {
+	if p.trace {
+		defer un(trace(p, "PropDecl"))
+	}
+	decl := &ast.PropDecl{Doc: doc, Prop: pos, Name: p.parseIdent()}
+	decl.Type = p.parseType()
+	decl.Lbrace = p.expect(token.LBRACE)
+	bad := false
+	for p.tok != token.RBRACE && p.tok != token.EOF {
+		tokPos, lit := p.pos, p.lit
+		if p.tok != token.IDENT || (lit != "get" && lit != "set") {
+			p.errorExpected(tokPos, "get or set", 2)
+			p.advance(stmtStart)
+			bad = true
+			break
+		}
+		p.next()
+		acc := &ast.PropAccessor{TokPos: tokPos}
+		if lit == "set" {
+			acc.Param = p.parseIdent()
+		}
+		acc.Rarrow = p.expect(token.DRARROW)
+		if p.tok == token.LBRACE {
+			acc.Body = p.parseBlockStmt()
+		} else {
+			acc.X = p.parseExpr(false, false, false)
+		}
+		switch {
+		case lit == "get" && decl.Get == nil:
+			decl.Get = acc
+		case lit == "set" && decl.Set == nil:
+			decl.Set = acc
+		default:
+			p.error(tokPos, "duplicate "+lit+" of property "+decl.Name.Name)
+		}
+		if p.tok != token.RBRACE {
+			p.expectSemi()
+		}
+	}
+	decl.Rbrace = p.expect(token.RBRACE)
+	p.expectSemi()
+	if decl.Get == nil && !bad {
+		p.error(decl.Lbrace, "missing get of property "+decl.Name.Name)
+	}
+	return decl
+}
+
+func (p *parser) parseFuncDecl(sync map[token.Token]bool, pos, async token.Pos) ast.Decl {
+	decl, call := p.parseFuncDeclOrCall()
+	if decl != nil {
+		if async != token.NoPos {
+			if d, ok := decl.(*ast.FuncDecl); ok {
+				d.Async = async
+			} else {
+				p.error(async, "overload can't be async")
+			}
+		}
+		if p.errors.Len() != 0 {
+			p.advance(sync)
+		}
+		return decl
+	}
+	if async != token.NoPos {
+		call.Fun.(*ast.FuncLit).Async = async
+	}
+	return p.parseGlobalStmts(sync, pos, &ast.ExprStmt{X: call})
+}
+
 func (p *parser) parseGlobalStmts(sync map[token.Token]bool, pos token.Pos, stmts ...ast.Stmt) *ast.FuncDecl {
 	p.topScope = ast.NewScope(p.topScope)
 	doc := p.leadComment
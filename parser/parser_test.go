@@ -132,7 +132,8 @@ func TestErrTplLit(t *testing.T) {
 func TestErrTuple(t *testing.T) {
 	testErrCode(t, `println (1,2)*2`, `/foo/bar.gop:1:9: tuple is not supported`, ``)
 	testErrCode(t, `println 2*(1,2)`, `/foo/bar.gop:1:13: expected ')', found ','`, ``)
-	testErrCode(t, `func test() (int,int) { return (100,100)`, `/foo/bar.gop:1:32: tuple is not supported`, ``)
+	testErrCode(t, `func test() (int,int) { return (a,b...)`, `/foo/bar.gop:1:32: tuple is not supported`, ``)
+	testErrCode(t, `defer ()`, `/foo/bar.gop:1:7: tuple is not supported`, ``)
 }
 
 func TestErrOperand(t *testing.T) {
@@ -260,6 +261,22 @@ var (
 const c = 100
 const d
 `, `/foo/bar.gox:5:7: missing constant value`, ``)
+	testClassErrCode(t, `
+prop Name string {
+	set v => setName(v)
+}
+`, `/foo/bar.gox:2:18: missing get of property Name`, ``)
+	testClassErrCode(t, `
+prop Name string {
+	get => name
+	get => "x"
+}
+`, `/foo/bar.gox:4:2: duplicate get of property Name`, ``)
+	testClassErrCode(t, `
+prop Name string {
+	let => name
+}
+`, `/foo/bar.gox:3:2: expected get or set, found let (and 1 more errors)`, ``)
 }
 
 func TestErrGlobal(t *testing.T) {
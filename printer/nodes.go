@@ -830,6 +830,9 @@ func (p *printer) expr1(expr ast.Expr, prec1, depth int) {
 		p.print(&ast.Ident{Name: x.Unit})
 
 	case *ast.FuncLit:
+		if x.Async.IsValid() {
+			p.print(x.Async, &ast.Ident{NamePos: x.Async, Name: "async"}, blank)
+		}
 		p.print(x.Type.Pos(), token.FUNC)
 		// See the comment in funcDecl about how the header size is computed.
 		startCol := p.out.Column - len("func")
@@ -1041,6 +1044,16 @@ func (p *printer) expr1(expr ast.Expr, prec1, depth int) {
 			p.print(x.Colon, token.COLON)
 			p.expr0(x.Y, depth+1)
 		*/
+	case *ast.TupleType:
+		p.print(token.LPAREN)
+		p.exprList(x.Lparen, x.Elts, depth+1, 0, x.Rparen, false)
+		p.print(x.Rparen, token.RPAREN)
+
+	case *ast.TupleLit:
+		p.print(token.LPAREN)
+		p.exprList(x.Lparen, x.Elts, depth+1, 0, x.Rparen, false)
+		p.print(x.Rparen, token.RPAREN)
+
 	case *ast.SliceLit:
 		p.print(token.LBRACK)
 		p.exprList(x.Lbrack, x.Elts, depth+1, commaTerm, x.Rbrack, x.Incomplete)
@@ -1058,6 +1071,12 @@ func (p *printer) expr1(expr ast.Expr, prec1, depth int) {
 			p.print(blank)
 			p.listForPhrase(x.Fors)
 			p.print(token.RBRACK)
+		case token.LPAREN: // (...)
+			p.print(token.LPAREN)
+			p.expr0(x.Elt, depth+1)
+			p.print(blank)
+			p.listForPhrase(x.Fors)
+			p.print(token.RPAREN)
 		default: // {...}
 			p.print(token.LBRACE)
 			if x.Elt != nil {
@@ -1073,6 +1092,9 @@ func (p *printer) expr1(expr ast.Expr, prec1, depth int) {
 			p.listForPhrase(x.Fors)
 			p.print(token.RBRACE)
 		}
+	case *ast.AwaitExpr:
+		p.print(x.Await, &ast.Ident{NamePos: x.Await, Name: "await"}, blank)
+		p.expr1(x.X, token.UnaryPrec, depth)
 	case *ast.ErrWrapExpr:
 		p.expr(x.X)
 		p.print(x.Tok)
@@ -1158,8 +1180,8 @@ func (p *printer) listForPhrase(list []*ast.ForPhrase) {
 			p.expr(x.Key)
 			p.print(token.COMMA, blank)
 		}
-		p.print(x.Value, blank)
-		p.print(x.TokPos, in, blank)
+		p.forPhraseValue(x)
+		p.print(blank, x.TokPos, in, blank)
 		p.expr(x.X)
 		if x.Cond != nil {
 			p.print(blank, x.Cond.Pos(), token.IF, blank)
@@ -1172,6 +1194,14 @@ func (p *printer) listForPhrase(list []*ast.ForPhrase) {
 	}
 }
 
+func (p *printer) forPhraseValue(x *ast.ForPhrase) {
+	if x.Tuple != nil {
+		p.expr(x.Tuple)
+	} else {
+		p.expr(x.Value)
+	}
+}
+
 func (p *printer) possibleSelectorExpr(expr ast.Expr, prec1, depth int) bool {
 	if x, ok := expr.(*ast.SelectorExpr); ok {
 		return p.selectorExpr(x, depth, true)
@@ -1570,7 +1600,7 @@ func (p *printer) stmt(stmt ast.Stmt, nextIsRBrace bool) {
 			p.expr(s.Key)
 			p.print(token.COMMA, blank)
 		}
-		p.expr(s.Value)
+		p.forPhraseValue(s.ForPhrase)
 		p.print(blank, s.TokPos, in, blank)
 		p.expr(s.X)
 		if s.Cond != nil {
@@ -2030,11 +2060,17 @@ func (p *printer) funcDecl(d *ast.FuncDecl) {
 	}
 
 	pos := d.Pos()
-	p.print(pos, token.FUNC, blank)
+	if d.Async.IsValid() {
+		p.print(pos, &ast.Ident{NamePos: pos, Name: "async"}, blank)
+	}
+	p.print(d.Type.Pos(), token.FUNC, blank)
 	// We have to save startCol only after emitting FUNC; otherwise it can be on a
 	// different line (all whitespace preceding the FUNC is emitted only when the
 	// FUNC is emitted).
 	startCol := p.out.Column - len("func ")
+	if d.Async.IsValid() {
+		startCol -= len("async ")
+	}
 	if d.Recv != nil {
 		if d.Static { // static method
 			if !d.IsClass {
@@ -2078,6 +2114,39 @@ func (p *printer) overloadFuncDecl(d *ast.OverloadFuncDecl) {
 	p.print(token.RPAREN)
 }
 
+func (p *printer) propDecl(d *ast.PropDecl) {
+	p.setComment(d.Doc)
+	p.print(d.Pos(), &ast.Ident{NamePos: d.Prop, Name: "prop"}, blank)
+	p.expr(d.Name)
+	p.print(blank)
+	p.expr(d.Type)
+	p.print(blank, d.Lbrace, token.LBRACE, indent)
+	for _, acc := range []*ast.PropAccessor{d.Get, d.Set} {
+		if acc == nil {
+			continue
+		}
+		p.linebreak(p.lineFor(acc.Pos()), 1, ignore, true)
+		name := "get"
+		if acc.Param != nil {
+			name = "set"
+		}
+		p.print(acc.TokPos, &ast.Ident{NamePos: acc.TokPos, Name: name}, blank)
+		if acc.Param != nil {
+			p.expr(acc.Param)
+			p.print(blank)
+		}
+		p.print(acc.Rarrow, token.DRARROW, blank)
+		if acc.Body != nil {
+			p.block(acc.Body, 1)
+		} else {
+			p.expr(acc.X)
+		}
+	}
+	p.print(unindent)
+	p.linebreak(p.lineFor(d.Rbrace), 1, ignore, true)
+	p.print(d.Rbrace, token.RBRACE)
+}
+
 func (p *printer) decl(decl ast.Decl) {
 	switch d := decl.(type) {
 	case *ast.BadDecl:
@@ -2088,6 +2157,8 @@ func (p *printer) decl(decl ast.Decl) {
 		p.funcDecl(d)
 	case *ast.OverloadFuncDecl:
 		p.overloadFuncDecl(d)
+	case *ast.PropDecl:
+		p.propDecl(d)
 	default:
 		panic("unreachable")
 	}
@@ -1057,6 +1057,8 @@ func getDoc(n ast.Node) *ast.CommentGroup {
 		return n.Doc
 	case *ast.FuncDecl:
 		return n.Doc
+	case *ast.PropDecl:
+		return n.Doc
 	case *ast.File:
 		return n.Doc
 	}
@@ -0,0 +1,930 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package analysis implements static checks of tpl grammars, such as
+// undefined, unused or unreachable rules, FIRST/FOLLOW conflicts, repetitions
+// that loop forever and rules that can never succeed.
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	gopast "github.com/goplus/gop/ast"
+	gopparser "github.com/goplus/gop/parser"
+	"github.com/goplus/gop/parser/iox"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/parser"
+	"github.com/goplus/gop/tpl/token"
+	"github.com/qiniu/x/errors"
+)
+
+// -----------------------------------------------------------------------------
+
+// Severity represents the severity of a diagnostic.
+type Severity int
+
+const (
+	// Warning means the grammar works, but maybe not as expected.
+	Warning Severity = iota
+	// Error means the grammar is broken.
+	Error
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Kind represents the kind of a diagnostic.
+type Kind string
+
+const (
+	Undefined   Kind = "undefined"   // reference to an undefined rule
+	Unused      Kind = "unused"      // rule that is never referenced
+	Unreachable Kind = "unreachable" // rule or alternative that is never tried
+	Conflict    Kind = "conflict"    // FIRST/FIRST or FIRST/FOLLOW conflict
+	Loop        Kind = "loop"        // repetition of a nullable expression
+	Never       Kind = "never"       // rule that can never succeed
+	Invalid     Kind = "invalid"     // grammar with imports or extensions of rules that can't be compiled
+)
+
+// Diagnostic represents a problem found in a grammar.
+type Diagnostic struct {
+	Pos      token.Position
+	Severity Severity
+	Kind     Kind
+	Rule     string // rule where the problem is found
+	Msg      string
+}
+
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("%v: %v: %s", d.Pos, d.Severity, d.Msg)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d *Diagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		File     string   `json:"file"`
+		Line     int      `json:"line"`
+		Column   int      `json:"column"`
+		Severity Severity `json:"severity"`
+		Kind     Kind     `json:"kind"`
+		Rule     string   `json:"rule"`
+		Msg      string   `json:"message"`
+	}{d.Pos.Filename, d.Pos.Line, d.Pos.Column, d.Severity, d.Kind, d.Rule, d.Msg})
+}
+
+// -----------------------------------------------------------------------------
+
+// CheckFile checks the grammar in a file. If the file is a Go+ source file
+// (.gop or .gox), every tpl`...` literal in it is checked as a grammar, and
+// the positions of diagnostics refer to the Go+ source file.
+func CheckFile(fset *token.FileSet, filename string, src any) (ret []*Diagnostic, err error) {
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	switch filepath.Ext(filename) {
+	case ".gop", ".gox":
+		b, err := iox.ReadSourceLocal(filename, src)
+		if err != nil {
+			return nil, err
+		}
+		var mode gopparser.Mode
+		if filepath.Ext(filename) == ".gox" {
+			mode = gopparser.ParseGoPlusClass
+		}
+		f, err := gopparser.ParseFile(fset, filename, b, mode)
+		if err != nil {
+			return nil, err
+		}
+		gopast.Inspect(f, func(node gopast.Node) bool {
+			if lit, ok := node.(*gopast.DomainTextLit); ok {
+				if g, ok := lit.Extra.(*ast.File); ok {
+					ret = append(ret, Check(fset, g)...)
+				}
+			}
+			return true
+		})
+		return ret, nil
+	}
+	f, err := parser.ParseFile(fset, filename, src, nil)
+	if err != nil {
+		return
+	}
+	return Check(fset, f), nil
+}
+
+// Check checks a grammar made up of the given files, whose first rule is the
+// document rule. The diagnostics are sorted by position.
+//
+// If the grammar imports rules or extends them, such as R |= ..., it's merged
+// by cl.Merge, whose errors are reported as invalid, and the merged rules are
+// checked. Imported rules aren't reported as unused or unreachable, since a
+// grammar usually uses a part of the rules it imports.
+func Check(fset *token.FileSet, files ...*ast.File) []*Diagnostic {
+	p := &checker{fset: fset, rules: make(map[string]*ast.Rule)}
+	merge := false
+	own := make(map[string]bool) // rules defined by files
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.ImportDecl:
+				merge = true
+			case *ast.Rule:
+				if decl.IsExtension() {
+					merge = true
+				} else {
+					own[decl.Name.Name] = true
+				}
+			}
+		}
+	}
+	if merge {
+		conf := &cl.Config{OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {}}
+		f, _, err := cl.Merge(conf, fset, files...)
+		if err != nil {
+			p.reportErrors(err)
+			return p.diags
+		}
+		files, p.own = []*ast.File{f}, own
+	}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if decl, ok := decl.(*ast.Rule); ok {
+				if _, ok := p.rules[decl.Name.Name]; !ok {
+					p.rules[decl.Name.Name] = decl
+					p.order = append(p.order, decl)
+				}
+			}
+		}
+	}
+	if len(p.order) > 0 {
+		p.check()
+	}
+	sort.SliceStable(p.diags, func(i, j int) bool {
+		a, b := p.diags[i].Pos, p.diags[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return p.diags
+}
+
+// -----------------------------------------------------------------------------
+
+// term is an element of FIRST and FOLLOW sets: a token class if lit is empty,
+// or a literal otherwise. A token class may exclude some literals of it, eg.
+// IDENT of `!keyword IDENT`.
+type term struct {
+	tok    token.Token
+	lit    string
+	except string // excluded literals, separated by "-"
+}
+
+func (t term) String() string {
+	if t.lit != "" {
+		return t.lit
+	}
+	if t.except != "" {
+		return t.tok.String() + "-" + t.except
+	}
+	return t.tok.String()
+}
+
+// excludes reports whether lit is excluded from token class t.
+func (t term) excludes(lit string) bool {
+	for _, e := range strings.Split(t.except, "-") {
+		if e == lit {
+			return true
+		}
+	}
+	return false
+}
+
+// conflicts reports whether a term of a conflicts with a term of b the way
+// tpl/matcher does: a token class conflicts with the same token class and the
+// literals of it which aren't excluded, but a literal conflicts with the same
+// literal only.
+func conflicts(a, b []term) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.tok != y.tok {
+				continue
+			}
+			if x.lit == "" && (y.lit == "" || !x.excludes(y.lit)) || x.lit == y.lit {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exclude excludes lits, the literals of a negative lookahead, from first, the
+// FIRST set of the items after it.
+func exclude(first []term, lits []term) []term {
+	ret := make([]term, 0, len(first))
+next:
+	for _, t := range first {
+		for _, lit := range lits {
+			if lit.tok != t.tok {
+				continue
+			}
+			if t.lit == lit.lit {
+				continue next
+			}
+			if t.lit == "" && !t.excludes(lit.lit) {
+				if t.except != "" {
+					t.except += "-"
+				}
+				t.except += lit.lit
+			}
+		}
+		ret = append(ret, t)
+	}
+	return ret
+}
+
+func union(a, b []term) ([]term, bool) {
+	changed := false
+next:
+	for _, y := range b {
+		for _, x := range a {
+			if x == y {
+				continue next
+			}
+		}
+		a, changed = append(a, y), true
+	}
+	return a, changed
+}
+
+func termsString(terms []term) string {
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = t.String()
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// tokenOf returns the token of a token class name, such as IDENT or QSTRING.
+func tokenOf(name string) (token.Token, bool) {
+	switch name {
+	case "RAWSTRING", "QSTRING":
+		return token.STRING, true
+	}
+	return token.Lookup(name)
+}
+
+// literal returns the term matched by a literal, or ok == false if lit
+// matches nothing (the empty string) or is invalid.
+func literal(lit *ast.BasicLit) (t term, ok bool) {
+	switch lit.Kind {
+	case token.CHAR:
+		v, multibyte, tail, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+		if err == nil && tail == "" && !multibyte {
+			return term{tok: token.Token(v)}, true
+		}
+	case token.REGEXP: // a token rule, such as NUMBER = /[0-9]+/
+		return term{tok: token.REGEXP, lit: lit.Value}, true
+	case token.STRING:
+		v, err := strconv.Unquote(lit.Value)
+		if err != nil || v == "" {
+			break
+		}
+		if c := v[0]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' {
+			return term{tok: token.IDENT, lit: v}, true
+		}
+		if tok, ok := token.Operator(v); ok {
+			return term{tok: tok}, true
+		}
+	}
+	return
+}
+
+// isEmpty reports whether lit is the empty string, which always matches.
+func isEmpty(lit *ast.BasicLit) bool {
+	return lit.Kind == token.STRING && (lit.Value == `""` || lit.Value == "``")
+}
+
+// -----------------------------------------------------------------------------
+
+type checker struct {
+	fset  *token.FileSet
+	rules map[string]*ast.Rule
+	order []*ast.Rule
+	own   map[string]bool // rules defined by the checked files, or nil if all
+	diags []*Diagnostic
+
+	nullable   map[string]bool // may succeed without consuming tokens
+	always     map[string]bool // always succeeds
+	productive map[string]bool // may succeed
+	first      map[string][]term
+	follow     map[string][]term
+
+	changed bool
+	rule    string // rule being checked
+}
+
+func (p *checker) report(pos token.Pos, sev Severity, kind Kind, format string, args ...any) {
+	p.diags = append(p.diags, &Diagnostic{
+		Pos: p.fset.Position(pos), Severity: sev, Kind: kind, Rule: p.rule,
+		Msg: fmt.Sprintf(format, args...),
+	})
+}
+
+// reportErrors reports the errors of cl.Merge.
+func (p *checker) reportErrors(err error) {
+	var errs []error
+	if list, ok := err.(errors.List); ok {
+		errs = list
+	} else {
+		errs = []error{err}
+	}
+	for _, e := range errs {
+		d := &Diagnostic{Severity: Error, Kind: Invalid, Msg: e.Error()}
+		if e, ok := e.(*matcher.Error); ok {
+			d.Pos, d.Msg = e.Fset.Position(e.Pos), e.Msg
+		}
+		p.diags = append(p.diags, d)
+	}
+}
+
+func (p *checker) check() {
+	p.nullable = make(map[string]bool)
+	p.always = make(map[string]bool)
+	p.productive = make(map[string]bool)
+	p.first = make(map[string][]term)
+	p.follow = make(map[string][]term)
+	p.fixpoint(func(r *ast.Rule) {
+		name := r.Name.Name
+		p.setBool(p.nullable, name, p.isNullable(r.Expr))
+		p.setBool(p.always, name, p.isAlways(r.Expr))
+		p.setBool(p.productive, name, p.isProductive(r.Expr))
+		p.addTerms(p.first, name, p.firstOf(r.Expr))
+	})
+	start := p.order[0].Name.Name
+	p.follow[start] = []term{{tok: token.EOF}}
+	p.fixpoint(func(r *ast.Rule) {
+		p.walkFollow(r.Expr, p.follow[r.Name.Name])
+	})
+
+	refs := make(map[string]bool)
+	for _, r := range p.order {
+		p.rule = r.Name.Name
+		p.refs(r.Expr, refs)
+	}
+	reached := map[string]bool{start: true}
+	p.reach(p.order[0].Expr, reached)
+	for _, r := range p.order {
+		name := r.Name.Name
+		p.rule = name
+		switch {
+		case name == start:
+		case p.own != nil && !p.own[name]: // imported rule
+		case !refs[name]:
+			p.report(r.Pos(), Warning, Unused, "rule `%s` is unused", name)
+		case !reached[name]:
+			p.report(r.Pos(), Warning, Unreachable, "rule `%s` is unreachable from `%s`", name, start)
+		}
+		if !p.productive[name] {
+			p.report(r.Pos(), Error, Never, "rule `%s` can never succeed", name)
+		}
+		p.checkExpr(r.Expr, p.follow[name])
+	}
+}
+
+// fixpoint calls f on every rule until nothing changes.
+func (p *checker) fixpoint(f func(r *ast.Rule)) {
+	for p.changed = true; p.changed; {
+		p.changed = false
+		for _, r := range p.order {
+			f(r)
+		}
+	}
+}
+
+func (p *checker) setBool(m map[string]bool, name string, v bool) {
+	if v && !m[name] {
+		m[name], p.changed = true, true
+	}
+}
+
+func (p *checker) addTerms(m map[string][]term, name string, terms []term) {
+	var changed bool
+	if m[name], changed = union(m[name], terms); changed {
+		p.changed = true
+	}
+}
+
+func (p *checker) isNullable(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if _, ok := p.rules[e.Name]; ok {
+			return p.nullable[e.Name]
+		}
+		return e.Name == "SPACE"
+	case *ast.BasicLit:
+		return isEmpty(e)
+	case *ast.Sequence:
+		for _, item := range e.Items {
+			if !p.isNullable(item) {
+				return false
+			}
+		}
+		return true
+	case *ast.Choice:
+		for _, option := range e.Options {
+			if p.isNullable(option) {
+				return true
+			}
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.ADD {
+			return p.isNullable(e.X)
+		}
+		return true
+	case *ast.BinaryExpr:
+		if e.Op == token.REM {
+			return p.isNullable(e.X)
+		}
+	}
+	return false
+}
+
+func (p *checker) isAlways(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return p.always[e.Name]
+	case *ast.BasicLit:
+		return isEmpty(e)
+	case *ast.Sequence:
+		for _, item := range e.Items {
+			if !p.isAlways(item) {
+				return false
+			}
+		}
+		return true
+	case *ast.Choice:
+		for _, option := range e.Options {
+			if p.isAlways(option) {
+				return true
+			}
+		}
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case token.QUESTION, token.MUL:
+			return true
+		case token.ADD, token.AND:
+			return p.isAlways(e.X)
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.REM {
+			return p.isAlways(e.X)
+		}
+	}
+	return false
+}
+
+func (p *checker) isProductive(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if _, ok := p.rules[e.Name]; ok {
+			return p.productive[e.Name]
+		}
+		return true
+	case *ast.Sequence:
+		for _, item := range e.Items {
+			if !p.isProductive(item) {
+				return false
+			}
+		}
+		return true
+	case *ast.Choice:
+		for _, option := range e.Options {
+			if p.isProductive(option) {
+				return true
+			}
+		}
+		return false
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case token.ADD, token.AND:
+			return p.isProductive(e.X)
+		case token.NOT:
+			return !p.isAlways(e.X)
+		}
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.REM:
+			return p.isProductive(e.X)
+		case token.INC:
+			return p.isProductive(e.X) && p.isProductive(e.Y)
+		}
+	}
+	return true
+}
+
+// firstOf returns the FIRST set of e, that is, the terms that the first token
+// matched by e may be.
+func (p *checker) firstOf(e ast.Expr) []term {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if _, ok := p.rules[e.Name]; ok {
+			return p.first[e.Name]
+		}
+		if tok, ok := tokenOf(e.Name); ok {
+			return []term{{tok: tok}}
+		}
+	case *ast.BasicLit:
+		if t, ok := literal(e); ok {
+			return []term{t}
+		}
+	case *ast.Sequence:
+		var ret []term
+		for i, item := range e.Items {
+			if la, ok := item.(*ast.UnaryExpr); ok && la.Op == token.NOT && i+1 < len(e.Items) {
+				if lits, ok := p.literals(la.X, make(map[string]bool)); ok { // eg. !keyword IDENT
+					rest := p.firstOf(&ast.Sequence{Items: e.Items[i+1:]})
+					ret, _ = union(ret, exclude(rest, lits))
+					return ret
+				}
+			}
+			ret, _ = union(ret, p.firstOf(item))
+			if !p.isNullable(item) {
+				break
+			}
+		}
+		return ret
+	case *ast.Choice:
+		var ret []term
+		for _, option := range e.Options {
+			ret, _ = union(ret, p.firstOf(option))
+		}
+		return ret
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case token.QUESTION, token.MUL, token.ADD:
+			return p.firstOf(e.X)
+		}
+	case *ast.BinaryExpr:
+		ret := p.firstOf(e.X)
+		if e.Op == token.REM && p.isNullable(e.X) {
+			ret, _ = union(append([]term(nil), ret...), p.firstOf(e.Y))
+		}
+		return ret
+	}
+	return nil
+}
+
+// literals returns the literals that e matches if e matches a single literal
+// token, such as keyword of `keyword = "if" | "else"`.
+func (p *checker) literals(e ast.Expr, visited map[string]bool) (lits []term, ok bool) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		if t, ok := literal(e); ok && t.lit != "" && t.tok != token.REGEXP {
+			return []term{t}, true
+		}
+	case *ast.Ident:
+		if r, ok := p.rules[e.Name]; ok && !visited[e.Name] {
+			visited[e.Name] = true
+			return p.literals(r.Expr, visited)
+		}
+	case *ast.Choice:
+		for _, option := range e.Options {
+			sub, ok := p.literals(option, visited)
+			if !ok {
+				return nil, false
+			}
+			lits = append(lits, sub...)
+		}
+		return lits, true
+	}
+	return nil, false
+}
+
+// leftCorner reports whether target may be matched at the start of e, eg.
+// choice expr "-" INT | INT at the start of expr "-" INT if it's the rule expr.
+func (p *checker) leftCorner(e, target ast.Expr, visited map[string]bool) bool {
+	if e == target {
+		return true
+	}
+	switch e := e.(type) {
+	case *ast.Ident:
+		if r, ok := p.rules[e.Name]; ok && !visited[e.Name] {
+			visited[e.Name] = true
+			return p.leftCorner(r.Expr, target, visited)
+		}
+	case *ast.Sequence:
+		for _, item := range e.Items {
+			if p.leftCorner(item, target, visited) {
+				return true
+			}
+			if !p.isNullable(item) {
+				break
+			}
+		}
+	case *ast.Choice:
+		for _, option := range e.Options {
+			if p.leftCorner(option, target, visited) {
+				return true
+			}
+		}
+	case *ast.UnaryExpr:
+		return p.leftCorner(e.X, target, visited)
+	case *ast.BinaryExpr:
+		return p.leftCorner(e.X, target, visited) ||
+			e.Op == token.REM && p.isNullable(e.X) && p.leftCorner(e.Y, target, visited)
+	}
+	return false
+}
+
+// firstFollow returns the terms that the first token after e may be, where
+// follow is the FOLLOW set of the whole.
+func (p *checker) firstFollow(e ast.Expr, follow []term) []term {
+	ret := append([]term(nil), p.firstOf(e)...)
+	if p.isNullable(e) {
+		ret, _ = union(ret, follow)
+	}
+	return ret
+}
+
+// walkFollow adds follow to the FOLLOW sets of rules referred by e.
+func (p *checker) walkFollow(e ast.Expr, follow []term) {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if _, ok := p.rules[e.Name]; ok {
+			p.addTerms(p.follow, e.Name, follow)
+		}
+	case *ast.Sequence:
+		for i := len(e.Items) - 1; i >= 0; i-- {
+			item := e.Items[i]
+			p.walkFollow(item, follow)
+			follow = p.firstFollow(item, follow)
+		}
+	case *ast.Choice:
+		for _, option := range e.Options {
+			p.walkFollow(option, follow)
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.MUL || e.Op == token.ADD {
+			follow, _ = union(append([]term(nil), p.firstOf(e.X)...), follow)
+		}
+		p.walkFollow(e.X, follow)
+	case *ast.BinaryExpr:
+		if e.Op == token.REM { // X % Y is X *(Y X)
+			rest := &ast.UnaryExpr{Op: token.MUL, X: &ast.Sequence{Items: []ast.Expr{e.Y, e.X}}}
+			p.walkFollow(&ast.Sequence{Items: []ast.Expr{e.X, rest}}, follow)
+		} else {
+			p.walkFollow(e.X, p.firstOf(e.Y))
+			p.walkFollow(e.Y, follow)
+		}
+	}
+}
+
+// refs collects the rules referred by e, except the rule being checked.
+func (p *checker) refs(e ast.Expr, refs map[string]bool) {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if e.Name != p.rule {
+			refs[e.Name] = true
+		}
+	case *ast.Sequence:
+		for _, item := range e.Items {
+			p.refs(item, refs)
+		}
+	case *ast.Choice:
+		for _, option := range e.Options {
+			p.refs(option, refs)
+		}
+	case *ast.UnaryExpr:
+		p.refs(e.X, refs)
+	case *ast.BinaryExpr:
+		p.refs(e.X, refs)
+		p.refs(e.Y, refs)
+	}
+}
+
+func (p *checker) reach(e ast.Expr, reached map[string]bool) {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if r, ok := p.rules[e.Name]; ok && !reached[e.Name] {
+			reached[e.Name] = true
+			p.reach(r.Expr, reached)
+		}
+	case *ast.Sequence:
+		for _, item := range e.Items {
+			p.reach(item, reached)
+		}
+	case *ast.Choice:
+		for _, option := range e.Options {
+			p.reach(option, reached)
+		}
+	case *ast.UnaryExpr:
+		p.reach(e.X, reached)
+	case *ast.BinaryExpr:
+		p.reach(e.X, reached)
+		p.reach(e.Y, reached)
+	}
+}
+
+// checkExpr reports problems of e, where follow is the FOLLOW set of e.
+func (p *checker) checkExpr(e ast.Expr, follow []term) {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if _, ok := p.rules[e.Name]; !ok && e.Name != "SPACE" {
+			if _, ok := tokenOf(e.Name); !ok {
+				p.report(e.Pos(), Error, Undefined, "`%s` is undefined", e.Name)
+			}
+		}
+	case *ast.Sequence:
+		follows := make([][]term, len(e.Items))
+		for i := len(e.Items) - 1; i >= 0; i-- {
+			follows[i] = follow
+			follow = p.firstFollow(e.Items[i], follow)
+		}
+		for i, item := range e.Items {
+			p.checkExpr(item, follows[i])
+		}
+	case *ast.Choice:
+		p.checkChoice(e, follow)
+		for _, option := range e.Options {
+			p.checkExpr(option, follow)
+		}
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case token.MUL, token.ADD:
+			if p.isNullable(e.X) {
+				p.report(e.Pos(), Error, Loop, "%v may match empty, so %v loops forever", exprString(e.X), exprString(e))
+			}
+			p.checkFollow(e, e.X, follow)
+			follow, _ = union(append([]term(nil), p.firstOf(e.X)...), follow)
+		case token.QUESTION:
+			p.checkFollow(e, e.X, follow)
+		}
+		p.checkExpr(e.X, follow)
+	case *ast.BinaryExpr:
+		if e.Op == token.REM {
+			if p.isNullable(e.X) && p.isNullable(e.Y) {
+				p.report(e.Pos(), Error, Loop, "both %v and %v may match empty, so %v loops forever",
+					exprString(e.X), exprString(e.Y), exprString(e))
+			}
+			p.checkFollow(e, e.Y, follow)
+			p.checkExpr(e.X, p.firstFollow(e.Y, follow))
+			p.checkExpr(e.Y, p.firstOf(e.X))
+		} else {
+			p.checkExpr(e.X, p.firstOf(e.Y))
+			p.checkExpr(e.Y, follow)
+		}
+	}
+}
+
+// checkFollow reports a FIRST/FOLLOW conflict of e, which matches x greedily
+// as long as it can, if x may start with a token that follows e.
+func (p *checker) checkFollow(e, x ast.Expr, follow []term) {
+	first := p.firstOf(x)
+	var both []term
+	for _, t := range first {
+		if conflicts([]term{t}, follow) {
+			both = append(both, t)
+		}
+	}
+	if len(both) > 0 {
+		p.report(e.Pos(), Warning, Conflict, "FIRST/FOLLOW conflict in %v: %v may start or follow it",
+			exprString(e), termsString(both))
+	}
+}
+
+// checkChoice reports conflicts between the options of c, where follow is the
+// FOLLOW set of c. Like matcher.Choices.CheckConflicts, options that start with
+// c itself (the growing options of a left-recursive rule, such as expr "-" INT
+// in expr = expr "-" INT | INT) always conflict with the others, so they
+// aren't reported.
+func (p *checker) checkChoice(c *ast.Choice, follow []term) {
+	n := len(c.Options)
+	firsts := make([][]term, n)
+	growing := make([]bool, n)
+	for i, option := range c.Options {
+		firsts[i] = p.firstOf(option)
+		growing[i] = p.leftCorner(option, c, make(map[string]bool))
+	}
+	for i, option := range c.Options {
+		if p.isAlways(option) && i+1 < n {
+			p.report(c.Options[i+1].Pos(), Warning, Unreachable,
+				"alternative %v is unreachable, since %v always succeeds", exprString(c.Options[i+1]), exprString(option))
+			break
+		}
+		if growing[i] {
+			continue
+		}
+		for at := i + 1; at < n; at++ {
+			if !growing[at] && conflicts(firsts[i], firsts[at]) {
+				p.report(option.Pos(), Warning, Conflict, "conflict between %v and %v",
+					termsString(firsts[i]), termsString(firsts[at]))
+				break
+			}
+		}
+	}
+	for i, option := range c.Options {
+		if p.isNullable(option) {
+			for j, other := range c.Options {
+				if j != i && !p.isNullable(other) && conflicts(firsts[j], follow) {
+					p.report(other.Pos(), Warning, Conflict,
+						"FIRST/FOLLOW conflict: %v may start with %v, but %v may match empty and be followed by it",
+						exprString(other), termsString(firsts[j]), exprString(option))
+				}
+			}
+			break
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+func exprString(e ast.Expr) string {
+	var b strings.Builder
+	writeExpr(&b, e, 0)
+	return "`" + b.String() + "`"
+}
+
+const (
+	precChoice = iota
+	precSeq
+	precList
+	precUnary
+)
+
+func writeExpr(b *strings.Builder, e ast.Expr, prec int) {
+	switch e := e.(type) {
+	case *ast.Ident:
+		b.WriteString(e.Name)
+	case *ast.BasicLit:
+		b.WriteString(e.Value)
+	case *ast.Choice:
+		writeParen(b, prec > precChoice, func() {
+			for i, option := range e.Options {
+				if i > 0 {
+					b.WriteString(" | ")
+				}
+				writeExpr(b, option, precSeq)
+			}
+		})
+	case *ast.Sequence:
+		writeParen(b, prec > precSeq, func() {
+			for i, item := range e.Items {
+				if i > 0 {
+					b.WriteByte(' ')
+				}
+				writeExpr(b, item, precList)
+			}
+		})
+	case *ast.UnaryExpr:
+		b.WriteString(e.Op.String())
+		writeExpr(b, e.X, precUnary)
+	case *ast.BinaryExpr:
+		writeParen(b, prec > precList, func() {
+			writeExpr(b, e.X, precUnary)
+			b.WriteString(" " + e.Op.String() + " ")
+			writeExpr(b, e.Y, precUnary)
+		})
+	}
+}
+
+func writeParen(b *strings.Builder, paren bool, f func()) {
+	if paren {
+		b.WriteByte('(')
+		f()
+		b.WriteByte(')')
+	} else {
+		f()
+	}
+}
+
+// -----------------------------------------------------------------------------
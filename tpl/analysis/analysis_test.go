@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/tpl/analysis"
+)
+
+func check(t *testing.T, filename, src string, expected ...string) []*analysis.Diagnostic {
+	t.Helper()
+	diags, err := analysis.CheckFile(nil, filename, src)
+	if err != nil {
+		t.Fatal("CheckFile:", err)
+	}
+	var b strings.Builder
+	for _, d := range diags {
+		b.WriteString(d.String())
+		b.WriteByte('\n')
+	}
+	if ret, exp := b.String(), strings.Join(expected, "\n")+"\n"; ret != exp && (len(expected) > 0 || ret != "") {
+		t.Fatalf("CheckFile:\n%s\nexpected:\n%s", ret, exp)
+	}
+	return diags
+}
+
+func TestRules(t *testing.T) {
+	check(t, "foo.tpl", `
+doc = stmt % ";"
+
+stmt = "print" expr | IDENT "=" expr
+
+expr = INT | foo
+
+unused = INT
+
+self = "(" self ")"
+
+bad = bad INT
+`,
+		"foo.tpl:6:14: error: `foo` is undefined",
+		"foo.tpl:8:1: warning: rule `unused` is unused",
+		"foo.tpl:10:1: warning: rule `self` is unused",
+		"foo.tpl:10:1: error: rule `self` can never succeed",
+		"foo.tpl:12:1: warning: rule `bad` is unused",
+		"foo.tpl:12:1: error: rule `bad` can never succeed",
+	)
+	check(t, "bar.tpl", `
+doc = a
+
+a = INT | "(" a ")"
+
+b = c
+
+c = "(" b ")" | INT
+`,
+		"bar.tpl:6:1: warning: rule `b` is unreachable from `doc`",
+		"bar.tpl:8:1: warning: rule `c` is unreachable from `doc`",
+	)
+	check(t, "tokens.tpl", `
+doc = NAME | NUMBER
+
+NAME = /[a-z]+/
+
+NUMBER = /[0-9]+/
+`)
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(dir+"/expr.tpl", []byte(`
+expr = operand % "+"
+
+operand = INT | "(" expr ")"
+
+unused = INT
+`), 0644)
+	check(t, dir+"/foo.tpl", `
+import "expr.tpl"
+import e "expr.tpl"
+
+doc = expr ";" e.expr ";" stmt
+
+operand |= IDENT | INT
+
+e.operand = FLOAT | e.operand "." IDENT
+
+stmt = IDENT
+
+unused = INT
+`,
+		dir+"/expr.tpl:4:11: warning: conflict between [INT (] and [IDENT INT]",
+		dir+"/foo.tpl:13:1: warning: rule `unused` is unused",
+	)
+	check(t, dir+"/bar.tpl", `
+import "expr.tpl"
+import "none.tpl"
+
+doc = expr | foo
+`,
+		dir+"/bar.tpl:3:8: error: could not import none.tpl (open "+dir+"/none.tpl: no such file or directory)",
+		dir+"/bar.tpl:5:14: error: `foo` is undefined",
+	)
+}
+
+func TestConflicts(t *testing.T) {
+	check(t, "foo.tpl", `
+doc = stmt | IDENT "=" INT | *INT | "x"
+
+stmt = IDENT | INT
+`,
+		"foo.tpl:2:7: warning: conflict between [IDENT INT] and [IDENT]",
+		"foo.tpl:2:14: warning: conflict between [IDENT] and [x]",
+		"foo.tpl:2:37: warning: alternative `\"x\"` is unreachable, since `*INT` always succeeds",
+	)
+	check(t, "bar.tpl", `
+doc = *(INT % ",") "," ?IDENT IDENT | !"x"
+`,
+		"bar.tpl:2:9: warning: FIRST/FOLLOW conflict in `INT % \",\"`: [,] may start or follow it",
+		"bar.tpl:2:24: warning: FIRST/FOLLOW conflict in `?IDENT`: [IDENT] may start or follow it",
+	)
+	check(t, "baz.tpl", `
+doc = ("if" | ?IDENT) "if"
+`,
+		"baz.tpl:2:8: warning: FIRST/FOLLOW conflict: `\"if\"` may start with [if], but `?IDENT` may match empty and be followed by it",
+		"baz.tpl:2:15: warning: FIRST/FOLLOW conflict in `?IDENT`: [IDENT] may start or follow it",
+	)
+}
+
+func TestConflictsLeftRec(t *testing.T) {
+	check(t, "foo.tpl", `
+doc = expr ";" sum
+
+expr = expr "-" INT | INT
+
+sum = sum "+" term | term
+
+term = INT | "(" sum ")"
+`)
+}
+
+func TestConflictsLookahead(t *testing.T) {
+	check(t, "foo.tpl", `
+stmt = ident "=" INT | "if" IDENT
+
+ident = !keyword IDENT
+
+keyword = "if" | "else"
+`)
+	check(t, "bar.tpl", `
+stmt = ident "=" INT | "else" IDENT | "if" IDENT
+
+ident = !keyword IDENT
+
+keyword = "if"
+`,
+		"bar.tpl:2:8: warning: conflict between [IDENT-if] and [else]",
+	)
+}
+
+func TestLoop(t *testing.T) {
+	check(t, "foo.tpl", `
+doc = *?INT +item (?"," % ?";") !INT
+
+item = *"x"
+`,
+		"foo.tpl:2:7: error: `?INT` may match empty, so `*?INT` loops forever",
+		"foo.tpl:2:8: warning: FIRST/FOLLOW conflict in `?INT`: [INT] may start or follow it",
+		"foo.tpl:2:13: error: `item` may match empty, so `+item` loops forever",
+		"foo.tpl:2:20: error: both `?\",\"` and `?\";\"` may match empty, so `?\",\" % ?\";\"` loops forever",
+		"foo.tpl:4:8: warning: FIRST/FOLLOW conflict in `*\"x\"`: [x] may start or follow it",
+	)
+}
+
+func TestGop(t *testing.T) {
+	diags := check(t, "foo.gop", "echo 1\n\ncl := tpl`\nexpr = INT | foo\n`\n\ncl2 := tpl`doc = IDENT`\n",
+		"foo.gop:4:14: error: `foo` is undefined",
+	)
+	b, err := json.Marshal(diags)
+	if err != nil {
+		t.Fatal("json.Marshal:", err)
+	}
+	if string(b) != `[{"file":"foo.gop","line":4,"column":14,"severity":"error","kind":"undefined","rule":"expr","message":"`+"`foo`"+` is undefined"}]` {
+		t.Fatal("json.Marshal:", string(b))
+	}
+	if _, err = analysis.CheckFile(nil, "bar.gop", "cl := tpl`expr = `"); err == nil {
+		t.Fatal("CheckFile: no error")
+	}
+}
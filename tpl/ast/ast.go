@@ -28,7 +28,7 @@ type Node interface {
 	End() token.Pos
 }
 
-// Decl: Rule
+// Decl: ImportDecl, Rule
 type Decl interface {
 	Node
 	declNode()
@@ -64,17 +64,45 @@ func (p *File) End() token.Pos {
 
 // -----------------------------------------------------------------------------
 
+// ImportDecl:
+//
+//	'import' STRING
+//	'import' IDENT STRING
+type ImportDecl struct {
+	ImportPos token.Pos // position of "import"
+	Name      *Ident    // prefix of the imported rules, or nil
+	Path      *BasicLit // import path
+}
+
+func (p *ImportDecl) Pos() token.Pos { return p.ImportPos }
+func (p *ImportDecl) End() token.Pos { return p.Path.End() }
+func (p *ImportDecl) declNode()      {}
+
+// -----------------------------------------------------------------------------
+
 // Rule:
 //
 //	IDENT '=' Expr
 //	IDENT '=' Expr => { ... }
+//	IDENT '|=' Expr
+//	IDENT '|=' Expr => { ... }
+//
+// A rule defined with '|=' extends a rule with a new alternative. IDENT can be
+// a qualified name, such as json.value, which refers to a rule of a grammar
+// imported with the prefix json.
 type Rule struct {
 	Name    *Ident
-	TokPos  token.Pos // position of '='
+	TokPos  token.Pos   // position of Tok
+	Tok     token.Token // token.ASSIGN (=) or token.OR_ASSIGN (|=)
 	Expr    Expr
 	RetProc Node // => { ... } (see gop/ast.LambdaExpr2) or nil
 }
 
+// IsExtension reports whether the rule extends another rule (IDENT '|=' Expr).
+func (p *Rule) IsExtension() bool {
+	return p.Tok == token.OR_ASSIGN
+}
+
 // IsList reports whether the rule is a list rule.
 func (p *Rule) IsList() bool {
 	switch e := p.Expr.(type) {
@@ -106,7 +134,7 @@ func (p *Rule) declNode() {}
 
 // -----------------------------------------------------------------------------
 
-// Ident: IDENT
+// Ident: IDENT or IDENT '.' IDENT
 type Ident struct {
 	NamePos token.Pos // identifier position
 	Name    string    // identifier name
@@ -118,10 +146,10 @@ func (p *Ident) exprNode()      {}
 
 // -----------------------------------------------------------------------------
 
-// BasicLit: STRING | CHAR
+// BasicLit: STRING | CHAR | REGEXP
 type BasicLit struct {
 	ValuePos token.Pos   // literal position
-	Kind     token.Token // token.STRING or token.CHAR
+	Kind     token.Token // token.STRING, token.CHAR or token.REGEXP (/[0-9]+/)
 	Value    string
 }
 
@@ -153,10 +181,10 @@ func (p *Sequence) exprNode()      {}
 
 // -----------------------------------------------------------------------------
 
-// UnaryExpr: *R, +R or ?R
+// UnaryExpr: *R, +R, ?R, &R or !R
 type UnaryExpr struct {
 	OpPos token.Pos   // operator position
-	Op    token.Token // operator: token.MUL, token.ADD or token.QUESTION
+	Op    token.Token // operator: token.MUL, token.ADD, token.QUESTION, token.AND or token.NOT
 	X     Expr        // operand
 }
 
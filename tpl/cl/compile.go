@@ -19,10 +19,13 @@ package cl
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/goplus/gop/tpl/ast"
 	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/scanner"
 	"github.com/goplus/gop/tpl/token"
 	"github.com/qiniu/x/errors"
 )
@@ -36,18 +39,52 @@ var (
 type Result struct {
 	Doc   *matcher.Var
 	Rules map[string]*matcher.Var
+
+	// Tokens are the tokens defined by token rules, such as NUMBER = /[0-9]+/,
+	// which are scanned before the builtin tokens (see scanner.SetTokens).
+	Tokens []*scanner.TokenDef
+
+	// Scannerless reports whether the rules match characters instead of
+	// tokens (see Config.Scannerless).
+	Scannerless bool
+
+	// Stops reports whether matching of a choice stops at each option if it
+	// matches some tokens but fails (see matcher.Choices.CheckConflicts).
+	Stops map[*ast.Choice][]bool
 }
 
 type choice struct {
-	m *matcher.Choices
-	c *ast.Choice
+	m     *matcher.Choices
+	c     *ast.Choice
+	g     *grammar // grammar where c is compiled
+	stops []bool
 }
 
 type context struct {
-	rules   map[string]*matcher.Var
-	choices []choice
-	errs    errors.List
-	fset    *token.FileSet
+	g        *grammar // grammar being compiled
+	grammars map[importKey]*grammar
+	files    map[string]*ast.File     // files of imported grammars
+	tokens   map[*ast.Rule]*tokenRule // token rules, shared by all imports of a grammar
+	defs     []*scanner.TokenDef
+	exprs    map[*matcher.Var]ast.Expr // expressions of rules, to be extended
+	vars     []*matcher.Var
+	choices  []choice
+	decls    map[*matcher.Var]*ast.Rule // rules defining the expressions of vars
+	owners   map[*matcher.Var]*grammar  // grammars where the expressions of vars are compiled
+	exts     map[*ast.Choice]*grammar   // extensions R |= ..., and grammars where they're compiled
+	errs     errors.List
+	fset     *token.FileSet
+	dir      string
+
+	scannerless bool
+}
+
+// tokenRule represents a token rule, such as NUMBER = /[0-9]+/.
+type tokenRule struct {
+	pos  token.Pos
+	m    matcher.Matcher
+	rule *ast.Rule
+	idx  int // index of Result.Tokens
 }
 
 func (p *context) newErrorf(pos token.Pos, format string, args ...any) error {
@@ -71,81 +108,246 @@ func New(fset *token.FileSet, files ...*ast.File) (ret Result, err error) {
 type Config struct {
 	RetProcs   map[string]any
 	OnConflict func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int)
+
+	// Scannerless compiles the rules to match characters instead of tokens,
+	// for whitespace-sensitive formats: a literal matches its characters, and
+	// a token rule, such as NUMBER = /[0-9]+/, matches its regular expression
+	// at the current character. Token classes, such as IDENT and INT, aren't
+	// available.
+	Scannerless bool
+
+	// Dir is the directory to resolve relative paths of imported files, such
+	// as import "expr.tpl", if the importing file has no filename. By default,
+	// they're relative to the directory of the importing file.
+	Dir string
 }
 
 // NewEx compiles a set of rules from the given files.
 func NewEx(conf *Config, fset *token.FileSet, files ...*ast.File) (ret Result, err error) {
+	_, _, ret, err = compile(conf, fset, files)
+	return
+}
+
+func compile(conf *Config, fset *token.FileSet, files []*ast.File) (ctx *context, g *grammar, ret Result, err error) {
 	if conf == nil {
 		conf = &Config{}
 	}
-	retProcs := conf.RetProcs
-	rules := make(map[string]*matcher.Var)
-	ctx := &context{rules: rules, fset: fset}
+	ctx = &context{
+		grammars:    make(map[importKey]*grammar),
+		files:       make(map[string]*ast.File),
+		tokens:      make(map[*ast.Rule]*tokenRule),
+		exprs:       make(map[*matcher.Var]ast.Expr),
+		decls:       make(map[*matcher.Var]*ast.Rule),
+		owners:      make(map[*matcher.Var]*grammar),
+		exts:        make(map[*ast.Choice]*grammar),
+		fset:        fset,
+		dir:         conf.Dir,
+		scannerless: conf.Scannerless,
+	}
+	g = ctx.load(files, conf.RetProcs)
+	if g.doc == nil {
+		if err = ctx.errs.ToError(); err == nil {
+			err = ErrNoDocFound
+		}
+		return
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			switch e := e.(type) {
+			case matcher.UndefinedRuleError:
+				ctx.addError(e.Pos, e.Error())
+			default:
+				panic(e)
+			}
+		}
+		err = ctx.errs.ToError()
+	}()
+	for _, v := range ctx.vars {
+		v.First(nil) // to find left-recursive rules
+	}
+	onConflict := conf.OnConflict
+	if onConflict == nil {
+		onConflict = onConflictDefault
+	}
+	stops := make(map[*ast.Choice][]bool, len(ctx.choices))
+	for i, item := range ctx.choices {
+		stops[item.c] = item.m.CheckConflicts(func(firsts [][]any, i, at int) {
+			onConflict(fset, item.c, firsts, i, at)
+		})
+		ctx.choices[i].stops = stops[item.c]
+	}
+	matcher.InitFollow(g.doc) // to recover from errors
+	ret = Result{Doc: g.doc, Rules: g.rules, Scannerless: conf.Scannerless, Stops: stops}
+	if !conf.Scannerless {
+		ret.Tokens = ctx.defs
+	}
+	return
+}
+
+// load compiles the grammar made up of files. Its document rule is the first
+// rule defined by them, or the document rule of the first grammar imported
+// without a prefix if they only override or extend imported rules.
+func (p *context) load(files []*ast.File, retProcs map[string]any) *grammar {
+	g := newGrammar()
+	old := p.g
+	p.g = g
+	defer func() { p.g = old }()
+
+	var impDoc *matcher.Var
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if decl, ok := decl.(*ast.ImportDecl); ok {
+				if sub := p.importGrammar(decl); sub != nil && decl.Name == nil && impDoc == nil {
+					impDoc = sub.doc
+				}
+			}
+		}
+	}
+
+	// declare rules
+	vars := make(map[*ast.Rule]*matcher.Var)
+	overrides := make(map[*ast.Rule]bool)
+	var extensions []*ast.Rule
 	for _, f := range files {
 		for _, decl := range f.Decls {
 			switch decl := decl.(type) {
 			case *ast.Rule:
 				ident := decl.Name
 				name := ident.Name
-				if old, ok := rules[name]; ok {
-					oldPos := fset.Position(old.Pos)
-					ctx.addErrorf(ident.Pos(),
-						"duplicate rule `%s`, previous declaration at %v", name, oldPos)
+				if decl.IsExtension() {
+					extensions = append(extensions, decl)
+					continue
+				}
+				_, isToken := isTokenRule(decl)
+				if v, ok := g.rules[name]; ok && g.imported[name] && !isToken {
+					delete(g.imported, name) // can be overridden only once
+					vars[decl], overrides[decl] = v, true
+					continue
+				}
+				oldPos := token.NoPos
+				if old, ok := g.rules[name]; ok {
+					oldPos = old.Pos
+				} else if old, ok := g.tokens[name]; ok {
+					oldPos = old.pos
+				}
+				if oldPos != token.NoPos {
+					p.addErrorf(ident.Pos(),
+						"duplicate rule `%s`, previous declaration at %v", name, p.fset.Position(oldPos))
+					continue
+				}
+				if strings.Contains(name, ".") {
+					p.addErrorf(ident.Pos(), "`%s` is undefined", name)
+					continue
+				}
+				if lit, ok := isTokenRule(decl); ok {
+					if t, ok := p.tokens[decl]; ok { // imported again with another prefix
+						g.tokens[name] = t
+					} else if def, ok := compileTokenRule(decl, lit, len(p.defs), p); ok {
+						p.defs = append(p.defs, def)
+						p.tokens[decl] = g.tokens[name]
+					}
 					continue
 				}
 				v := matcher.NewVar(ident.Pos(), name)
-				rules[name] = v
+				g.rules[name] = v
+				vars[decl] = v
+			case *ast.ImportDecl:
 			default:
-				ctx.addError(decl.Pos(), "unknown declaration")
+				p.addError(decl.Pos(), "unknown declaration")
 			}
 		}
 	}
-	var doc *matcher.Var
+
+	// compile rules
 	for _, f := range files {
 		for _, decl := range f.Decls {
-			switch decl := decl.(type) {
-			case *ast.Rule:
-				ident := decl.Name
-				name := ident.Name
-				v := rules[name]
-				if r, ok := compileExpr(decl.Expr, ctx); ok {
-					v.RetProc = retProcs[name]
-					if e := v.Assign(r); e != nil {
-						ctx.addError(ident.Pos(), e.Error())
+			if decl, ok := decl.(*ast.Rule); ok {
+				v, ok := vars[decl]
+				if !ok { // extension, token rule or duplicate
+					continue
+				}
+				name := decl.Name.Name
+				if r, ok := compileExpr(decl.Expr, p); ok {
+					v.SetRetProc(retProcs[name])
+					if overrides[decl] {
+						v.Elem = r
+					} else if e := v.Assign(r); e != nil {
+						p.addError(decl.Name.Pos(), e.Error())
+						continue
+					} else {
+						p.vars = append(p.vars, v)
 					}
-					if doc == nil {
-						doc = v
+					p.exprs[v], p.decls[v], p.owners[v] = decl.Expr, decl, g
+					if g.doc == nil && !overrides[decl] {
+						g.doc = v
 					}
 				}
 			}
 		}
 	}
-	if doc == nil {
-		err = ErrNoDocFound
-		return
-	}
-	defer func() {
-		if e := recover(); e != nil {
-			switch e := e.(type) {
-			case matcher.RecursiveError:
-				ctx.addError(e.Pos, e.Error())
-			default:
-				panic(e)
+
+	// extend rules
+	for _, decl := range extensions {
+		name := decl.Name.Name
+		v, ok := g.rules[name]
+		if !ok {
+			if _, ok := g.tokens[name]; ok {
+				p.addErrorf(decl.Name.Pos(), "cannot extend token rule `%s`", name)
+			} else {
+				p.addErrorf(decl.Name.Pos(), "`%s` is undefined", name)
 			}
+			continue
+		}
+		r, ok := compileExpr(decl.Expr, p)
+		if !ok || v.Elem == nil {
+			continue
+		}
+		c := &ast.Choice{Options: []ast.Expr{p.exprs[v], decl.Expr}}
+		m := matcher.Choice(v.Elem, r)
+		p.choices = append(p.choices, choice{m: m, c: c, g: g})
+		v.Elem, p.exprs[v], p.exts[c] = m, c, g
+		if decl.RetProc != nil {
+			p.decls[v] = decl
+		}
+		if retProc, ok := retProcs[name]; ok {
+			v.SetRetProc(retProc)
 		}
-		err = ctx.errs.ToError()
-	}()
-	onConflict := conf.OnConflict
-	if onConflict == nil {
-		onConflict = onConflictDefault
 	}
-	for _, item := range ctx.choices {
-		item.m.CheckConflicts(func(firsts [][]any, i, at int) {
-			onConflict(fset, item.c, firsts, i, at)
-		})
+
+	// RetProcs of imported rules
+	for name, retProc := range retProcs {
+		if v, ok := g.rules[name]; ok && g.imported[name] {
+			v.SetRetProc(retProc)
+		}
 	}
-	ret = Result{doc, rules}
-	return
+	if g.doc == nil {
+		g.doc = impDoc
+	}
+	return g
+}
+
+// isTokenRule reports whether r is a token rule, such as NUMBER = /[0-9]+/.
+func isTokenRule(r *ast.Rule) (*ast.BasicLit, bool) {
+	lit, ok := r.Expr.(*ast.BasicLit)
+	return lit, ok && lit.Kind == token.REGEXP
+}
+
+// compileTokenRule compiles the idx-th token rule r, whose expression is lit.
+func compileTokenRule(r *ast.Rule, lit *ast.BasicLit, idx int, ctx *context) (*scanner.TokenDef, bool) {
+	name := r.Name.Name
+	pattern := lit.Value[1 : len(lit.Value)-1]
+	if _, err := regexp.Compile(pattern); err != nil {
+		ctx.addErrorf(lit.Pos(), "invalid regexp %s: %v", lit.Value, err)
+		return nil, false
+	}
+	re := regexp.MustCompile("^(?:" + pattern + ")")
+	def := &scanner.TokenDef{Tok: token.USER_BEG + token.Token(idx), Name: name, Regexp: re}
+	m := matcher.NamedToken(def.Tok, name)
+	if ctx.scannerless {
+		m = matcher.Regexp(def.Tok, name, re)
+	}
+	ctx.g.tokens[name] = &tokenRule{lit.Pos(), m, r, idx}
+	return def, true
 }
 
 func onConflictDefault(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {
@@ -158,34 +360,20 @@ func LogConflict(pos token.Position, firsts [][]any, i, at int) {
 	fmt.Fprintf(os.Stderr, "%v: [WARN] conflict between %v and %v\n", pos, firsts[i], firsts[at])
 }
 
-var (
-	idents = map[string]token.Token{
-		"EOF":     token.EOF,
-		"COMMENT": token.COMMENT,
-		"IDENT":   token.IDENT,
-		"INT":     token.INT,
-		"FLOAT":   token.FLOAT,
-		"IMAG":    token.IMAG,
-		"CHAR":    token.CHAR,
-		"STRING":  token.STRING,
-		"RAT":     token.RAT,
-		"UNIT":    token.UNIT,
-		"LPAREN":  token.LPAREN,
-		"RPAREN":  token.RPAREN,
-		"LBRACK":  token.LBRACK,
-		"RBRACK":  token.RBRACK,
-		"LBRACE":  token.LBRACE,
-		"RBRACE":  token.RBRACE,
-	}
-)
-
 func compileExpr(expr ast.Expr, ctx *context) (matcher.Matcher, bool) {
 	switch expr := expr.(type) {
 	case *ast.Ident:
 		name := expr.Name
-		if v, ok := ctx.rules[name]; ok {
+		if v, ok := ctx.g.rules[name]; ok {
+			ctx.g.refs[expr] = v
 			return v, true
-		} else if tok, ok := idents[name]; ok {
+		} else if t, ok := ctx.g.tokens[name]; ok {
+			ctx.g.refs[expr] = t
+			return t.m, true
+		} else if ctx.scannerless {
+			ctx.addErrorf(expr.Pos(), "`%s` is undefined, token classes aren't available in the scannerless mode", name)
+			return matcher.True(), true
+		} else if tok, ok := token.Lookup(name); ok {
 			return matcher.Token(tok), true
 		}
 		var quoteCh byte
@@ -213,6 +401,9 @@ func compileExpr(expr ast.Expr, ctx *context) (matcher.Matcher, bool) {
 				ctx.addError(expr.Pos(), "invalid literal "+lit)
 				break
 			}
+			if ctx.scannerless {
+				return matcher.Text(textToken(string(v)), string(v)), true
+			}
 			return tokenExpr(token.Token(v), expr, ctx)
 		case token.STRING:
 			v, e := strconv.Unquote(lit)
@@ -223,13 +414,18 @@ func compileExpr(expr ast.Expr, ctx *context) (matcher.Matcher, bool) {
 			if v == "" {
 				return matcher.True(), true
 			}
+			if ctx.scannerless {
+				return matcher.Text(textToken(v), v), true
+			}
 			if c := v[0]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' {
 				return matcher.Literal(token.IDENT, v), true
 			}
-			if t, ok := checkToken(v); ok {
+			if t, ok := token.Operator(v); ok {
 				return tokenExpr(t, expr, ctx)
 			}
-			fallthrough
+			ctx.addError(expr.Pos(), "invalid literal "+lit)
+		case token.REGEXP:
+			ctx.addErrorf(expr.Pos(), "regexp %s must be the whole expression of a token rule, such as NUMBER = /[0-9]+/", lit)
 		default:
 			ctx.addError(expr.Pos(), "invalid literal "+lit)
 		}
@@ -253,7 +449,7 @@ func compileExpr(expr ast.Expr, ctx *context) (matcher.Matcher, bool) {
 			}
 		}
 		ret := matcher.Choice(options...)
-		ctx.choices = append(ctx.choices, choice{ret, expr})
+		ctx.choices = append(ctx.choices, choice{m: ret, c: expr, g: ctx.g})
 		return ret, true
 	case *ast.UnaryExpr:
 		if x, ok := compileExpr(expr.X, ctx); ok {
@@ -264,6 +460,10 @@ func compileExpr(expr ast.Expr, ctx *context) (matcher.Matcher, bool) {
 				return matcher.Repeat0(x), true
 			case token.ADD:
 				return matcher.Repeat1(x), true
+			case token.AND:
+				return matcher.Lookahead(x), true
+			case token.NOT:
+				return matcher.NotLookahead(x), true
 			default:
 				ctx.addErrorf(expr.Pos(), "invalid token %v", expr.Op)
 			}
@@ -295,16 +495,15 @@ func tokenExpr(tok token.Token, expr *ast.BasicLit, ctx *context) (matcher.Match
 	return nil, false
 }
 
-func checkToken(v string) (ret token.Token, ok bool) {
-	if len(v) == 1 {
-		return token.Token(v[0]), true
+// textToken returns the kind of the token matched by literal v in the
+// scannerless mode, which is the kind v has in the token mode, or CHAR if v
+// isn't an identifier or an operator.
+func textToken(v string) token.Token {
+	if c := v[0]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' {
+		return token.IDENT
 	}
-	token.ForEach(0, func(tok token.Token, lit string) int {
-		if lit == v {
-			ret, ok = tok, true
-			return token.Break
-		}
-		return 0
-	})
-	return
+	if t, ok := token.Operator(v); ok && t.Len() > 0 {
+		return t
+	}
+	return token.CHAR
 }
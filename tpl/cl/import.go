@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cl
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/gop/parser/iox"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/parser"
+)
+
+// -----------------------------------------------------------------------------
+
+type registered struct {
+	src      []byte
+	retProcs map[string]any
+}
+
+var registry = make(map[string]*registered)
+
+// Register registers a grammar, so that it can be imported by path, eg.
+//
+//	import json "github.com/goplus/tpl/json"
+//
+// retProcs are the RetProcs of its rules. It's usually called in the init
+// function of a Go package, whose import path is used as path, to build a
+// library of grammars. Register panics if path is registered twice.
+func Register(path string, src any, retProcs map[string]any) {
+	if _, ok := registry[path]; ok {
+		panic("tpl/cl.Register: grammar registered twice: " + path)
+	}
+	b, err := iox.ReadSourceLocal(path, src)
+	if err != nil {
+		panic(err)
+	}
+	registry[path] = &registered{b, retProcs}
+}
+
+// grammar represents a compiled grammar: the rules of its files, including
+// the imported ones.
+type grammar struct {
+	rules    map[string]*matcher.Var
+	tokens   map[string]*tokenRule
+	imported map[string]bool    // imported rules, which can be overridden
+	refs     map[*ast.Ident]any // rules (*matcher.Var or *tokenRule) referred by identifiers
+	subs     []subGrammar       // imported grammars
+	doc      *matcher.Var
+}
+
+// subGrammar represents a grammar imported with a prefix, eg. "b." of
+// import b "b.tpl", or "" if it's imported without a prefix.
+type subGrammar struct {
+	prefix string
+	g      *grammar
+}
+
+func newGrammar() *grammar {
+	return &grammar{
+		rules:    make(map[string]*matcher.Var),
+		tokens:   make(map[string]*tokenRule),
+		imported: make(map[string]bool),
+		refs:     make(map[*ast.Ident]any),
+	}
+}
+
+// importKey identifies an imported grammar. A grammar imported with different
+// prefixes is loaded once for each, so that overriding or extending a rule of
+// one prefix doesn't change the others.
+type importKey struct {
+	path   string
+	prefix string
+}
+
+// importGrammar imports the grammar of decl into the current grammar, and
+// returns it. It returns nil if the import fails.
+func (p *context) importGrammar(decl *ast.ImportDecl) *grammar {
+	path, err := strconv.Unquote(decl.Path.Value)
+	if err != nil || path == "" {
+		p.addErrorf(decl.Path.Pos(), "invalid import path: %s", decl.Path.Value)
+		return nil
+	}
+	var src any // nil to read the file
+	var retProcs map[string]any
+	key := path
+	if r, ok := registry[path]; ok {
+		src, retProcs = r.src, r.retProcs
+	} else if !filepath.IsAbs(path) {
+		dir := p.dir
+		if filename := p.fset.Position(decl.Pos()).Filename; filename != "" {
+			dir = filepath.Dir(filename)
+		}
+		key = filepath.Join(dir, path)
+	}
+	prefix := ""
+	if decl.Name != nil {
+		prefix = decl.Name.Name + "."
+	}
+	ik := importKey{key, prefix}
+	sub, ok := p.grammars[ik]
+	if !ok {
+		f, ok := p.files[key]
+		if !ok {
+			if f, err = parser.ParseFile(p.fset, key, src, nil); err != nil {
+				p.addErrorf(decl.Path.Pos(), "could not import %s (%v)", path, err)
+				return nil
+			}
+			p.files[key] = f
+		}
+		p.grammars[ik] = nil // loading
+		sub = p.load([]*ast.File{f}, retProcs)
+		p.grammars[ik] = sub
+	} else if sub == nil {
+		p.addErrorf(decl.Path.Pos(), "import cycle not allowed: %s", path)
+		return nil
+	}
+	g := p.g
+	g.subs = append(g.subs, subGrammar{prefix, sub})
+	names := make([]string, 0, len(sub.rules)+len(sub.tokens))
+	for name := range sub.rules {
+		names = append(names, name)
+	}
+	for name := range sub.tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.Contains(name, ".") { // rules imported with a prefix aren't exported
+			continue
+		}
+		v, t := sub.rules[name], sub.tokens[name]
+		name = prefix + name
+		oldV, ok1 := g.rules[name]
+		oldT, ok2 := g.tokens[name]
+		if ok1 || ok2 {
+			if ok1 && oldV != v || ok2 && oldT != t { // not the same grammar imported twice
+				p.addErrorf(decl.Path.Pos(), "rule `%s` of %s is already imported", name, path)
+			}
+			continue
+		}
+		if v != nil {
+			g.rules[name] = v
+		} else {
+			g.tokens[name] = t
+		}
+		g.imported[name] = true
+	}
+	return sub
+}
+
+// -----------------------------------------------------------------------------
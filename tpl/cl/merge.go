@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cl
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// Merge compiles a grammar made up of files like NewEx, and returns it as a
+// single file of rules without imports and extensions of rules, for tools
+// which work on the rules of a grammar, such as tpl/analysis. Its first rule
+// is the document rule.
+//
+// The rules are the ones of files, the ones they refer to (including imported
+// ones) and all token rules, which are placed last in the order they're
+// scanned (see Result.Tokens). An imported rule is named as it's referred in
+// files, eg. b.expr of import b "b.tpl", or by the prefixes of the imports
+// it's found through if it isn't visible in files, eg. b.c.expr. Identifiers
+// in the expressions are renamed accordingly, and an extended rule R |= X
+// becomes R = (...) | X. The rules of ret are named the same way, and its
+// Stops are the ones of the choices in f.
+func Merge(conf *Config, fset *token.FileSet, files ...*ast.File) (f *ast.File, ret Result, err error) {
+	ctx, g, ret, err := compile(conf, fset, files)
+	if err != nil {
+		return
+	}
+	p := &merger{
+		ctx:   ctx,
+		names: make(map[any]string),
+		used:  make(map[string]bool),
+		stops: make(map[choiceKey][]bool),
+		rules: make(map[string]*matcher.Var),
+		added: make(map[*matcher.Var]bool),
+		ret:   make(map[*ast.Choice][]bool),
+	}
+	for _, item := range ctx.choices {
+		p.stops[choiceKey{item.g, item.c}] = item.stops
+	}
+	p.nameAll(g, "")
+
+	// the document rule, rules of files and the ones they refer to
+	p.add(g.doc)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if r, ok := decl.(*ast.Rule); ok {
+				if v, ok := g.rules[r.Name.Name]; ok {
+					p.add(v)
+				}
+			}
+		}
+	}
+	f = &ast.File{}
+	for i := 0; i < len(p.queue); i++ {
+		v := p.queue[i]
+		decl, pos := ctx.decls[v], v.Pos
+		if !decl.IsExtension() { // v or its override
+			pos = decl.Name.NamePos
+		}
+		r := &ast.Rule{
+			Name:    &ast.Ident{NamePos: pos, Name: p.names[v]},
+			TokPos:  decl.TokPos,
+			Tok:     token.ASSIGN,
+			Expr:    p.expr(ctx.exprs[v], ctx.owners[v]),
+			RetProc: decl.RetProc,
+		}
+		f.Decls = append(f.Decls, r)
+		p.rules[r.Name.Name] = v
+	}
+
+	// token rules, in the order they're scanned
+	tokens := make([]*tokenRule, 0, len(ctx.defs))
+	for _, t := range ctx.tokens {
+		tokens = append(tokens, t)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].idx < tokens[j].idx
+	})
+	for _, t := range tokens {
+		r := *t.rule
+		r.Name = &ast.Ident{NamePos: t.rule.Name.NamePos, Name: p.names[t]}
+		f.Decls = append(f.Decls, &r)
+	}
+	ret.Rules, ret.Stops = p.rules, p.ret
+	return
+}
+
+type choiceKey struct {
+	g *grammar
+	c *ast.Choice
+}
+
+type merger struct {
+	ctx   *context
+	names map[any]string // rules (*matcher.Var or *tokenRule) => names in the merged grammar
+	used  map[string]bool
+	stops map[choiceKey][]bool
+	queue []*matcher.Var
+	rules map[string]*matcher.Var
+	added map[*matcher.Var]bool
+	ret   map[*ast.Choice][]bool // stops of choices in the merged grammar
+}
+
+// nameAll names the rules of g, whose rules are referred with prefix, and the
+// ones of the grammars it imports.
+func (p *merger) nameAll(g *grammar, prefix string) {
+	names := make([]string, 0, len(g.rules)+len(g.tokens))
+	for name := range g.rules {
+		names = append(names, name)
+	}
+	for name := range g.tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var rule any
+		if v, ok := g.rules[name]; ok {
+			rule = v
+		} else {
+			rule = g.tokens[name]
+		}
+		if _, ok := p.names[rule]; !ok {
+			p.names[rule] = p.unique(prefix + name)
+		}
+	}
+	for _, sub := range g.subs {
+		p.nameAll(sub.g, prefix+sub.prefix)
+	}
+}
+
+// unique returns name if it isn't used yet, or name_2, name_3, etc. otherwise.
+func (p *merger) unique(name string) string {
+	ret := name
+	for i := 2; p.used[ret]; i++ {
+		ret = name + "_" + strconv.Itoa(i)
+	}
+	p.used[ret] = true
+	return ret
+}
+
+func (p *merger) add(v *matcher.Var) {
+	if !p.added[v] {
+		p.added[v] = true
+		p.queue = append(p.queue, v)
+	}
+}
+
+// expr returns a copy of e, which is compiled in grammar g, whose identifiers
+// referring to rules are renamed to the names of the rules in the merged
+// grammar.
+func (p *merger) expr(e ast.Expr, g *grammar) ast.Expr {
+	switch e := e.(type) {
+	case *ast.Ident:
+		switch rule := g.refs[e].(type) {
+		case *matcher.Var:
+			p.add(rule)
+			return &ast.Ident{NamePos: e.NamePos, Name: p.names[rule]}
+		case *tokenRule:
+			return &ast.Ident{NamePos: e.NamePos, Name: p.names[rule]}
+		}
+	case *ast.Sequence:
+		items := make([]ast.Expr, len(e.Items))
+		for i, item := range e.Items {
+			items[i] = p.expr(item, g)
+		}
+		return &ast.Sequence{Items: items}
+	case *ast.Choice:
+		options := make([]ast.Expr, len(e.Options))
+		key := choiceKey{g, e}
+		if ext, ok := p.ctx.exts[e]; ok { // R |= X, where X is compiled in ext
+			options[0], options[1] = p.expr(e.Options[0], g), p.expr(e.Options[1], ext)
+			key.g = ext
+		} else {
+			for i, option := range e.Options {
+				options[i] = p.expr(option, g)
+			}
+		}
+		ret := &ast.Choice{Options: options}
+		p.ret[ret] = p.stops[key]
+		return ret
+	case *ast.UnaryExpr:
+		return &ast.UnaryExpr{OpPos: e.OpPos, Op: e.Op, X: p.expr(e.X, g)}
+	case *ast.BinaryExpr:
+		return &ast.BinaryExpr{X: p.expr(e.X, g), OpPos: e.OpPos, Op: e.Op, Y: p.expr(e.Y, g)}
+	}
+	return e
+}
+
+// -----------------------------------------------------------------------------
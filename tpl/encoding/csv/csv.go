@@ -18,10 +18,299 @@ package csv
 
 import (
 	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
-// New creates a new csv object from a string
-func New(text string) (records [][]string, err error) {
-	return csv.NewReader(strings.NewReader(text)).ReadAll()
+// A ParseError is returned for parsing errors. Line numbers are 1-indexed and
+// columns are 0-indexed.
+type ParseError = csv.ParseError
+
+// -----------------------------------------------------------------------------
+
+// Table represents a csv table, whose first row is the header.
+type Table struct {
+	Header  []string
+	Records [][]string // rows except the header
+	index   map[string]int
+}
+
+// New creates a new csv table from a string, whose first row is the header.
+// Fields are separated by commas, or by delim if it's specified:
+//
+//	csv`> ";"
+//	name;age
+//	Tom;18
+//	`
+//
+// Leading spaces of fields are ignored.
+func New(text string, delim ...string) (ret *Table, err error) {
+	r := csv.NewReader(strings.NewReader(text))
+	r.TrimLeadingSpace = true
+	if len(delim) > 0 {
+		c, n := utf8.DecodeRuneInString(delim[0])
+		if len(delim) > 1 || n == 0 || n != len(delim[0]) {
+			return nil, fmt.Errorf("csv: invalid delimiter %q, should be a single character", strings.Join(delim, ""))
+		}
+		r.Comma = c
+	}
+	records, err := r.ReadAll()
+	if err != nil {
+		return
+	}
+	if len(records) == 0 {
+		return nil, errors.New("csv: missing header")
+	}
+	return NewTable(records[0], records[1:]), nil
+}
+
+// NewTable creates a new csv table from its header and records.
+func NewTable(header []string, records [][]string) *Table {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+	return &Table{Header: header, Records: records, index: index}
+}
+
+// Len returns the number of rows, except the header.
+func (p *Table) Len() int {
+	return len(p.Records)
+}
+
+// Col returns the index of a column, or -1 if it doesn't exist.
+func (p *Table) Col(name string) int {
+	if i, ok := p.index[name]; ok {
+		return i
+	}
+	return -1
+}
+
+// Row returns the i-th row (starting from 0, except the header).
+func (p *Table) Row(i int) Row {
+	return Row{p, i}
+}
+
+// Rows returns all rows, except the header.
+func (p *Table) Rows() []Row {
+	ret := make([]Row, len(p.Records))
+	for i := range ret {
+		ret[i] = Row{p, i}
+	}
+	return ret
+}
+
+// Gop_Enum enumerates rows of a table, which makes `for row in table` and
+// `[... for row in table]` work.
+func (p *Table) Gop_Enum(callback func(row Row)) {
+	for i := range p.Records {
+		callback(Row{p, i})
+	}
+}
+
+// Where returns a table of the rows satisfying cond.
+func (p *Table) Where(cond func(row Row) bool) *Table {
+	var records [][]string
+	for i, rec := range p.Records {
+		if cond(Row{p, i}) {
+			records = append(records, rec)
+		}
+	}
+	return &Table{Header: p.Header, Records: records, index: p.index}
+}
+
+// Select returns a table of the specified columns. It panics if a column
+// doesn't exist.
+func (p *Table) Select(names ...string) *Table {
+	cols := make([]int, len(names))
+	for i, name := range names {
+		if cols[i] = p.Col(name); cols[i] < 0 {
+			panic("csv: column not found: " + name)
+		}
+	}
+	records := make([][]string, len(p.Records))
+	for i, rec := range p.Records {
+		r := make([]string, len(cols))
+		for j, col := range cols {
+			r[j] = field(rec, col)
+		}
+		records[i] = r
+	}
+	return NewTable(names, records)
+}
+
+// Column returns the values of a column. It panics if the column doesn't
+// exist.
+func (p *Table) Column(name string) []string {
+	col := p.mustCol(name)
+	ret := make([]string, len(p.Records))
+	for i, rec := range p.Records {
+		ret[i] = field(rec, col)
+	}
+	return ret
+}
+
+// Ints returns the values of a column as integers.
+func (p *Table) Ints(name string) ([]int, error) {
+	return column(p, name, Row.Int)
+}
+
+// Floats returns the values of a column as floating-point numbers.
+func (p *Table) Floats(name string) ([]float64, error) {
+	return column(p, name, Row.Float)
+}
+
+// Bools returns the values of a column as booleans (see strconv.ParseBool).
+func (p *Table) Bools(name string) ([]bool, error) {
+	return column(p, name, Row.Bool)
+}
+
+// Times returns the values of a column as times (see Row.Time).
+func (p *Table) Times(name string) ([]time.Time, error) {
+	return column(p, name, Row.Time)
+}
+
+func column[T any](p *Table, name string, conv func(row Row, name string) (T, error)) ([]T, error) {
+	p.mustCol(name)
+	ret := make([]T, len(p.Records))
+	for i := range p.Records {
+		v, err := conv(Row{p, i}, name)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = v
+	}
+	return ret, nil
 }
+
+func (p *Table) mustCol(name string) int {
+	col := p.Col(name)
+	if col < 0 {
+		panic("csv: column not found: " + name)
+	}
+	return col
+}
+
+func field(rec []string, col int) string {
+	if col < len(rec) {
+		return rec[col]
+	}
+	return ""
+}
+
+// String returns the csv text of a table.
+func (p *Table) String() string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write(p.Header)
+	w.WriteAll(p.Records)
+	return b.String()
+}
+
+// -----------------------------------------------------------------------------
+
+// Row represents a row of a csv table.
+type Row struct {
+	t *Table
+	i int
+}
+
+// Fields returns the fields of a row.
+func (p Row) Fields() []string {
+	return p.t.Records[p.i]
+}
+
+// Get returns the field of a column. It returns "" if the column doesn't
+// exist.
+func (p Row) Get(name string) string {
+	if col := p.t.Col(name); col >= 0 {
+		return field(p.t.Records[p.i], col)
+	}
+	return ""
+}
+
+// Int returns the field of a column as an integer.
+func (p Row) Int(name string) (int, error) {
+	v, err := p.lookup(name)
+	if err == nil {
+		var n int
+		if n, err = strconv.Atoi(v); err == nil {
+			return n, nil
+		}
+	}
+	return 0, p.fieldError(name, err)
+}
+
+// Float returns the field of a column as a floating-point number.
+func (p Row) Float(name string) (float64, error) {
+	v, err := p.lookup(name)
+	if err == nil {
+		var f float64
+		if f, err = strconv.ParseFloat(v, 64); err == nil {
+			return f, nil
+		}
+	}
+	return 0, p.fieldError(name, err)
+}
+
+// Bool returns the field of a column as a boolean (see strconv.ParseBool).
+func (p Row) Bool(name string) (bool, error) {
+	v, err := p.lookup(name)
+	if err == nil {
+		var b bool
+		if b, err = strconv.ParseBool(v); err == nil {
+			return b, nil
+		}
+	}
+	return false, p.fieldError(name, err)
+}
+
+// TimeLayouts are the layouts to parse a time field, which are tried in order.
+var TimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Time returns the field of a column as a time (see TimeLayouts).
+func (p Row) Time(name string) (time.Time, error) {
+	v, err := p.lookup(name)
+	if err == nil {
+		t, e := parseTime(v)
+		if e == nil {
+			return t, nil
+		}
+		err = e
+	}
+	return time.Time{}, p.fieldError(name, err)
+}
+
+func parseTime(v string) (t time.Time, err error) {
+	for _, layout := range TimeLayouts {
+		if t, err = time.Parse(layout, v); err == nil {
+			return
+		}
+	}
+	return
+}
+
+var errNoColumn = errors.New("column not found")
+
+func (p Row) lookup(name string) (string, error) {
+	if col := p.t.Col(name); col >= 0 {
+		return field(p.t.Records[p.i], col), nil
+	}
+	return "", errNoColumn
+}
+
+func (p Row) fieldError(name string, err error) error {
+	return fmt.Errorf("csv: row %d, column %s: %w", p.i+1, name, err)
+}
+
+// -----------------------------------------------------------------------------
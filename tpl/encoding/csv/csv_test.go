@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goplus/gop/tpl/encoding/csv"
+)
+
+const people = `
+name, age, score, vip, joined
+Tom, 18, 90.5, true, 2024-01-02
+Jerry, 20, 85, false, 2023-05-06
+`
+
+func TestTable(t *testing.T) {
+	tbl, err := csv.New(people)
+	if err != nil {
+		t.Fatal("csv.New:", err)
+	}
+	if tbl.Len() != 2 || tbl.Col("age") != 1 || tbl.Col("x") != -1 {
+		t.Fatal("Table:", tbl.Len(), tbl.Header)
+	}
+	if ages, err := tbl.Ints("age"); err != nil || ages[0] != 18 || ages[1] != 20 {
+		t.Fatal("Ints:", ages, err)
+	}
+	if scores, err := tbl.Floats("score"); err != nil || scores[0] != 90.5 {
+		t.Fatal("Floats:", scores, err)
+	}
+	if vips, err := tbl.Bools("vip"); err != nil || !vips[0] || vips[1] {
+		t.Fatal("Bools:", vips, err)
+	}
+	if ts, err := tbl.Times("joined"); err != nil || ts[1].Year() != 2023 {
+		t.Fatal("Times:", ts, err)
+	}
+	if _, err = tbl.Ints("name"); err == nil || err.Error() != `csv: row 1, column name: strconv.Atoi: parsing "Tom": invalid syntax` {
+		t.Fatal("Ints:", err)
+	}
+	if _, err = tbl.Row(0).Int("x"); err == nil || err.Error() != "csv: row 1, column x: column not found" {
+		t.Fatal("Int:", err)
+	}
+	adults := tbl.Where(func(row csv.Row) bool {
+		age, _ := row.Int("age")
+		return age >= 20
+	}).Select("name", "age")
+	if v := adults.String(); v != "name,age\nJerry,20\n" {
+		t.Fatal("Where/Select:", v)
+	}
+	var names []string
+	tbl.Gop_Enum(func(row csv.Row) {
+		names = append(names, row.Get("name"))
+	})
+	if len(names) != 2 || names[1] != "Jerry" || tbl.Column("name")[0] != "Tom" {
+		t.Fatal("Gop_Enum:", names)
+	}
+}
+
+func TestDelim(t *testing.T) {
+	tbl, err := csv.New("a;b\n1;2\n", ";")
+	if err != nil || tbl.Rows()[0].Get("b") != "2" {
+		t.Fatal("csv.New:", tbl, err)
+	}
+	if _, err = csv.New("a;b\n", ";;"); err == nil || err.Error() != `csv: invalid delimiter ";;", should be a single character` {
+		t.Fatal("csv.New:", err)
+	}
+	if _, err = csv.New(""); err == nil || err.Error() != "csv: missing header" {
+		t.Fatal("csv.New:", err)
+	}
+}
+
+type person struct {
+	Name   string
+	Age    int
+	Score  float64 `csv:"score"`
+	VIP    bool    `csv:"vip"`
+	Joined time.Time
+	Note   string `csv:"-"`
+}
+
+func TestDecode(t *testing.T) {
+	tbl, err := csv.New(people)
+	if err != nil {
+		t.Fatal("csv.New:", err)
+	}
+	var ret []*person
+	if err = tbl.Decode(&ret); err != nil {
+		t.Fatal("Decode:", err)
+	}
+	if len(ret) != 2 || ret[0].Name != "Tom" || ret[0].Age != 18 || !ret[0].VIP || ret[1].Joined.Month() != 5 {
+		t.Fatal("Decode:", ret[0], ret[1])
+	}
+	var ps []person
+	if err = tbl.Select("age", "name").Decode(&ps); err != nil || ps[1].Name != "Jerry" || ps[1].Age != 20 {
+		t.Fatal("Decode:", ps, err)
+	}
+	if err = tbl.Select("name").Decode(&[]struct{ Name int }{}); err == nil ||
+		err.Error() != `csv: row 1, column name: strconv.ParseInt: parsing "Tom": invalid syntax` {
+		t.Fatal("Decode:", err)
+	}
+	if err = tbl.Decode(ps); err == nil {
+		t.Fatal("Decode: no error")
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	tyTime = reflect.TypeOf(time.Time{})
+)
+
+// Decode stores the rows of a table into ret, which must be a pointer to a
+// slice of structs (or pointers to structs). A column is stored into the field
+// tagged `csv:"name"`, or the field of the same name (case-insensitive) if
+// there is no such tag. Fields tagged `csv:"-"` and unknown columns are
+// skipped. Fields can be strings, integers, floating-point numbers, booleans
+// or time.Time (see TimeLayouts).
+func (p *Table) Decode(ret any) error {
+	v := reflect.ValueOf(ret)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return errors.New("csv.Decode: ret should be a pointer to a slice")
+	}
+	slice := v.Elem()
+	elem := slice.Type().Elem()
+	isPtr := elem.Kind() == reflect.Ptr
+	if isPtr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return errors.New("csv.Decode: ret should be a pointer to a slice of structs")
+	}
+	fields := p.fieldsOf(elem)
+	out := reflect.MakeSlice(slice.Type(), len(p.Records), len(p.Records))
+	for i, rec := range p.Records {
+		item := reflect.New(elem)
+		for col, fld := range fields {
+			if fld == nil {
+				continue
+			}
+			if err := setField(item.Elem().FieldByIndex(fld), field(rec, col)); err != nil {
+				return Row{p, i}.fieldError(p.Header[col], err)
+			}
+		}
+		if isPtr {
+			out.Index(i).Set(item)
+		} else {
+			out.Index(i).Set(item.Elem())
+		}
+	}
+	slice.Set(out)
+	return nil
+}
+
+// fieldsOf returns the field index of each column, or nil if it's skipped.
+func (p *Table) fieldsOf(t reflect.Type) [][]int {
+	ret := make([][]int, len(p.Header))
+	for col, name := range p.Header {
+		for i, n := 0, t.NumField(); i < n; i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" { // unexported
+				continue
+			}
+			if tag, ok := f.Tag.Lookup("csv"); ok {
+				if tag == name {
+					ret[col] = f.Index
+					break
+				}
+			} else if strings.EqualFold(f.Name, name) && ret[col] == nil {
+				ret[col] = f.Index
+			}
+		}
+	}
+	return ret
+}
+
+func setField(f reflect.Value, v string) error {
+	if f.Type() == tyTime {
+		t, err := parseTime(v)
+		if err == nil {
+			f.Set(reflect.ValueOf(t))
+		}
+		return err
+	}
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(v, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(v, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(v, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(x)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	default:
+		return errors.New("unsupported field type " + f.Type().String())
+	}
+	return nil
+}
@@ -18,11 +18,302 @@ package json
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 )
 
-// New creates a new json object from a string
-func New(text string) (ret any, err error) {
-	err = json.NewDecoder(strings.NewReader(text)).Decode(&ret)
+// A SyntaxError is a description of a JSON syntax error.
+type SyntaxError = json.SyntaxError
+
+// JSON5 is the mode of New which accepts comments, trailing commas, unquoted
+// keys and single-quoted strings, as JSON5 does. It also accepts JSONC, ie.
+// JSON with comments.
+const JSON5 = "json5"
+
+// -----------------------------------------------------------------------------
+
+// Value represents a JSON value. Data is nil, bool, float64, string, []any or
+// map[string]any.
+type Value struct {
+	Data any
+}
+
+// New creates a new json object from a string. If mode is JSON5, the string
+// can be written in the lenient syntax of JSON5:
+//
+//	json`> "json5"
+//	{
+//		// comments
+//		name: 'Ken',
+//		tags: ["a", "b",],
+//	}
+//	`
+//
+// Other features of JSON5, like hexadecimal numbers and multi-line strings,
+// aren't supported.
+func New(text string, mode ...string) (ret *Value, err error) {
+	if len(mode) > 0 {
+		if len(mode) > 1 || mode[0] != JSON5 {
+			return nil, fmt.Errorf("json: invalid mode %q, should be %q", strings.Join(mode, ""), JSON5)
+		}
+		if text, err = strict(text); err != nil {
+			return
+		}
+	}
+	ret = new(Value)
+	if err = json.NewDecoder(strings.NewReader(text)).Decode(&ret.Data); err != nil {
+		return nil, err
+	}
 	return
 }
+
+// Get returns the value at path (see Lookup), or nil if it doesn't exist. It
+// panics if path is invalid.
+func (p *Value) Get(path string) any {
+	ret, _ := p.Lookup(path)
+	return ret
+}
+
+// Lookup returns the value at path, and reports whether it exists. path is a
+// JSON Pointer (RFC 6901), or a simple path of keys separated by dots:
+//
+//	/a/b/0    JSON Pointer, where ~0 and ~1 are escapes of ~ and /
+//	a.b[0]    simple path, the same as a.b.0
+//	""        the value itself
+//
+// An array element is selected by its index (starting from 0). Lookup panics
+// if path is invalid.
+func (p *Value) Lookup(path string) (ret any, ok bool) {
+	keys, err := parsePath(path)
+	if err != nil {
+		panic(err)
+	}
+	ret = p.Data
+	for _, key := range keys {
+		switch v := ret.(type) {
+		case map[string]any:
+			if ret, ok = v[key]; !ok {
+				return
+			}
+		case []any:
+			i, ok := index(key)
+			if !ok || i >= len(v) {
+				return nil, false
+			}
+			ret = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return ret, true
+}
+
+func index(key string) (i int, ok bool) {
+	if key == "" || len(key) > 1 && key[0] == '0' {
+		return
+	}
+	for _, c := range []byte(key) {
+		if c < '0' || c > '9' || i > (1<<31)/10 {
+			return 0, false
+		}
+		i = i*10 + int(c-'0')
+	}
+	return i, true
+}
+
+// String returns the JSON text of a value.
+func (p *Value) String() string {
+	b, _ := json.Marshal(p.Data)
+	return string(b)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p *Value) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Data)
+}
+
+// -----------------------------------------------------------------------------
+
+// PathError represents an invalid path of Lookup.
+type PathError struct {
+	Path string
+	Msg  string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("json: invalid path %q: %s", e.Path, e.Msg)
+}
+
+var unescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func parsePath(path string) (keys []string, err error) {
+	if path == "" {
+		return
+	}
+	if path[0] == '/' {
+		keys = strings.Split(path[1:], "/")
+		for i, key := range keys {
+			for j := 0; j < len(key); j++ {
+				if key[j] == '~' && (j+1 == len(key) || key[j+1] != '0' && key[j+1] != '1') {
+					return nil, &PathError{path, "invalid escape in " + strconv.Quote(key)}
+				}
+			}
+			keys[i] = unescaper.Replace(key)
+		}
+		return
+	}
+	s := path
+	for {
+		end := strings.IndexAny(s, ".[")
+		if end < 0 {
+			end = len(s)
+		}
+		if end == 0 {
+			return nil, &PathError{path, "missing key"}
+		}
+		keys, s = append(keys, s[:end]), s[end:]
+		for strings.HasPrefix(s, "[") {
+			end = strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, &PathError{path, "missing ]"}
+			}
+			if _, ok := index(s[1:end]); !ok {
+				return nil, &PathError{path, "invalid index [" + s[1:end] + "]"}
+			}
+			keys, s = append(keys, s[1:end]), s[end+1:]
+		}
+		if s == "" {
+			return
+		}
+		if s[0] != '.' {
+			return nil, &PathError{path, "unexpected " + s}
+		}
+		s = s[1:]
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// strict converts the JSON5 text to strict JSON. Comments are replaced by
+// spaces, trailing commas are removed, unquoted keys are quoted and
+// single-quoted strings are double-quoted. Syntax errors other than
+// unterminated comments are left to the JSON decoder.
+func strict(text string) (string, error) {
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '"' || c == '\'':
+			i = quote(&b, text, i)
+		case c == '/' && i+1 < len(text) && (text[i+1] == '/' || text[i+1] == '*'):
+			end := skipComment(text, i)
+			if end < 0 {
+				return "", errors.New("json: comment not terminated")
+			}
+			for _, c := range []byte(text[i:end]) {
+				if c != '\n' {
+					c = ' '
+				}
+				b.WriteByte(c)
+			}
+			i = end
+		case c == ',':
+			if j := skipSpace(text, i+1); j < len(text) && (text[j] == '}' || text[j] == ']') {
+				c = ' '
+			}
+			b.WriteByte(c)
+			i++
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(text) && (isIdentStart(text[j]) || text[j] >= '0' && text[j] <= '9') {
+				j++
+			}
+			if k := skipSpace(text, j); k < len(text) && text[k] == ':' {
+				b.WriteByte('"')
+				b.WriteString(text[i:j])
+				b.WriteByte('"')
+			} else {
+				b.WriteString(text[i:j])
+			}
+			i = j
+		case c >= '0' && c <= '9':
+			j := i + 1 // a number, eg. 1e5, whose letters aren't a key
+			for j < len(text) && (isIdentStart(text[j]) || text[j] >= '0' && text[j] <= '9' || text[j] == '.') {
+				j++
+			}
+			b.WriteString(text[i:j])
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// quote writes the string literal at text[i:] double-quoted, and returns the
+// offset after it.
+func quote(b *strings.Builder, text string, i int) int {
+	q := text[i]
+	b.WriteByte('"')
+	for i++; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == q:
+			b.WriteByte('"')
+			return i + 1
+		case c == '\\' && i+1 < len(text):
+			if i++; text[i] != '\'' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(text[i])
+		case c == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return i
+}
+
+// skipComment returns the offset after the comment at text[i:], or -1 if it's
+// an unterminated /*-style comment.
+func skipComment(text string, i int) int {
+	if text[i+1] == '/' {
+		if end := strings.IndexByte(text[i:], '\n'); end >= 0 {
+			return i + end
+		}
+		return len(text)
+	}
+	if end := strings.Index(text[i+2:], "*/"); end >= 0 {
+		return i + 2 + end + 2
+	}
+	return -1
+}
+
+// skipSpace returns the offset of the first character which isn't a space or
+// in a comment at text[i:].
+func skipSpace(text string, i int) int {
+	for i < len(text) {
+		switch c := text[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '/' && i+1 < len(text) && (text[i+1] == '/' || text[i+1] == '*'):
+			if i = skipComment(text, i); i < 0 {
+				return len(text)
+			}
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '$'
+}
+
+// -----------------------------------------------------------------------------
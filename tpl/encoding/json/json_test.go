@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goplus/gop/tpl/encoding/json"
+	"github.com/goplus/gop/tpl/encoding/json5"
+)
+
+const config = `
+// JSONC/JSON5 config
+{
+	name: 'Ken "K"', /* single-quoted */
+	$ver: 1.5e3,
+	"a/b": {"~c": [true, null, 'it\'s',],},
+	url: "http://goplus.org", // a // in a string
+}
+`
+
+func TestJSON5(t *testing.T) {
+	v, err := json.New(config, json.JSON5)
+	if err != nil {
+		t.Fatal("json.New:", err)
+	}
+	if s := v.String(); s != `{"$ver":1500,"a/b":{"~c":[true,null,"it's"]},"name":"Ken \"K\"","url":"http://goplus.org"}` {
+		t.Fatal("String:", s)
+	}
+	v2, err := json5.New(config)
+	if err != nil || !reflect.DeepEqual(v, v2) {
+		t.Fatal("json5.New:", v2, err)
+	}
+	if _, err = json.New(config); err == nil {
+		t.Fatal("json.New: no error of comments")
+	}
+	if _, err = json.New(`{}`, "yaml"); err == nil || err.Error() != `json: invalid mode "yaml", should be "json5"` {
+		t.Fatal("json.New:", err)
+	}
+	if _, err = json.New(`{} /* x`, json.JSON5); err == nil || err.Error() != "json: comment not terminated" {
+		t.Fatal("json.New:", err)
+	}
+	if _, err = json.New(`{a: 1,,}`, json.JSON5); err == nil {
+		t.Fatal("json.New: no error of ,,")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	v, err := json.New(config, json.JSON5)
+	if err != nil {
+		t.Fatal("json.New:", err)
+	}
+	cases := []struct {
+		path string
+		ret  any
+		ok   bool
+	}{
+		{"/name", `Ken "K"`, true},
+		{"/a~1b/~0c/2", "it's", true},
+		{"/a~1b/~0c/1", nil, true},
+		{"/a~1b/~0c/3", nil, false},
+		{"/a~1b/~0c/01", nil, false},
+		{"/a~1b/~0c/-", nil, false},
+		{"/name/0", nil, false},
+		{"/none", nil, false},
+		{"$ver", 1500.0, true},
+		{"a/b.~c[0]", true, true},
+		{"a/b.~c.2", "it's", true},
+	}
+	for _, c := range cases {
+		if ret, ok := v.Lookup(c.path); ret != c.ret || ok != c.ok {
+			t.Fatal("Lookup:", c.path, ret, ok)
+		}
+	}
+	if ret := v.Get(""); !reflect.DeepEqual(ret, v.Data) {
+		t.Fatal("Get:", ret)
+	}
+	if ret := v.Get("url"); ret != "http://goplus.org" {
+		t.Fatal("Get:", ret)
+	}
+}
+
+func TestPathErr(t *testing.T) {
+	v, err := json.New(`[1, 2]`)
+	if err != nil {
+		t.Fatal("json.New:", err)
+	}
+	errs := []struct {
+		path string
+		err  string
+	}{
+		{"/a~2", `json: invalid path "/a~2": invalid escape in "a~2"`},
+		{"/a~", `json: invalid path "/a~": invalid escape in "a~"`},
+		{"a..b", `json: invalid path "a..b": missing key`},
+		{"a[0", `json: invalid path "a[0": missing ]`},
+		{"a[x]", `json: invalid path "a[x]": invalid index [x]`},
+		{"a[0]b", `json: invalid path "a[0]b": unexpected b`},
+	}
+	for _, e := range errs {
+		func() {
+			defer func() {
+				if err, ok := recover().(error); !ok || err.Error() != e.err {
+					t.Fatal("Get:", e.path, err)
+				}
+			}()
+			v.Get(e.path)
+		}()
+	}
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xml
+
+import (
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+type pred struct {
+	index int    // [n] if index > 0
+	attr  string // [@attr] or [@attr='value']
+	value string
+	eq    bool
+}
+
+type step struct {
+	desc  bool // preceded by //
+	name  string
+	preds []pred
+}
+
+// Find returns the elements selected by path, which is a simplified XPath:
+//
+//	a/b       child elements b of child elements a
+//	/a        the root element a, from any node of the document
+//	//b       descendant elements b
+//	a//b      descendant elements b of child elements a
+//	*         any child element
+//	svg:rect  elements rect with the namespace prefix svg
+//	. and ..  the node itself and its parent
+//	a[2]      the second child element a (starting from 1)
+//	a[@id]    child elements a having attribute id
+//	a[@id='x'] child elements a whose attribute id is x
+//
+// An unprefixed name matches elements of the name in any namespace. Find panics
+// if path is invalid.
+func (p *Node) Find(path string) []*Node {
+	steps, err := parsePath(path)
+	if err != nil {
+		panic(err)
+	}
+	ctx := []*Node{p}
+	if strings.HasPrefix(path, "/") {
+		for ctx[0].Parent != nil {
+			ctx[0] = ctx[0].Parent
+		}
+	}
+	for _, s := range steps {
+		ctx = s.selectFrom(ctx)
+	}
+	return ctx
+}
+
+// FindFirst returns the first element selected by path (see Find), or nil if
+// there isn't any.
+func (p *Node) FindFirst(path string) *Node {
+	if ret := p.Find(path); len(ret) > 0 {
+		return ret[0]
+	}
+	return nil
+}
+
+func (s *step) selectFrom(ctx []*Node) []*Node {
+	if s.desc {
+		var all []*Node
+		for _, n := range ctx {
+			all = n.descendants(all)
+		}
+		ctx = all
+	}
+	var ret []*Node
+	seen := make(map[*Node]bool)
+	for _, n := range ctx {
+		var sel []*Node
+		switch s.name {
+		case ".":
+			sel = []*Node{n}
+		case "..":
+			if n.Parent != nil {
+				sel = []*Node{n.Parent}
+			}
+		default:
+			for _, c := range n.Nodes {
+				if c.Type == ElementNode && s.match(c) {
+					sel = append(sel, c)
+				}
+			}
+		}
+		for _, pd := range s.preds {
+			sel = pd.filter(sel)
+		}
+		for _, c := range sel {
+			if !seen[c] {
+				seen[c] = true
+				ret = append(ret, c)
+			}
+		}
+	}
+	return ret
+}
+
+// descendants appends p and all its descendants which can have children.
+func (p *Node) descendants(ret []*Node) []*Node {
+	ret = append(ret, p)
+	for _, n := range p.Nodes {
+		if n.Type == ElementNode {
+			ret = n.descendants(ret)
+		}
+	}
+	return ret
+}
+
+func (s *step) match(n *Node) bool {
+	if s.name == "*" {
+		return true
+	}
+	prefix, local := splitName(s.name)
+	return n.Data == local && (prefix == "" || n.Prefix == prefix)
+}
+
+func (pd *pred) filter(sel []*Node) []*Node {
+	if pd.index > 0 {
+		if pd.index <= len(sel) {
+			return sel[pd.index-1 : pd.index]
+		}
+		return nil
+	}
+	var ret []*Node
+	for _, n := range sel {
+		if v, ok := n.LookupAttr(pd.attr); ok && (!pd.eq || v == pd.value) {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+
+// PathError represents an invalid path of Find.
+type PathError struct {
+	Path string
+	Msg  string
+}
+
+func (e *PathError) Error() string {
+	return "xml: invalid path " + strconv.Quote(e.Path) + ": " + e.Msg
+}
+
+func parsePath(path string) (steps []*step, err error) {
+	fail := func(msg string) ([]*step, error) {
+		return nil, &PathError{path, msg}
+	}
+	s := path
+	if strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "//") {
+		s = s[1:]
+	}
+	for {
+		st := new(step)
+		if strings.HasPrefix(s, "//") {
+			st.desc, s = true, s[2:]
+		}
+		end := strings.IndexAny(s, "/[")
+		if end < 0 {
+			end = len(s)
+		}
+		if st.name, s = s[:end], s[end:]; st.name == "" {
+			return fail("missing element name")
+		}
+		for strings.HasPrefix(s, "[") {
+			end = strings.IndexByte(s, ']')
+			if end < 0 {
+				return fail("missing ]")
+			}
+			pd, ok := parsePred(s[1:end])
+			if !ok {
+				return fail("invalid predicate [" + s[1:end] + "]")
+			}
+			st.preds, s = append(st.preds, pd), s[end+1:]
+		}
+		steps = append(steps, st)
+		if s == "" {
+			return
+		}
+		if !strings.HasPrefix(s, "//") {
+			if s[0] != '/' {
+				return fail("unexpected " + strconv.Quote(s))
+			}
+			s = s[1:]
+		}
+	}
+}
+
+func parsePred(s string) (pd pred, ok bool) {
+	if !strings.HasPrefix(s, "@") {
+		n, err := strconv.Atoi(s)
+		return pred{index: n}, err == nil && n > 0
+	}
+	s = s[1:]
+	if pos := strings.IndexByte(s, '='); pos >= 0 {
+		v := s[pos+1:]
+		if len(v) < 2 || (v[0] != '\'' && v[0] != '"') || v[len(v)-1] != v[0] {
+			return
+		}
+		pd.value, pd.eq, s = v[1:len(v)-1], true, s[:pos]
+	}
+	pd.attr = s
+	return pd, s != ""
+}
+
+// -----------------------------------------------------------------------------
@@ -17,12 +17,368 @@
 package xml
 
 import (
+	"bytes"
 	"encoding/xml"
+	"fmt"
+	"io"
 	"strings"
 )
 
-// New creates a new xml object from a string
-func New(text string) (ret any, err error) {
-	err = xml.NewDecoder(strings.NewReader(text)).Decode(&ret)
-	return
+// -----------------------------------------------------------------------------
+
+// A NodeType is the type of a Node.
+type NodeType int
+
+const (
+	DocumentNode NodeType = iota
+	ElementNode
+	TextNode
+	CDATANode
+	CommentNode
+	ProcInstNode  // eg. <?xml version="1.0"?>
+	DirectiveNode // eg. <!DOCTYPE html>
+)
+
+// A SyntaxError represents a syntax error in the XML input stream.
+type SyntaxError = xml.SyntaxError
+
+// XMLNamespace is the namespace bound to the prefix xml.
+const XMLNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// An Attr is an attribute of an element.
+type Attr struct {
+	Prefix string // eg. "xlink" of xlink:href, or "xmlns" of xmlns:svg
+	Space  string // namespace URL, empty for an unprefixed attribute
+	Name   string // local name
+	Value  string
+}
+
+// A Node is a node of an XML document tree.
+//
+// For an element, Data is its local name, Prefix and Space are its namespace
+// prefix and URL, and Attrs are its attributes. For a processing instruction,
+// Data is its target, and Inst is its instruction. For other nodes, Data is
+// the text, CDATA, comment or directive.
+type Node struct {
+	Type   NodeType
+	Prefix string
+	Space  string
+	Data   string
+	Inst   string
+	Attrs  []Attr
+
+	Parent *Node
+	Nodes  []*Node // child nodes
+}
+
+// New creates a new xml document from a string.
+func New(text string) (ret *Node, err error) {
+	return Parse(strings.NewReader(text))
+}
+
+// Parse parses an xml document from r.
+func Parse(r io.Reader) (doc *Node, err error) {
+	var b bytes.Buffer
+	d := xml.NewDecoder(io.TeeReader(r, &b))
+	doc = &Node{Type: DocumentNode}
+	scopes := []map[string]string{{"xml": XMLNamespace}}
+	cur := doc
+	for {
+		start := d.InputOffset()
+		t, e := d.RawToken()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return nil, e
+		}
+		var n *Node
+		switch t := t.(type) {
+		case xml.StartElement:
+			scope := make(map[string]string)
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" {
+					scope[a.Name.Local] = a.Value
+				} else if a.Name.Space == "" && a.Name.Local == "xmlns" {
+					scope[""] = a.Value
+				}
+			}
+			scopes = append(scopes, scope)
+			n = &Node{Type: ElementNode, Prefix: t.Name.Space, Data: t.Name.Local}
+			if n.Space, err = lookupNS(scopes, n.Prefix, d); err != nil {
+				return nil, err
+			}
+			for _, a := range t.Attr {
+				attr := Attr{Prefix: a.Name.Space, Name: a.Name.Local, Value: a.Value}
+				if attr.Prefix != "" && attr.Prefix != "xmlns" {
+					if attr.Space, err = lookupNS(scopes, attr.Prefix, d); err != nil {
+						return nil, err
+					}
+				}
+				n.Attrs = append(n.Attrs, attr)
+			}
+			cur.append(n)
+			cur = n
+			continue
+		case xml.EndElement:
+			if cur == doc || t.Name.Space != cur.Prefix || t.Name.Local != cur.Data {
+				line, _ := d.InputPos()
+				return nil, &xml.SyntaxError{Msg: "unexpected end element </" + qname(t.Name) + ">", Line: line}
+			}
+			cur, scopes = cur.Parent, scopes[:len(scopes)-1]
+			continue
+		case xml.CharData:
+			n = &Node{Type: TextNode, Data: string(t)}
+			if bytes.HasPrefix(b.Bytes()[start:], cdataBegin) {
+				n.Type = CDATANode
+			}
+		case xml.Comment:
+			n = &Node{Type: CommentNode, Data: string(t)}
+		case xml.ProcInst:
+			n = &Node{Type: ProcInstNode, Data: t.Target, Inst: string(t.Inst)}
+		case xml.Directive:
+			n = &Node{Type: DirectiveNode, Data: string(t)}
+		}
+		if cur == doc && n.Type == TextNode && strings.TrimSpace(n.Data) == "" {
+			continue // whitespace out of the root element
+		}
+		cur.append(n)
+	}
+	if cur != doc {
+		line, _ := d.InputPos()
+		return nil, &xml.SyntaxError{Msg: "unexpected EOF", Line: line}
+	}
+	if doc.Root() == nil {
+		return nil, &xml.SyntaxError{Msg: "no root element", Line: 1}
+	}
+	return doc, nil
 }
+
+var cdataBegin = []byte("<![CDATA[")
+
+func lookupNS(scopes []map[string]string, prefix string, d *xml.Decoder) (string, error) {
+	for i := len(scopes) - 1; i >= 0; i-- {
+		if url, ok := scopes[i][prefix]; ok {
+			return url, nil
+		}
+	}
+	if prefix == "" {
+		return "", nil
+	}
+	line, _ := d.InputPos()
+	return "", &xml.SyntaxError{Msg: "undefined namespace prefix: " + prefix, Line: line}
+}
+
+func qname(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
+
+func (p *Node) append(n *Node) {
+	n.Parent = p
+	p.Nodes = append(p.Nodes, n)
+}
+
+// -----------------------------------------------------------------------------
+
+// Name returns the qualified name of an element, eg. "svg:rect".
+func (p *Node) Name() string {
+	return qname(xml.Name{Space: p.Prefix, Local: p.Data})
+}
+
+// Root returns the root element of a document, or p itself if p isn't a
+// document. It returns nil if there is no root element.
+func (p *Node) Root() *Node {
+	if p.Type != DocumentNode {
+		return p
+	}
+	for _, n := range p.Nodes {
+		if n.Type == ElementNode {
+			return n
+		}
+	}
+	return nil
+}
+
+// Children returns the child elements of a node. If p is a document, they're
+// the child elements of the root element.
+func (p *Node) Children() []*Node {
+	if p.Type == DocumentNode {
+		if p = p.Root(); p == nil {
+			return nil
+		}
+	}
+	var ret []*Node
+	for _, n := range p.Nodes {
+		if n.Type == ElementNode {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// LookupAttr returns the value of an attribute, whose name is in form of
+// local or prefix:local, and whether it exists.
+func (p *Node) LookupAttr(name string) (string, bool) {
+	if p.Type == DocumentNode {
+		if p = p.Root(); p == nil {
+			return "", false
+		}
+	}
+	prefix, local := splitName(name)
+	for _, a := range p.Attrs {
+		if a.Name == local && a.Prefix == prefix {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// Attr returns the value of an attribute, whose name is in form of local or
+// prefix:local. It returns "" if the attribute doesn't exist.
+func (p *Node) Attr(name string) string {
+	v, _ := p.LookupAttr(name)
+	return v
+}
+
+// Text returns the text of a node: all text and CDATA of it and its
+// descendants.
+func (p *Node) Text() string {
+	switch p.Type {
+	case TextNode, CDATANode:
+		return p.Data
+	case ElementNode, DocumentNode:
+		var b strings.Builder
+		p.text(&b)
+		return b.String()
+	}
+	return ""
+}
+
+func (p *Node) text(b *strings.Builder) {
+	for _, n := range p.Nodes {
+		switch n.Type {
+		case TextNode, CDATANode:
+			b.WriteString(n.Data)
+		case ElementNode:
+			n.text(b)
+		}
+	}
+}
+
+func splitName(name string) (prefix, local string) {
+	if pos := strings.IndexByte(name, ':'); pos >= 0 {
+		return name[:pos], name[pos+1:]
+	}
+	return "", name
+}
+
+// -----------------------------------------------------------------------------
+
+// String returns the xml text of a node.
+func (p *Node) String() string {
+	var b strings.Builder
+	p.WriteTo(&b)
+	return b.String()
+}
+
+// WriteTo writes the xml text of a node to w.
+func (p *Node) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countWriter{w: w}
+	p.write(cw)
+	return cw.n, cw.err
+}
+
+type countWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (p *countWriter) WriteString(s string) {
+	if p.err == nil {
+		var n int
+		n, p.err = io.WriteString(p.w, s)
+		p.n += int64(n)
+	}
+}
+
+func (p *Node) write(w *countWriter) {
+	switch p.Type {
+	case DocumentNode:
+		for i, n := range p.Nodes {
+			if i > 0 {
+				w.WriteString("\n")
+			}
+			n.write(w)
+		}
+	case ElementNode:
+		w.WriteString("<" + p.Name())
+		for _, a := range p.Attrs {
+			w.WriteString(" ")
+			if a.Prefix != "" {
+				w.WriteString(a.Prefix + ":")
+			}
+			w.WriteString(a.Name + `="` + escape(a.Value, true) + `"`)
+		}
+		if len(p.Nodes) == 0 {
+			w.WriteString("/>")
+			return
+		}
+		w.WriteString(">")
+		for _, n := range p.Nodes {
+			n.write(w)
+		}
+		w.WriteString("</" + p.Name() + ">")
+	case TextNode:
+		w.WriteString(escape(p.Data, false))
+	case CDATANode:
+		// "]]>" can't be in a CDATA section, so it's split into two sections
+		w.WriteString("<![CDATA[" + strings.ReplaceAll(p.Data, "]]>", "]]]]><![CDATA[>") + "]]>")
+	case CommentNode:
+		w.WriteString("<!--" + p.Data + "-->")
+	case ProcInstNode:
+		w.WriteString("<?" + p.Data)
+		if p.Inst != "" {
+			w.WriteString(" " + p.Inst)
+		}
+		w.WriteString("?>")
+	case DirectiveNode:
+		w.WriteString("<!" + p.Data + ">")
+	default:
+		panic(fmt.Sprintf("xml: unknown node type %d", p.Type))
+	}
+}
+
+func escape(s string, attr bool) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '&':
+			b.WriteString("&amp;")
+		case '"':
+			if attr {
+				b.WriteString("&quot;")
+				continue
+			}
+			b.WriteRune(c)
+		case '\n', '\t', '\r':
+			if attr {
+				fmt.Fprintf(&b, "&#x%X;", c)
+				continue
+			}
+			b.WriteRune(c)
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xml_test
+
+import (
+	"testing"
+
+	"github.com/goplus/gop/tpl/encoding/xml"
+)
+
+const library = `<?xml version="1.0"?>
+<!-- books -->
+<library xmlns="urn:lib" xmlns:x="urn:x">
+  <book id="1" x:lang="en"><title>Go &amp; Go+</title><note><![CDATA[<b>new</b>]]></note></book>
+  <book id="2"><title>XML</title></book>
+  <x:magazine id="3"/>
+</library>`
+
+func TestNode(t *testing.T) {
+	doc, err := xml.New(library)
+	if err != nil {
+		t.Fatal("xml.New:", err)
+	}
+	root := doc.Root()
+	if root.Name() != "library" || root.Space != "urn:lib" || len(doc.Children()) != 3 {
+		t.Fatal("Root:", root.Name(), root.Space, len(doc.Children()))
+	}
+	book := root.Children()[0]
+	if book.Attr("id") != "1" || book.Attr("x:lang") != "en" || book.Attr("lang") != "" {
+		t.Fatal("Attr:", book.Attrs)
+	}
+	if book.Attrs[1].Space != "urn:x" {
+		t.Fatal("Attr namespace:", book.Attrs[1])
+	}
+	if v := book.Text(); v != "Go & Go+<b>new</b>" {
+		t.Fatal("Text:", v)
+	}
+	if note := book.FindFirst("note"); note.Nodes[0].Type != xml.CDATANode {
+		t.Fatal("CDATA:", note.Nodes[0].Type)
+	}
+	if m := root.Children()[2]; m.Prefix != "x" || m.Space != "urn:x" {
+		t.Fatal("magazine:", m.Prefix, m.Space)
+	}
+	if v := doc.String(); v != `<?xml version="1.0"?>
+<!-- books -->
+<library xmlns="urn:lib" xmlns:x="urn:x">
+  <book id="1" x:lang="en"><title>Go &amp; Go+</title><note><![CDATA[<b>new</b>]]></note></book>
+  <book id="2"><title>XML</title></book>
+  <x:magazine id="3"/>
+</library>` {
+		t.Fatal("String:", v)
+	}
+}
+
+func TestFind(t *testing.T) {
+	doc, err := xml.New(library)
+	if err != nil {
+		t.Fatal("xml.New:", err)
+	}
+	cases := []struct {
+		path string
+		ids  string
+	}{
+		{"library/book", "12"},
+		{"/library/*", "123"},
+		{"//book[2]", "2"},
+		{"//book[@x:lang]", "1"},
+		{"//*[@id='3']", "3"},
+		{"//x:magazine", "3"},
+		{"//title/../.", "12"},
+		{"//title/..[@id=\"2\"]", "2"},
+		{"book", ""},
+	}
+	for _, c := range cases {
+		ids := ""
+		for _, n := range doc.Find(c.path) {
+			ids += n.Attr("id")
+		}
+		if ids != c.ids {
+			t.Fatal("Find:", c.path, ids)
+		}
+	}
+	if n := doc.Root().FindFirst("/library/book/title"); n.Text() != "Go & Go+" {
+		t.Fatal("FindFirst:", n)
+	}
+	defer func() {
+		if e := recover(); e == nil || e.(error).Error() != `xml: invalid path "a[x]": invalid predicate [x]` {
+			t.Fatal("Find:", e)
+		}
+	}()
+	doc.Find("a[x]")
+}
+
+func TestErr(t *testing.T) {
+	cases := []struct {
+		text string
+		err  string
+	}{
+		{"<a></b>", "XML syntax error on line 1: unexpected end element </b>"},
+		{"<a>\n<b>", "XML syntax error on line 2: unexpected EOF"},
+		{"<a><x:b/></a>", "XML syntax error on line 1: undefined namespace prefix: x"},
+		{"<!-- a -->", "XML syntax error on line 1: no root element"},
+	}
+	for _, c := range cases {
+		if _, err := xml.New(c.text); err == nil || err.Error() != c.err {
+			t.Fatal("xml.New:", c.text, err)
+		}
+	}
+}
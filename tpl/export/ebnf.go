@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/token"
+)
+
+const (
+	precChoice = iota
+	precSeq
+	precPostfix
+)
+
+// EBNF writes the rules of a grammar in W3C EBNF notation (see
+// https://www.w3.org/TR/xml/#sec-notation). Since EBNF has no lookahead, &R
+// and !R are written as comments, as well as the adjoin operator of R1 ++ R2.
+// Token classes, such as IDENT and INT, are listed in a comment at the end.
+func EBNF(w io.Writer, files ...*ast.File) error {
+	if err := checkFiles(files); err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	all := rules(files)
+	width := 0
+	for _, r := range all {
+		if n := len(r.Name.Name); n > width {
+			width = n
+		}
+	}
+	indent := strings.Repeat(" ", width+3)
+	for _, r := range all {
+		name := r.Name.Name
+		b.WriteString(name + strings.Repeat(" ", width-len(name)) + " ::= ")
+		if c, ok := r.Expr.(*ast.Choice); ok {
+			for i, option := range c.Options {
+				if i > 0 {
+					b.WriteString("\n" + indent + "| ")
+				}
+				writeEBNF(&b, option, precSeq)
+			}
+		} else {
+			writeEBNF(&b, r.Expr, precChoice)
+		}
+		b.WriteByte('\n')
+	}
+	if classes := tokenClasses(files); len(classes) > 0 {
+		names := make([]string, 0, len(classes))
+		for name := range classes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b.WriteString("\n/* token classes: " + strings.Join(names, " ") + " */\n")
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+func writeEBNF(b *bytes.Buffer, e ast.Expr, prec int) {
+	switch e := e.(type) {
+	case *ast.Ident:
+		b.WriteString(e.Name)
+	case *ast.BasicLit:
+		text, _, ok := literal(e)
+		switch {
+		case ok:
+			b.WriteString(quote(text))
+		case e.Value == `""` || e.Value == "``":
+			b.WriteString("/* empty */")
+		default:
+			b.WriteString(e.Value)
+		}
+	case *ast.Choice:
+		paren(b, prec > precChoice, func() {
+			for i, option := range e.Options {
+				if i > 0 {
+					b.WriteString(" | ")
+				}
+				writeEBNF(b, option, precSeq)
+			}
+		})
+	case *ast.Sequence:
+		paren(b, prec > precSeq, func() {
+			for i, item := range e.Items {
+				if i > 0 {
+					b.WriteByte(' ')
+				}
+				writeEBNF(b, item, precPostfix)
+			}
+		})
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case token.AND, token.NOT:
+			b.WriteString("/* " + e.Op.String())
+			writeEBNF(b, e.X, precPostfix)
+			b.WriteString(" */")
+		default:
+			writeEBNF(b, e.X, precPostfix)
+			b.WriteString(e.Op.String())
+		}
+	case *ast.BinaryExpr:
+		paren(b, prec > precSeq, func() {
+			writeEBNF(b, e.X, precPostfix)
+			if e.Op == token.REM { // R1 % R2 is R1 (R2 R1)*
+				b.WriteString(" (")
+				writeEBNF(b, e.Y, precPostfix)
+				b.WriteByte(' ')
+				writeEBNF(b, e.X, precPostfix)
+				b.WriteString(")*")
+			} else {
+				b.WriteString(" /* ++ */ ")
+				writeEBNF(b, e.Y, precPostfix)
+			}
+		})
+	}
+}
+
+func paren(b *bytes.Buffer, paren bool, f func()) {
+	if paren {
+		b.WriteByte('(')
+		f()
+		b.WriteByte(')')
+	} else {
+		f()
+	}
+}
+
+// quote quotes a literal the way of W3C EBNF, which has no escape sequences.
+func quote(text string) string {
+	if strings.Contains(text, `"`) {
+		return "'" + text + "'"
+	}
+	return `"` + text + `"`
+}
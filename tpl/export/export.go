@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package export exports tpl grammars to other notations: W3C EBNF, railroad
+// diagrams (SVG or HTML) and TextMate grammars. Imports and extensions of
+// rules, such as R |= ..., are resolved by merging a grammar with cl.Merge,
+// whose result can be exported.
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// checkFiles checks that a grammar has no imports or extensions of rules, such
+// as R |= ..., which are resolved by cl.Merge rather than this package.
+func checkFiles(files []*ast.File) error {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.ImportDecl:
+				return fmt.Errorf("tpl/export: import %s isn't resolved, merge the grammar by cl.Merge first", decl.Path.Value)
+			case *ast.Rule:
+				if decl.IsExtension() {
+					return fmt.Errorf("tpl/export: extension of rule `%s` isn't resolved, merge the grammar by cl.Merge first", decl.Name.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func rules(files []*ast.File) (ret []*ast.Rule) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if r, ok := decl.(*ast.Rule); ok {
+				ret = append(ret, r)
+			}
+		}
+	}
+	return
+}
+
+func walk(e ast.Expr, f func(e ast.Expr)) {
+	f(e)
+	switch e := e.(type) {
+	case *ast.Sequence:
+		for _, item := range e.Items {
+			walk(item, f)
+		}
+	case *ast.Choice:
+		for _, option := range e.Options {
+			walk(option, f)
+		}
+	case *ast.UnaryExpr:
+		walk(e.X, f)
+	case *ast.BinaryExpr:
+		walk(e.X, f)
+		walk(e.Y, f)
+	}
+}
+
+// literal returns the text of a literal, and whether it's a keyword or an
+// operator. It returns ok == false if lit is invalid or the empty string.
+func literal(lit *ast.BasicLit) (text string, keyword, ok bool) {
+	switch lit.Kind {
+	case token.CHAR:
+		v, multibyte, tail, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+		if err == nil && tail == "" && !multibyte && token.Token(v).Len() > 0 {
+			return string(v), false, true
+		}
+	case token.STRING:
+		v, err := strconv.Unquote(lit.Value)
+		if err != nil || v == "" {
+			break
+		}
+		if c := v[0]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' {
+			return v, true, true
+		}
+		tok, ok := token.Operator(v)
+		return v, false, ok && tok.Len() > 0
+	}
+	return
+}
+
+// Keywords returns the keywords of a grammar, that is, the quoted IDENT
+// literals such as "if", in alphabetical order. Imports of the grammar aren't
+// resolved, unless it's merged by cl.Merge.
+func Keywords(files ...*ast.File) []string {
+	ret, _ := literals(files)
+	return ret
+}
+
+// Operators returns the operators of a grammar, such as "+" and "<=", with the
+// longest ones first. Imports of the grammar aren't resolved, unless it's
+// merged by cl.Merge.
+func Operators(files ...*ast.File) []string {
+	_, ret := literals(files)
+	return ret
+}
+
+func literals(files []*ast.File) (keywords, operators []string) {
+	seen := make(map[string]bool)
+	for _, r := range rules(files) {
+		walk(r.Expr, func(e ast.Expr) {
+			if lit, ok := e.(*ast.BasicLit); ok {
+				if text, keyword, ok := literal(lit); ok && !seen[text] {
+					seen[text] = true
+					if keyword {
+						keywords = append(keywords, text)
+					} else {
+						operators = append(operators, text)
+					}
+				}
+			}
+		})
+	}
+	sort.Strings(keywords)
+	sort.Slice(operators, func(i, j int) bool {
+		a, b := operators[i], operators[j]
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	})
+	return
+}
+
+// tokenClasses returns the token classes, such as IDENT and INT, referred by
+// a grammar.
+func tokenClasses(files []*ast.File) map[string]bool {
+	all := rules(files)
+	names := ruleNames(all)
+	ret := make(map[string]bool)
+	for _, r := range all {
+		walk(r.Expr, func(e ast.Expr) {
+			if ident, ok := e.(*ast.Ident); ok && !names[ident.Name] {
+				ret[ident.Name] = true
+			}
+		})
+	}
+	return ret
+}
+
+func ruleNames(rules []*ast.Rule) map[string]bool {
+	ret := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		ret[r.Name.Name] = true
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package export_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/export"
+	"github.com/goplus/gop/tpl/parser"
+	"github.com/goplus/gop/tpl/token"
+)
+
+const grammar = `
+stmt = "if" expr block ?("else" block) | IDENT "=" expr | "print" !"(" expr
+
+block = "{" *stmt "}"
+
+expr = term % ("+" | "-" | "<=")
+
+term = INT | QSTRING | IDENT ++ "!" | "(" expr ")" | ""
+`
+
+func parse(t *testing.T) *ast.File {
+	f, err := parser.ParseFile(token.NewFileSet(), "foo.tpl", grammar, nil)
+	if err != nil {
+		t.Fatal("ParseFile:", err)
+	}
+	return f
+}
+
+func TestLiterals(t *testing.T) {
+	f := parse(t)
+	if ret := export.Keywords(f); !reflect.DeepEqual(ret, []string{"else", "if", "print"}) {
+		t.Fatal("Keywords:", ret)
+	}
+	if ret := export.Operators(f); !reflect.DeepEqual(ret, []string{"<=", "!", "(", ")", "+", "-", "=", "{", "}"}) {
+		t.Fatal("Operators:", ret)
+	}
+}
+
+func TestEBNF(t *testing.T) {
+	var b strings.Builder
+	if err := export.EBNF(&b, parse(t)); err != nil {
+		t.Fatal("EBNF:", err)
+	}
+	if ret := b.String(); ret != `stmt  ::= "if" expr block ("else" block)?
+        | IDENT "=" expr
+        | "print" /* !"(" */ expr
+block ::= "{" stmt* "}"
+expr  ::= term (("+" | "-" | "<=") term)*
+term  ::= INT
+        | QSTRING
+        | IDENT /* ++ */ "!"
+        | "(" expr ")"
+        | /* empty */
+
+/* token classes: IDENT INT QSTRING */
+` {
+		t.Fatal("EBNF:", ret)
+	}
+}
+
+// checkXML checks that src is well-formed, and returns the number of elements
+// of each name.
+func checkXML(t *testing.T, src string) map[string]int {
+	ret := make(map[string]int)
+	dec := xml.NewDecoder(strings.NewReader(src))
+	dec.Strict = false
+	dec.AutoClose = []string{"meta"}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return ret
+		}
+		if err != nil {
+			t.Fatal("xml:", err)
+		}
+		if e, ok := tok.(xml.StartElement); ok {
+			ret[e.Name.Local]++
+		}
+	}
+}
+
+func TestRailroad(t *testing.T) {
+	var b strings.Builder
+	if err := export.SVG(&b, parse(t)); err != nil {
+		t.Fatal("SVG:", err)
+	}
+	svg := checkXML(t, b.String())
+	if svg["svg"] != 1 || svg["rect"] != 26 || svg["a"] != 0 {
+		t.Fatal("SVG:", svg)
+	}
+	b.Reset()
+	if err := export.HTML(&b, "foo & bar", parse(t)); err != nil {
+		t.Fatal("HTML:", err)
+	}
+	page := checkXML(t, b.String())
+	if page["svg"] != 4 || page["h2"] != 4 || page["rect"] != 26 || page["a"] != 8 {
+		t.Fatal("HTML:", page)
+	}
+	if !strings.Contains(b.String(), "<title>foo &amp; bar</title>") {
+		t.Fatal("HTML: no title")
+	}
+}
+
+func TestTextMate(t *testing.T) {
+	var b strings.Builder
+	if err := export.TextMate(&b, &export.TextMateConfig{Name: "foo", FileTypes: []string{"foo"}}, parse(t)); err != nil {
+		t.Fatal("TextMate:", err)
+	}
+	var ret struct {
+		ScopeName  string
+		Patterns   []struct{ Include string }
+		Repository map[string]struct {
+			Patterns []struct{ Name, Match, Begin string }
+		}
+	}
+	if err := json.Unmarshal([]byte(b.String()), &ret); err != nil {
+		t.Fatal("json.Unmarshal:", err)
+	}
+	if ret.ScopeName != "source.foo" || len(ret.Patterns) != 5 {
+		t.Fatal("TextMate:", ret)
+	}
+	if p := ret.Repository["keywords"].Patterns[0]; p.Match != `\b(?:else|if|print)\b` || p.Name != "keyword.control.foo" {
+		t.Fatal("TextMate keywords:", p)
+	}
+	if p := ret.Repository["operators"].Patterns[0]; p.Match != `<=|!|\(|\)|\+|-|=|\{|\}` {
+		t.Fatal("TextMate operators:", p)
+	}
+	if strs := ret.Repository["strings"].Patterns; len(strs) != 1 || strs[0].Begin != `"` {
+		t.Fatal("TextMate strings:", strs)
+	}
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(dir+"/expr.tpl", []byte(`
+expr = operand % "+"
+
+operand = INT | "(" expr ")"
+
+unused = INT
+
+NUMBER = /[0-9]+/
+`), 0644)
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, dir+"/foo.tpl", `
+import e "expr.tpl"
+
+doc = e.expr ";" stmt
+
+e.operand |= IDENT
+
+stmt = "print" e.NUMBER
+`, nil)
+	if err != nil {
+		t.Fatal("ParseFile:", err)
+	}
+	if err = export.EBNF(io.Discard, f); err == nil ||
+		err.Error() != `tpl/export: import "expr.tpl" isn't resolved, merge the grammar by cl.Merge first` {
+		t.Fatal("EBNF:", err)
+	}
+	quiet := &cl.Config{
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+	}
+	merged, _, err := cl.Merge(quiet, fset, f)
+	if err != nil {
+		t.Fatal("Merge:", err)
+	}
+	var b strings.Builder
+	if err = export.EBNF(&b, merged); err != nil {
+		t.Fatal("EBNF:", err)
+	}
+	if ret := b.String(); ret != `doc       ::= e.expr ";" stmt
+e.operand ::= (INT | "(" e.expr ")")
+            | IDENT
+stmt      ::= "print" e.NUMBER
+e.expr    ::= e.operand ("+" e.operand)*
+e.NUMBER  ::= /[0-9]+/
+
+/* token classes: IDENT INT */
+` {
+		t.Fatal("EBNF:", ret)
+	}
+	if err = export.SVG(io.Discard, merged); err != nil {
+		t.Fatal("SVG:", err)
+	}
+}
@@ -0,0 +1,369 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"unicode/utf8"
+
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/token"
+)
+
+const (
+	arcR    = 10 // radius of arcs
+	hGap    = 10 // horizontal gap between items of a sequence
+	vGap    = 10 // vertical gap between options of a choice
+	boxH    = 11 // half height of boxes
+	charW   = 9  // width of a character in boxes
+	padding = 10 // padding of diagrams and lookahead groups
+)
+
+const style = `svg.railroad path { stroke-width: 2; stroke: #333; fill: none; }
+svg.railroad rect { stroke-width: 2; stroke: #333; fill: #ffc; }
+svg.railroad rect.nonterminal { fill: #cdf; }
+svg.railroad rect.token { fill: #dfd; }
+svg.railroad rect.group { stroke-width: 1; stroke-dasharray: 4; fill: none; }
+svg.railroad text { font: 14px monospace; text-anchor: middle; }
+svg.railroad text.label { font-size: 12px; text-anchor: start; }
+svg.railroad text.rule { font-size: 16px; font-weight: bold; text-anchor: start; }
+`
+
+// HTML writes the railroad diagrams of the rules of a grammar as an HTML page.
+// References to rules are linked to their diagrams.
+func HTML(w io.Writer, title string, files ...*ast.File) error {
+	if err := checkFiles(files); err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	title = html.EscapeString(title)
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n<h1>%s</h1>\n", title, style, title)
+	all := rules(files)
+	names := ruleNames(all)
+	for _, r := range all {
+		name := html.EscapeString(r.Name.Name)
+		fmt.Fprintf(&b, "<h2 id=\"%s\">%s</h2>\n", name, name)
+		d := newDiagram(r.Expr, names)
+		width, up, down := d.size()
+		s := &svg{&b, true}
+		fmt.Fprintf(&b, "<svg class=\"railroad\" xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\">\n", width+4*padding, up+down+2*padding)
+		s.rule(d, padding, up+padding)
+		b.WriteString("</svg>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// SVG writes the railroad diagrams of the rules of a grammar as an SVG image,
+// where the diagrams are stacked vertically.
+func SVG(w io.Writer, files ...*ast.File) error {
+	if err := checkFiles(files); err != nil {
+		return err
+	}
+	const title = 20 // height of rule names
+	var body bytes.Buffer
+	all := rules(files)
+	names := ruleNames(all)
+	width, height := 0, 0
+	for _, r := range all {
+		d := newDiagram(r.Expr, names)
+		dw, up, down := d.size()
+		fmt.Fprintf(&body, "<text class=\"rule\" x=\"%d\" y=\"%d\">%s</text>\n", padding, height+title, html.EscapeString(r.Name.Name))
+		height += title
+		(&svg{&body, false}).rule(d, padding, height+up+padding)
+		height += up + down + 2*padding
+		if dw += 4 * padding; dw > width {
+			width = dw
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<svg class=\"railroad\" xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\">\n<style>\n%s</style>\n", width, height, style)
+	b.Write(body.Bytes())
+	b.WriteString("</svg>\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// -----------------------------------------------------------------------------
+
+// diagram is a railroad diagram. It has a main line where it's entered from the
+// left and exited from the right, and up and down are its heights above and
+// below the main line.
+type diagram interface {
+	size() (width, up, down int)
+	draw(s *svg, x, y int) // (x, y) is the entry point
+}
+
+func newDiagram(e ast.Expr, rules map[string]bool) diagram {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if rules[e.Name] {
+			return &box{e.Name, "nonterminal"}
+		}
+		return &box{e.Name, "token"}
+	case *ast.BasicLit:
+		if text, _, ok := literal(e); ok {
+			return &box{text, "terminal"}
+		}
+		if e.Value == `""` || e.Value == "``" {
+			return skip{}
+		}
+		return &box{e.Value, "terminal"}
+	case *ast.Sequence:
+		items := make([]diagram, len(e.Items))
+		for i, item := range e.Items {
+			items[i] = newDiagram(item, rules)
+		}
+		return &sequence{items, hGap}
+	case *ast.Choice:
+		options := make([]diagram, len(e.Options))
+		for i, option := range e.Options {
+			options[i] = newDiagram(option, rules)
+		}
+		return &choice{options}
+	case *ast.UnaryExpr:
+		x := newDiagram(e.X, rules)
+		switch e.Op {
+		case token.QUESTION:
+			return &choice{[]diagram{x, skip{}}}
+		case token.MUL:
+			return &choice{[]diagram{&loop{x, skip{}}, skip{}}}
+		case token.ADD:
+			return &loop{x, skip{}}
+		default: // &R, !R
+			return &group{x, e.Op.String()}
+		}
+	case *ast.BinaryExpr:
+		x, y := newDiagram(e.X, rules), newDiagram(e.Y, rules)
+		if e.Op == token.REM {
+			return &loop{x, y}
+		}
+		return &sequence{[]diagram{x, y}, 0}
+	}
+	return skip{}
+}
+
+type svg struct {
+	b     *bytes.Buffer
+	links bool // link references to rules
+}
+
+func (s *svg) path(format string, args ...any) {
+	fmt.Fprintf(s.b, "<path d=\"%s\"/>\n", fmt.Sprintf(format, args...))
+}
+
+func (s *svg) line(x1, x2, y int) {
+	if x1 != x2 {
+		s.path("M%d %dH%d", x1, y, x2)
+	}
+}
+
+// rule draws a diagram of a rule, with its start and end marks.
+func (s *svg) rule(d diagram, x, y int) {
+	width, _, _ := d.size()
+	s.path("M%d %dv16m0 -8h%d", x, y-8, padding)
+	d.draw(s, x+padding, y)
+	x += padding + width
+	s.path("M%d %dh%dm0 -8v16", x, y, padding)
+}
+
+type skip struct{}
+
+func (skip) size() (width, up, down int) { return 0, 0, 0 }
+func (skip) draw(s *svg, x, y int)       {}
+
+type box struct {
+	text  string
+	class string // terminal, nonterminal or token
+}
+
+func (p *box) size() (width, up, down int) {
+	return utf8.RuneCountInString(p.text)*charW + 2*padding, boxH, boxH
+}
+
+func (p *box) draw(s *svg, x, y int) {
+	width, _, _ := p.size()
+	rx := 0
+	if p.class == "terminal" {
+		rx = boxH
+	}
+	link := s.links && p.class == "nonterminal"
+	text := html.EscapeString(p.text)
+	if link {
+		fmt.Fprintf(s.b, "<a href=\"#%s\">\n", text)
+	}
+	fmt.Fprintf(s.b, "<rect class=\"%s\" x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"%d\"/>\n",
+		p.class, x, y-boxH, width, 2*boxH, rx)
+	fmt.Fprintf(s.b, "<text x=\"%d\" y=\"%d\">%s</text>\n", x+width/2, y+5, text)
+	if link {
+		s.b.WriteString("</a>\n")
+	}
+}
+
+type sequence struct {
+	items []diagram
+	gap   int
+}
+
+func (p *sequence) size() (width, up, down int) {
+	for i, item := range p.items {
+		w, u, d := item.size()
+		if i > 0 {
+			width += p.gap
+		}
+		width += w
+		up, down = maxInt(up, u), maxInt(down, d)
+	}
+	return
+}
+
+func (p *sequence) draw(s *svg, x, y int) {
+	for i, item := range p.items {
+		if i > 0 {
+			s.line(x, x+p.gap, y)
+			x += p.gap
+		}
+		item.draw(s, x, y)
+		w, _, _ := item.size()
+		x += w
+	}
+}
+
+// choice draws its first option on the main line, and the others below it.
+type choice struct {
+	options []diagram
+}
+
+// offsets returns the vertical offsets of options from the main line.
+func (p *choice) offsets() []int {
+	dys := make([]int, len(p.options))
+	_, _, prevDown := p.options[0].size()
+	for i := 1; i < len(p.options); i++ {
+		_, up, down := p.options[i].size()
+		dys[i] = maxInt(dys[i-1]+prevDown+vGap+up, dys[i-1]+2*arcR)
+		prevDown = down
+	}
+	return dys
+}
+
+func (p *choice) size() (width, up, down int) {
+	dys := p.offsets()
+	for i, option := range p.options {
+		w, u, d := option.size()
+		width = maxInt(width, w)
+		if i == 0 {
+			up = u
+		}
+		down = maxInt(down, dys[i]+d)
+	}
+	return width + 4*arcR, up, down
+}
+
+func (p *choice) draw(s *svg, x, y int) {
+	width, _, _ := p.size()
+	inner := width - 4*arcR
+	dys := p.offsets()
+	for i, option := range p.options {
+		w, _, _ := option.size()
+		dy := dys[i]
+		if i == 0 {
+			s.line(x, x+2*arcR, y)
+		} else {
+			s.path("M%d %dA%d %d 0 0 1 %d %dV%dA%d %d 0 0 0 %d %d",
+				x, y, arcR, arcR, x+arcR, y+arcR, y+dy-arcR, arcR, arcR, x+2*arcR, y+dy)
+		}
+		option.draw(s, x+2*arcR, y+dy)
+		end := x + 2*arcR + inner
+		s.line(x+2*arcR+w, end, y+dy)
+		if i == 0 {
+			s.line(end, end+2*arcR, y)
+		} else {
+			s.path("M%d %dA%d %d 0 0 0 %d %dV%dA%d %d 0 0 1 %d %d",
+				end, y+dy, arcR, arcR, end+arcR, y+dy-arcR, y+arcR, arcR, arcR, end+2*arcR, y)
+		}
+	}
+}
+
+// loop draws item on the main line, and sep on the way back below it.
+type loop struct {
+	item, sep diagram
+}
+
+func (p *loop) offset() int {
+	_, _, down := p.item.size()
+	_, up, _ := p.sep.size()
+	return maxInt(down+vGap+up, 2*arcR)
+}
+
+func (p *loop) size() (width, up, down int) {
+	iw, iu, _ := p.item.size()
+	sw, _, sd := p.sep.size()
+	return maxInt(iw, sw) + 2*arcR, iu, p.offset() + sd
+}
+
+func (p *loop) draw(s *svg, x, y int) {
+	width, _, _ := p.size()
+	iw, _, _ := p.item.size()
+	sw, _, _ := p.sep.size()
+	inner := width - 2*arcR
+	dy := p.offset()
+	s.line(x, x+arcR, y)
+	p.item.draw(s, x+arcR, y)
+	s.line(x+arcR+iw, x+width, y)
+	right := x + arcR + inner
+	s.path("M%d %dA%d %d 0 0 1 %d %dV%dA%d %d 0 0 1 %d %d",
+		right, y, arcR, arcR, right+arcR, y+arcR, y+dy-arcR, arcR, arcR, right, y+dy)
+	s.line(x+arcR+sw, right, y+dy)
+	p.sep.draw(s, x+arcR, y+dy)
+	s.path("M%d %dA%d %d 0 0 1 %d %dV%dA%d %d 0 0 1 %d %d",
+		x+arcR, y+dy, arcR, arcR, x, y+dy-arcR, y+arcR, arcR, arcR, x+arcR, y)
+}
+
+// group draws item in a dashed box with a label, for lookahead operators.
+type group struct {
+	item  diagram
+	label string
+}
+
+const labelH = 14 // height of labels of groups
+
+func (p *group) size() (width, up, down int) {
+	w, u, d := p.item.size()
+	return w + 2*padding, u + padding + labelH, d + padding
+}
+
+func (p *group) draw(s *svg, x, y int) {
+	width, up, down := p.size()
+	w, iu, _ := p.item.size()
+	fmt.Fprintf(s.b, "<rect class=\"group\" x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\"/>\n",
+		x, y-up+labelH, width, up+down-labelH)
+	fmt.Fprintf(s.b, "<text class=\"label\" x=\"%d\" y=\"%d\">%s</text>\n", x, y-iu-padding-4, html.EscapeString(p.label))
+	s.line(x, x+padding, y)
+	p.item.draw(s, x+padding, y)
+	s.line(x+padding+w, x+width, y)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/goplus/gop/tpl/ast"
+)
+
+// TextMateConfig configures the TextMate grammar to export.
+type TextMateConfig struct {
+	Name      string   // name of the language
+	ScopeName string   // source.<Name> by default
+	FileTypes []string // file extensions, without the leading dot
+}
+
+type tmPattern struct {
+	Name     string      `json:"name,omitempty"`
+	Match    string      `json:"match,omitempty"`
+	Begin    string      `json:"begin,omitempty"`
+	End      string      `json:"end,omitempty"`
+	Include  string      `json:"include,omitempty"`
+	Patterns []tmPattern `json:"patterns,omitempty"`
+}
+
+type tmGrammar struct {
+	Name       string               `json:"name"`
+	ScopeName  string               `json:"scopeName"`
+	FileTypes  []string             `json:"fileTypes,omitempty"`
+	Patterns   []tmPattern          `json:"patterns"`
+	Repository map[string]tmPattern `json:"repository"`
+}
+
+// TextMate writes a TextMate grammar (.tmLanguage.json) of a grammar, for
+// token-level highlighting: comments, keywords, operators and the literals
+// of token classes referred by the grammar, such as STRING and INT. The
+// lexical syntax follows package tpl/scanner.
+func TextMate(w io.Writer, conf *TextMateConfig, files ...*ast.File) error {
+	if err := checkFiles(files); err != nil {
+		return err
+	}
+	name := conf.Name
+	scope := conf.ScopeName
+	if scope == "" {
+		scope = "source." + name
+	}
+	suffix := "." + name
+	g := &tmGrammar{
+		Name: name, ScopeName: scope, FileTypes: conf.FileTypes,
+		Repository: make(map[string]tmPattern),
+	}
+	add := func(key string, patterns ...tmPattern) {
+		g.Patterns = append(g.Patterns, tmPattern{Include: "#" + key})
+		g.Repository[key] = tmPattern{Patterns: patterns}
+	}
+	add("comments",
+		tmPattern{Name: "comment.line.double-slash" + suffix, Match: `//.*$`},
+		tmPattern{Name: "comment.line.number-sign" + suffix, Match: `#.*$`},
+		tmPattern{Name: "comment.block" + suffix, Begin: `/\*`, End: `\*/`},
+	)
+	classes := tokenClasses(files)
+	var strs []tmPattern
+	escape := []tmPattern{{Name: "constant.character.escape" + suffix, Match: `\\.`}}
+	if classes["STRING"] || classes["QSTRING"] {
+		strs = append(strs, tmPattern{Name: "string.quoted.double" + suffix, Begin: `"`, End: `"`, Patterns: escape})
+	}
+	if classes["STRING"] || classes["RAWSTRING"] {
+		strs = append(strs, tmPattern{Name: "string.quoted.other" + suffix, Begin: "`", End: "`"})
+	}
+	if classes["CHAR"] {
+		strs = append(strs, tmPattern{Name: "string.quoted.single" + suffix, Begin: `'`, End: `'`, Patterns: escape})
+	}
+	if strs != nil {
+		add("strings", strs...)
+	}
+	keywords, operators := literals(files)
+	if keywords != nil {
+		add("keywords", tmPattern{Name: "keyword.control" + suffix, Match: `\b(?:` + alternation(keywords) + `)\b`})
+	}
+	if classes["INT"] || classes["FLOAT"] || classes["IMAG"] || classes["RAT"] || classes["UNIT"] {
+		add("numbers", tmPattern{
+			Name:  "constant.numeric" + suffix,
+			Match: `\b(?:0[xX][0-9a-fA-F_]+|0[bB][01_]+|0[oO]?[0-7_]+|(?:[0-9][0-9_]*)?\.?[0-9][0-9_]*(?:[eE][+-]?[0-9]+)?)[a-zA-Z]*\b`,
+		})
+	}
+	if operators != nil {
+		add("operators", tmPattern{Name: "keyword.operator" + suffix, Match: alternation(operators)})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	return enc.Encode(g)
+}
+
+func alternation(list []string) string {
+	quoted := make([]string, len(list))
+	for i, s := range list {
+		quoted[i] = regexp.QuoteMeta(s)
+	}
+	return strings.Join(quoted, "|")
+}
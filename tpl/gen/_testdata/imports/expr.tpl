@@ -0,0 +1,5 @@
+expr = operand % "+"
+
+operand = NUMBER | "(" expr ")"
+
+NUMBER = /[0-9]+/
@@ -0,0 +1,11 @@
+import e "expr.tpl"
+
+doc = *(stmt ";")
+
+stmt = NAME "=" e.expr
+
+e.operand |= NAME
+
+e_expr = "(" ")"
+
+NAME = /[a-z]+/
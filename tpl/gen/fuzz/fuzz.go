@@ -0,0 +1,641 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fuzz generates random sentences of a compiled TPL grammar, for
+// property-based testing and fuzzing of parsers.
+//
+// A Generator walks the matchers of a grammar (see [cl.Result]) from its first
+// rule, and makes every decision (which option of a choice, how many times of
+// a repetition, etc.) by a [Source]. A Source can be a *rand.Rand, or the data
+// of Go's native fuzzing (see [Bytes] and [AddSeeds]), so a fuzzer mutates
+// sentences by mutating the decisions.
+//
+// Sentences are generated as of a context-free grammar: lookaheads (&R and !R)
+// are ignored, and the options of a choice are chosen regardless of their
+// order. So a sentence may be rejected by the grammar, eg. if an earlier option
+// of a choice matches a prefix of it, or a repetition consumes more than it's
+// generated for. To avoid them, the generator matches every sentence with the
+// grammar, and generates another one if it's rejected (see [Config.MaxRetry]).
+package fuzz
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrRejected is returned by Generate if MaxRetry sentences in a row are
+// rejected by the grammar (see [Config.MaxRetry]).
+var ErrRejected = errors.New("fuzz.Generate: all the sentences generated are rejected by the grammar")
+
+// Config configures a Generator.
+type Config struct {
+	// MaxDepth is the maximum nesting depth of rules (16 by default). Beyond
+	// it, the generator goes the shortest way: the option of a choice which
+	// ends with the least nested rules, the minimum count of a repetition, and
+	// no optional items.
+	MaxDepth int
+
+	// MaxRepeat is the maximum count of a repetition (3 by default). It also
+	// bounds the repetitions of regular expressions of token rules.
+	MaxRepeat int
+
+	// MaxTokens is the number of tokens (or characters in the scannerless
+	// mode) after which the generator goes the shortest way (256 by default).
+	MaxTokens int
+
+	// MaxRetry is the maximum number of times to generate another sentence
+	// if one is rejected by the grammar (16 by default). Generate fails with
+	// ErrRejected if all of them are rejected. A negative MaxRetry disables
+	// matching sentences with the grammar.
+	MaxRetry int
+
+	// Weights are the weighting hints of choices: key => the weights of its
+	// options. The key of the first choice of a rule, in the order they are
+	// written, is the rule name, and the keys of the others are "Rule#2",
+	// "Rule#3" and so on. An option of weight 0 is never chosen unless it's
+	// the shortest way. The options of other choices are equally weighted.
+	Weights map[string][]int
+}
+
+// A Source makes the decisions of generating a sentence.
+type Source interface {
+	// Intn returns a number in [0, n).
+	Intn(n int) int
+}
+
+// Bytes returns a Source which makes decisions by consuming data, for Go's
+// native fuzzing. It returns 0 when data runs out.
+func Bytes(data []byte) Source {
+	return &bytesSource{data}
+}
+
+type bytesSource struct {
+	data []byte
+}
+
+func (p *bytesSource) Intn(n int) int {
+	var v int
+	for max := n - 1; max > 0 && len(p.data) > 0; max >>= 8 {
+		v = v<<8 | int(p.data[0])
+		p.data = p.data[1:]
+	}
+	return v % n
+}
+
+// AddSeeds adds n random seeds, and an empty one, to the seed corpus of a
+// fuzz test whose fuzz target takes a []byte of decisions (see [Bytes]). The
+// seeds are the same every time.
+func AddSeeds(f *testing.F, n int) {
+	f.Add([]byte{})
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < n; i++ {
+		data := make([]byte, 16+r.Intn(112))
+		r.Read(data)
+		f.Add(data)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+const infinite = 1 << 30
+
+// A Generator generates random sentences of a grammar.
+type Generator struct {
+	conf        Config
+	scannerless bool
+	height      map[matcher.Matcher]int   // least nested rules to end a matcher
+	weights     map[matcher.Matcher][]int // *matcher.Choices => weights
+	tokens      map[token.Token]*syntax.Regexp
+	regexps     map[matcher.Matcher]*syntax.Regexp
+	terms       []matcher.Matcher // terminals, in the order they are found
+	doc         matcher.Matcher
+	grammar     tpl.Compiler // to match the generated sentences
+}
+
+// New creates a Generator of a compiled grammar, whose first rule (res.Doc)
+// is the start. It returns an error if a weighting hint doesn't match the
+// grammar, or a rule reachable from the first rule can never end.
+func New(res cl.Result, conf *Config) (*Generator, error) {
+	if res.Doc == nil {
+		return nil, errors.New("fuzz.New: no rules")
+	}
+	p := &Generator{
+		scannerless: res.Scannerless,
+		height:      make(map[matcher.Matcher]int),
+		weights:     make(map[matcher.Matcher][]int),
+		tokens:      make(map[token.Token]*syntax.Regexp),
+		regexps:     make(map[matcher.Matcher]*syntax.Regexp),
+		doc:         res.Doc,
+		grammar:     tpl.Compiler{Result: res},
+	}
+	if conf != nil {
+		p.conf = *conf
+	}
+	if p.conf.MaxDepth <= 0 {
+		p.conf.MaxDepth = 16
+	}
+	if p.conf.MaxRepeat <= 0 {
+		p.conf.MaxRepeat = 3
+	}
+	if p.conf.MaxTokens <= 0 {
+		p.conf.MaxTokens = 256
+	}
+	if p.conf.MaxRetry == 0 {
+		p.conf.MaxRetry = 16
+	}
+	for _, t := range res.Tokens {
+		re, err := syntax.Parse(t.Regexp.String(), syntax.Perl)
+		if err != nil {
+			return nil, fmt.Errorf("fuzz.New: token %s: %v", t.Name, err)
+		}
+		p.tokens[t.Tok] = re.Simplify()
+	}
+
+	// collect the rules and terminals reachable from the first rule
+	var vars []*matcher.Var
+	seen := make(map[matcher.Matcher]bool)
+	var walk func(m matcher.Matcher) error
+	walk = func(m matcher.Matcher) error {
+		if seen[m] {
+			return nil
+		}
+		seen[m] = true
+		d := matcher.Describe(m)
+		switch d.Kind {
+		case matcher.KindUnknown:
+			return fmt.Errorf("fuzz.New: unknown matcher %T", m)
+		case matcher.KindVar:
+			vars = append(vars, m.(*matcher.Var))
+		case matcher.KindRegexp:
+			re, err := syntax.Parse(d.Regexp.String(), syntax.Perl)
+			if err != nil {
+				return fmt.Errorf("fuzz.New: token %s: %v", d.Name, err)
+			}
+			p.regexps[m] = re.Simplify()
+			fallthrough
+		case matcher.KindToken, matcher.KindString, matcher.KindLiteral, matcher.KindText:
+			if d.Tok != token.EOF {
+				p.terms = append(p.terms, m)
+			}
+		}
+		for _, item := range d.Items {
+			if err := walk(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(res.Doc); err != nil {
+		return nil, err
+	}
+
+	// compute the heights by iterating to a fixpoint
+	for _, v := range vars {
+		p.height[v] = infinite
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, v := range vars {
+			if h := p.heightOf(v.Elem) + 1; h < p.height[v] {
+				p.height[v], changed = h, true
+			}
+		}
+	}
+	for _, v := range vars {
+		if p.height[v] >= infinite {
+			return nil, fmt.Errorf("fuzz.New: rule %s can never end", v.Name)
+		}
+	}
+
+	if err := p.initWeights(res.Rules); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// heightOf returns the least nesting depth of rules to end a matcher, and
+// records it.
+func (p *Generator) heightOf(m matcher.Matcher) (h int) {
+	d := matcher.Describe(m)
+	switch d.Kind {
+	case matcher.KindVar:
+		return p.height[m]
+	case matcher.KindChoice:
+		h = infinite
+		for _, item := range d.Items {
+			if v := p.heightOf(item); v < h {
+				h = v
+			}
+		}
+	case matcher.KindSequence, matcher.KindAdjoin, matcher.KindRepeat1:
+		for _, item := range d.Items {
+			if v := p.heightOf(item); v > h {
+				h = v
+			}
+		}
+	case matcher.KindRepeat0, matcher.KindRepeat01:
+		p.heightOf(d.Items[0])
+	}
+	p.height[m] = h
+	return
+}
+
+func (p *Generator) initWeights(rules map[string]*matcher.Var) error {
+	if len(p.conf.Weights) == 0 {
+		return nil
+	}
+	choices := make(map[string]*matcher.Choices)
+	for name, v := range rules {
+		n := 0
+		var walk func(m matcher.Matcher)
+		walk = func(m matcher.Matcher) {
+			d := matcher.Describe(m)
+			if d.Kind == matcher.KindVar {
+				return
+			}
+			if d.Kind == matcher.KindChoice {
+				n++
+				key := name
+				if n > 1 {
+					key += "#" + strconv.Itoa(n)
+				}
+				choices[key] = m.(*matcher.Choices)
+			}
+			for _, item := range d.Items {
+				walk(item)
+			}
+		}
+		walk(v.Elem)
+	}
+	keys := make([]string, 0, len(p.conf.Weights))
+	for key := range p.conf.Weights {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		weights := p.conf.Weights[key]
+		c, ok := choices[key]
+		if !ok {
+			return fmt.Errorf("fuzz.New: weights of %s: no such choice", key)
+		}
+		if n := len(matcher.Describe(c).Items); len(weights) != n {
+			return fmt.Errorf("fuzz.New: weights of %s: %d weights for %d options", key, len(weights), n)
+		}
+		for _, w := range weights {
+			if w < 0 {
+				return fmt.Errorf("fuzz.New: weights of %s: negative weight %d", key, w)
+			}
+		}
+		p.weights[c] = weights
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+
+// Generate generates a random sentence of the grammar, which is accepted by
+// the grammar unless matching is disabled by a negative MaxRetry. It returns
+// ErrRejected if MaxRetry sentences in a row are rejected (see
+// [Config.MaxRetry]).
+func (p *Generator) Generate(src Source) ([]byte, error) {
+	pieces, err := p.generate(src)
+	if err != nil {
+		return nil, err
+	}
+	return p.join(pieces), nil
+}
+
+// NearMiss generates a random sentence of the grammar like Generate, and then
+// mutates one of its tokens (or characters in the scannerless mode) by
+// deleting, duplicating or swapping it with the next one, or inserts a random
+// terminal of the grammar. The result is usually, but not always, an invalid
+// sentence. Since it's mutated anyway, the sentence is used even if it's
+// rejected by the grammar after MaxRetry retries.
+func (p *Generator) NearMiss(src Source) []byte {
+	pieces, _ := p.generate(src)
+	n := len(pieces)
+	op := src.Intn(4)
+	if n == 0 || n == 1 && op == 2 {
+		op = 3
+	}
+	switch op {
+	case 0: // delete
+		i := src.Intn(n)
+		pieces = append(pieces[:i], pieces[i+1:]...)
+	case 1: // duplicate
+		i := src.Intn(n)
+		pieces = append(pieces[:i+1], pieces[i:]...)
+	case 2: // swap
+		i := src.Intn(n - 1)
+		pieces[i].text, pieces[i+1].text = pieces[i+1].text, pieces[i].text
+	default: // insert
+		g := &generator{Generator: p, src: src}
+		if len(p.terms) > 0 {
+			g.gen(p.terms[src.Intn(len(p.terms))])
+		}
+		if len(g.out) > 0 {
+			i := src.Intn(n + 1)
+			pieces = append(pieces[:i], append(g.out[:1], pieces[i:]...)...)
+		}
+	}
+	return p.join(pieces)
+}
+
+// generate generates a sentence, and generates another one if it's rejected by
+// the grammar, for at most MaxRetry times. It returns the last sentence with
+// ErrRejected if all of them are rejected. If the data of a Bytes source runs
+// out, the retries are made by a pseudo-random source of a fixed seed, which
+// keeps the result deterministic.
+func (p *Generator) generate(src Source) ([]piece, error) {
+	for i := 0; ; i++ {
+		g := &generator{Generator: p, src: src}
+		g.gen(p.doc)
+		if p.conf.MaxRetry < 0 {
+			return g.out, nil
+		}
+		if _, err := p.grammar.Parse("", p.join(g.out), &tpl.Config{Memo: true}); err == nil {
+			return g.out, nil
+		}
+		if i >= p.conf.MaxRetry {
+			return g.out, ErrRejected
+		}
+		if bs, ok := src.(*bytesSource); ok && len(bs.data) == 0 {
+			src = rand.New(rand.NewSource(1)) // otherwise all the retries are the same
+		}
+	}
+}
+
+// join joins the pieces with separators. The tokens are separated by spaces,
+// or newlines after semicolons, unless they are adjoined.
+func (p *Generator) join(pieces []piece) []byte {
+	var b []byte
+	for i, v := range pieces {
+		if i > 0 && !p.scannerless && !v.adjoin {
+			if pieces[i-1].text == ";" {
+				b = append(b, '\n')
+			} else {
+				b = append(b, ' ')
+			}
+		}
+		b = append(b, v.text...)
+	}
+	return b
+}
+
+// -----------------------------------------------------------------------------
+
+type piece struct {
+	text   string
+	adjoin bool // no separator before it
+}
+
+type generator struct {
+	*Generator
+	src    Source
+	out    []piece
+	depth  int
+	adjoin bool
+}
+
+// short reports whether to go the shortest way.
+func (p *generator) short() bool {
+	return p.depth >= p.conf.MaxDepth || len(p.out) >= p.conf.MaxTokens
+}
+
+func (p *generator) emit(text string) {
+	p.out = append(p.out, piece{text, p.adjoin})
+	p.adjoin = false
+}
+
+func (p *generator) repeat(min, max int) int {
+	if p.short() || max <= min {
+		return min
+	}
+	return min + p.src.Intn(max-min+1)
+}
+
+func (p *generator) gen(m matcher.Matcher) {
+	d := matcher.Describe(m)
+	switch d.Kind {
+	case matcher.KindVar:
+		p.depth++
+		p.gen(d.Items[0])
+		p.depth--
+	case matcher.KindSequence:
+		for _, item := range d.Items {
+			p.gen(item)
+		}
+	case matcher.KindChoice:
+		p.gen(d.Items[p.choose(m, d.Items)])
+	case matcher.KindRepeat0:
+		for n := p.repeat(0, p.conf.MaxRepeat); n > 0; n-- {
+			p.gen(d.Items[0])
+		}
+	case matcher.KindRepeat1:
+		for n := p.repeat(1, p.conf.MaxRepeat); n > 0; n-- {
+			p.gen(d.Items[0])
+		}
+	case matcher.KindRepeat01:
+		if p.repeat(0, 1) > 0 {
+			p.gen(d.Items[0])
+		}
+	case matcher.KindAdjoin:
+		p.gen(d.Items[0])
+		p.adjoin = true
+		p.gen(d.Items[1])
+		p.adjoin = false
+	case matcher.KindToken:
+		if re, ok := p.tokens[d.Tok]; ok {
+			p.emit(p.regexp(re))
+		} else {
+			p.emit(p.token(d.Tok))
+		}
+	case matcher.KindString:
+		p.emit(d.Lit + "abc" + d.Lit)
+	case matcher.KindLiteral, matcher.KindText:
+		p.emit(d.Lit)
+	case matcher.KindRegexp:
+		p.emit(p.regexp(p.regexps[m]))
+	}
+}
+
+// choose chooses an option of a choice.
+func (p *generator) choose(m matcher.Matcher, options []matcher.Matcher) int {
+	weights := p.weights[m]
+	if !p.short() {
+		if weights == nil {
+			return p.src.Intn(len(options))
+		}
+		total := 0
+		for _, w := range weights {
+			total += w
+		}
+		if total > 0 {
+			v := p.src.Intn(total)
+			for i, w := range weights {
+				if v < w {
+					return i
+				}
+				v -= w
+			}
+		}
+	}
+	// the shortest way: the first least high option, preferring the ones
+	// with positive weights.
+	ret, min := 0, infinite+1
+	for i, option := range options {
+		h := p.height[option] * 2
+		if weights != nil && weights[i] == 0 {
+			h++
+		}
+		if h < min {
+			ret, min = i, h
+		}
+	}
+	return ret
+}
+
+var letters = "abcdefghijklmnopqrstuvwxyz"
+
+// token generates the text of a builtin token.
+func (p *generator) token(tok token.Token) string {
+	switch tok {
+	case token.EOF:
+		return ""
+	case token.IDENT: // a letter and an optional digit, which isn't a keyword
+		i := p.src.Intn(len(letters))
+		name := letters[i : i+1]
+		if p.repeat(0, 1) > 0 {
+			name += strconv.Itoa(p.src.Intn(10))
+		}
+		return name
+	case token.INT:
+		return strconv.Itoa(p.src.Intn(1000))
+	case token.FLOAT:
+		return "1.5"
+	case token.IMAG:
+		return "2i"
+	case token.RAT:
+		return "3r"
+	case token.UNIT:
+		return "1s"
+	case token.CHAR:
+		i := p.src.Intn(len(letters))
+		return "'" + letters[i:i+1] + "'"
+	case token.STRING:
+		return `"abc"`
+	case token.COMMENT:
+		return "/* c */"
+	}
+	return tok.String()
+}
+
+// regexp generates a random text matching a regular expression.
+func (p *generator) regexp(re *syntax.Regexp) string {
+	var b strings.Builder
+	p.genRegexp(&b, re)
+	return b.String()
+}
+
+func (p *generator) genRegexp(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		b.WriteRune(p.charClass(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte(letters[p.src.Intn(len(letters))])
+	case syntax.OpCapture:
+		p.genRegexp(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			p.genRegexp(b, sub)
+		}
+	case syntax.OpAlternate:
+		p.genRegexp(b, re.Sub[p.src.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := 0, p.conf.MaxRepeat
+		switch re.Op {
+		case syntax.OpPlus:
+			min = 1
+		case syntax.OpQuest:
+			max = 1
+		case syntax.OpRepeat:
+			min, max = re.Min, re.Max
+			if max < 0 || max > min+p.conf.MaxRepeat {
+				max = min + p.conf.MaxRepeat
+			}
+		}
+		if min > max {
+			min = max
+		}
+		for n := p.repeat(min, max); n > 0; n-- {
+			p.genRegexp(b, re.Sub[0])
+		}
+	} // empty-width assertions and OpEmptyMatch generate nothing
+}
+
+// charClass chooses a character of a class, preferring printable ASCII
+// characters.
+func (p *generator) charClass(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < ' ' {
+			lo = ' '
+		}
+		if hi > '~' {
+			hi = '~'
+		}
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+	if len(printable) == 0 {
+		if len(ranges) == 0 {
+			return 'a'
+		}
+		return ranges[0]
+	}
+	total := 0
+	for i := 0; i < len(printable); i += 2 {
+		total += int(printable[i+1]-printable[i]) + 1
+	}
+	v := rune(p.src.Intn(total))
+	for i := 0; i < len(printable); i += 2 {
+		if n := printable[i+1] - printable[i] + 1; v >= n {
+			v -= n
+		} else {
+			return printable[i] + v
+		}
+	}
+	return printable[0]
+}
+
+// -----------------------------------------------------------------------------
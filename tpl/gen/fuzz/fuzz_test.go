@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fuzz_test
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/gen/fuzz"
+	"github.com/goplus/gop/tpl/token"
+)
+
+const calcGrammar = `
+doc = *stmt
+
+stmt = "let" IDENT "=" expr ";" | "print" expr % "," ";"
+
+expr = term % ("+" | "-")
+
+term = factor % ("*" | "/")
+
+factor = INT | FLOAT | IDENT | "(" expr ")" | "-" factor
+`
+
+func compile(t testing.TB, src string, scannerless bool) tpl.Compiler {
+	t.Helper()
+	conf := &cl.Config{
+		OnConflict:  func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+		Scannerless: scannerless,
+	}
+	c, err := tpl.FromFile(nil, "", src, conf)
+	if err != nil {
+		t.Fatal("tpl.FromFile:", err)
+	}
+	return c
+}
+
+func newGenerator(t testing.TB, c tpl.Compiler, conf *fuzz.Config) *fuzz.Generator {
+	t.Helper()
+	g, err := fuzz.New(c.Result, conf)
+	if err != nil {
+		t.Fatal("fuzz.New:", err)
+	}
+	return g
+}
+
+func generate(t testing.TB, g *fuzz.Generator, src fuzz.Source) []byte {
+	t.Helper()
+	ret, err := g.Generate(src)
+	if err != nil {
+		t.Fatal("Generate:", err)
+	}
+	return ret
+}
+
+func TestGenerate(t *testing.T) {
+	c := compile(t, calcGrammar, false)
+	g := newGenerator(t, c, nil)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		src := generate(t, g, r)
+		if _, err := c.Parse("", src, nil); err != nil {
+			t.Fatalf("Parse %q: %v", src, err)
+		}
+	}
+	for _, data := range [][]byte{nil, {1, 2, 3}, bytes.Repeat([]byte{0xff}, 100)} {
+		src := generate(t, g, fuzz.Bytes(data))
+		if _, err := c.Parse("", src, nil); err != nil {
+			t.Fatalf("Parse %q: %v", src, err)
+		}
+		if src2 := generate(t, g, fuzz.Bytes(data)); !bytes.Equal(src, src2) {
+			t.Fatalf("Generate isn't deterministic: %q != %q", src, src2)
+		}
+	}
+}
+
+func TestDepth(t *testing.T) {
+	c := compile(t, calcGrammar, false)
+	g := newGenerator(t, c, &fuzz.Config{MaxDepth: 3, MaxTokens: 10})
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		src := generate(t, g, r)
+		if _, err := c.Parse("", src, nil); err != nil {
+			t.Fatalf("Parse %q: %v", src, err)
+		}
+		if bytes.Contains(src, []byte("(")) {
+			t.Fatalf("Generate %q: nested deeper than MaxDepth", src)
+		}
+	}
+}
+
+func TestWeights(t *testing.T) {
+	c := compile(t, calcGrammar, false)
+	g := newGenerator(t, c, &fuzz.Config{
+		Weights: map[string][]int{
+			"stmt":   {0, 1},
+			"factor": {0, 0, 1, 0, 0},
+			"expr":   {1, 0},
+		},
+	})
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		src := string(generate(t, g, r))
+		for _, tok := range strings.Fields(src) {
+			if tok == "let" || tok == "-" || tok == "(" || tok[0] >= '0' && tok[0] <= '9' {
+				t.Fatalf("Generate %q: an option of weight 0 is chosen", src)
+			}
+		}
+	}
+
+	errs := []struct {
+		weights map[string][]int
+		err     string
+	}{
+		{map[string][]int{"unknown": {1}}, "fuzz.New: weights of unknown: no such choice"},
+		{map[string][]int{"expr#2": {1, 1}}, "fuzz.New: weights of expr#2: no such choice"},
+		{map[string][]int{"factor": {1, 1}}, "fuzz.New: weights of factor: 2 weights for 5 options"},
+		{map[string][]int{"term": {1, -1}}, "fuzz.New: weights of term: negative weight -1"},
+	}
+	for _, e := range errs {
+		if _, err := fuzz.New(c.Result, &fuzz.Config{Weights: e.weights}); err == nil || err.Error() != e.err {
+			t.Fatal("fuzz.New:", err)
+		}
+	}
+}
+
+func TestNeverEnd(t *testing.T) {
+	c := compile(t, `doc = *stmt; stmt = "x" stmt`, false)
+	if _, err := fuzz.New(c.Result, nil); err == nil || err.Error() != "fuzz.New: rule stmt can never end" {
+		t.Fatal("fuzz.New:", err)
+	}
+}
+
+func TestScannerless(t *testing.T) {
+	c := compile(t, `
+doc = +(NAME ?" " "=" ?" " (NUMBER | NAME) '\n')
+
+NAME = /[a-z][a-z0-9]*/
+
+NUMBER = /[0-9]+(\.[0-9]+)?/
+`, true)
+	g := newGenerator(t, c, nil)
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 100; i++ {
+		src := generate(t, g, r)
+		if _, err := c.Parse("", src, nil); err != nil {
+			t.Fatalf("Parse %q: %v", src, err)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	// "a" is chosen before "a" "b", and +"x" consumes all the "x", so only
+	// "a ;" is accepted.
+	c := compile(t, `doc = ("a" | "a" "b" | +"x" "x") ";"`, false)
+	g := newGenerator(t, c, nil)
+	r := rand.New(rand.NewSource(6))
+	for i := 0; i < 100; i++ {
+		src := generate(t, g, r)
+		if _, err := c.Parse("", src, nil); err != nil {
+			t.Fatalf("Parse %q: %v", src, err)
+		}
+	}
+
+	g = newGenerator(t, c, &fuzz.Config{MaxRetry: -1})
+	rejected := 0
+	for i := 0; i < 100; i++ {
+		if _, err := c.Parse("", generate(t, g, r), nil); err != nil {
+			rejected++
+		}
+	}
+	if rejected == 0 {
+		t.Fatal("Generate: no rejected sentences without retrying")
+	}
+
+	// the retries of a Bytes source whose data runs out
+	g = newGenerator(t, c, nil)
+	for _, data := range [][]byte{nil, {1}, {2}} {
+		src := generate(t, g, fuzz.Bytes(data))
+		if string(src) != "a ;" {
+			t.Fatalf("Generate %v: %q", data, src)
+		}
+	}
+	// +"x" consumes all the "x", so no sentence is accepted
+	c = compile(t, `doc = +"x" "x"`, false)
+	g = newGenerator(t, c, &fuzz.Config{MaxRetry: 4})
+	if src, err := g.Generate(r); err != fuzz.ErrRejected || src != nil {
+		t.Fatalf("Generate: %q, %v", src, err)
+	}
+}
+
+func TestNearMiss(t *testing.T) {
+	c := compile(t, calcGrammar, false)
+	g := newGenerator(t, c, nil)
+	r := rand.New(rand.NewSource(5))
+	invalid := 0
+	for i := 0; i < 100; i++ {
+		if _, err := c.Parse("", g.NearMiss(r), nil); err != nil {
+			invalid++
+		}
+	}
+	if invalid < 50 {
+		t.Fatal("NearMiss: too few invalid sentences:", invalid)
+	}
+}
+
+func FuzzCalc(f *testing.F) {
+	fuzz.AddSeeds(f, 16)
+	c := compile(f, calcGrammar, false)
+	g, err := fuzz.New(c.Result, nil)
+	if err != nil {
+		f.Fatal("fuzz.New:", err)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		src := generate(t, g, fuzz.Bytes(data))
+		if _, err := c.Parse("", src, nil); err != nil {
+			t.Fatalf("Parse %q: %v", src, err)
+		}
+	})
+}
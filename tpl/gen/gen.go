@@ -0,0 +1,556 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	goparser "go/parser"
+	goscanner "go/scanner"
+	gotoken "go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/gop/parser/iox"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/parser"
+	"github.com/goplus/gop/tpl/scanner"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// Config configures the generated parser.
+type Config struct {
+	// Package is the package name of the generated parser ("parser" by default).
+	Package string
+
+	// Imports are the packages referred by the actions: name => path. The
+	// packages tpl, tpl/token, tpl/variant and some standard packages (see
+	// [DefaultImports]) don't need to be specified.
+	Imports map[string]string
+
+	// Memo enables packrat memoization of rules without actions (see
+	// [github.com/goplus/gop/tpl.Config.Memo]).
+	Memo bool
+}
+
+// DefaultImports are the packages which can be referred by actions without
+// being specified in [Config.Imports].
+var DefaultImports = map[string]string{
+	"tpl":     "github.com/goplus/gop/tpl",
+	"token":   "github.com/goplus/gop/tpl/token",
+	"variant": "github.com/goplus/gop/tpl/variant",
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"math":    "math",
+	"strconv": "strconv",
+	"strings": "strings",
+}
+
+// runtimeImports are the packages used by the runtime of generated parsers.
+var runtimeImports = map[string]string{
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"iox":     "github.com/goplus/gop/parser/iox",
+	"matcher": "github.com/goplus/gop/tpl/matcher",
+	"scanner": "github.com/goplus/gop/tpl/scanner",
+	"token":   "github.com/goplus/gop/tpl/token",
+	"types":   "github.com/goplus/gop/tpl/types",
+}
+
+// action represents the `=> { ... }` action of a rule.
+type action struct {
+	pos, end token.Pos
+	bodyPos  token.Pos
+	body     string // { ... }
+}
+
+func (p *action) Pos() token.Pos { return p.pos }
+func (p *action) End() token.Pos { return p.end }
+
+func parseAction(file *token.File, src []byte, offset int) (ast.Node, scanner.ErrorList) {
+	body := string(src[offset+2:]) // skip =>
+	trimmed := strings.TrimLeft(body, " \t\r\n")
+	bodyPos := file.Pos(offset + 2 + len(body) - len(trimmed))
+	return &action{file.Pos(offset), file.Pos(len(src)), bodyPos, strings.TrimSpace(trimmed)}, nil
+}
+
+// FromFile generates a Go recursive-descent parser from a tpl grammar file.
+// The actions (`=> { ... }`) of rules must be written in Go, where self is
+// the matching result of the rule, whose type is []any for list rules (see
+// [ast.Rule.IsList]) and any for others. As in Go+, lowercase functions of
+// imported packages, eg. tpl.binaryExpr, are referred by their exported names.
+//
+// Imports and extensions of rules are resolved by [cl.Merge], so the parser of
+// an imported rule is generated as well, with the name it's referred by, eg.
+// r_b_expr for b.expr. As in tpl/cl, the actions of imported rules aren't
+// parsed, and need to be written in the importing grammar by extending them.
+//
+// The generated parser has no dependency on tpl/cl, so no grammar is compiled
+// at runtime, and its matching results are the same as [tpl.Compiler.Parse].
+func FromFile(fset *token.FileSet, filename string, src any, conf *Config) (code []byte, err error) {
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	if conf == nil {
+		conf = &Config{}
+	}
+	b, err := iox.ReadSourceLocal(filename, src)
+	if err != nil {
+		return
+	}
+	f, err := parser.ParseFile(fset, filename, b, &parser.Config{ParseRetProc: parseAction})
+	if err != nil {
+		return
+	}
+	f, ret, err := cl.Merge(&cl.Config{
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+	}, fset, f)
+	if err != nil {
+		return
+	}
+	g := &generator{
+		conf:    conf,
+		fset:    fset,
+		rules:   ret.Rules,
+		names:   make(map[string]string),
+		tokens:  make(map[string]int),
+		defs:    ret.Tokens,
+		stops:   ret.Stops,
+		methods: make(map[ast.Expr]string),
+		imports: make(map[string]string),
+	}
+	for name, path := range runtimeImports {
+		g.imports[name] = path
+	}
+	return g.file(f)
+}
+
+// -----------------------------------------------------------------------------
+
+type generator struct {
+	conf    *Config
+	fset    *token.FileSet
+	rules   map[string]*matcher.Var
+	names   map[string]string // rules => names in Go identifiers, eg. b.expr => b_expr
+	tokens  map[string]int    // token rules: name => index of defs
+	defs    []*scanner.TokenDef
+	stops   map[*ast.Choice][]bool
+	methods map[ast.Expr]string // methods generated for expressions
+	imports map[string]string
+	body    bytes.Buffer
+	subs    bytes.Buffer // methods of subexpressions of the rule being generated
+	rule    string       // name of the rule being generated
+	nmethod int          // number of methods generated for the rule
+}
+
+func (p *generator) file(f *ast.File) ([]byte, error) {
+	var decls []*ast.Rule
+	var imported []string
+	used := make(map[string]bool)
+	for _, decl := range f.Decls {
+		r := decl.(*ast.Rule)
+		name := r.Name.Name
+		if p.rules[name] == nil { // token rules are placed last, in the order of defs
+			p.tokens[name] = len(p.tokens)
+			continue
+		}
+		decls = append(decls, r)
+		if strings.Contains(name, ".") {
+			imported = append(imported, name)
+		} else {
+			p.names[name], used[name] = name, true
+		}
+	}
+	for _, name := range imported { // b.expr => b_expr, or b_expr_2 if b_expr is used
+		goName := strings.ReplaceAll(name, ".", "_")
+		for i := 2; used[goName]; i++ {
+			goName = strings.ReplaceAll(name, ".", "_") + "_" + strconv.Itoa(i)
+		}
+		p.names[name], used[goName] = goName, true
+	}
+	if p.defs != nil {
+		p.imports["regexp"] = "regexp"
+	}
+	for i, r := range decls {
+		if err := p.ruleDecl(i, r); err != nil {
+			return nil, err
+		}
+	}
+
+	var b bytes.Buffer
+	pkg := p.conf.Package
+	if pkg == "" {
+		pkg = "parser"
+	}
+	fmt.Fprintf(&b, "// Code generated by gop tpl gen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	var std, others []string
+	for name, path := range p.imports {
+		if strings.Contains(path[:strings.IndexByte(path+"/", '/')], ".") {
+			others = append(others, name)
+		} else {
+			std = append(std, name)
+		}
+	}
+	for i, names := range [][]string{std, others} {
+		if i > 0 && len(std) > 0 && len(others) > 0 {
+			b.WriteByte('\n')
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return p.imports[names[i]] < p.imports[names[j]]
+		})
+		for _, name := range names {
+			path := p.imports[name]
+			if name == path[strings.LastIndexByte(path, '/')+1:] {
+				fmt.Fprintf(&b, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&b, "\t%s %q\n", name, path)
+			}
+		}
+	}
+	rt := runtime
+	if p.defs != nil {
+		rt = strings.Replace(rt, "\ts.Init(", "\ts.SetTokens(tokenDefs)\n\ts.Init(", 1)
+	}
+	fmt.Fprintf(&b, ")\n\n%s\nfunc (p *parser) doc(src []*types.Token) (int, any, error) {\n\treturn p.r_%s(src)\n}\n",
+		rt, p.names[decls[0].Name.Name])
+	if p.defs != nil {
+		p.tokenDefs(&b)
+	}
+	b.Write(p.body.Bytes())
+	return format.Source(b.Bytes())
+}
+
+// tokenDefs generates the tokens defined by token rules, such as
+// NUMBER = /[0-9]+/, and the method matching them.
+func (p *generator) tokenDefs(b *bytes.Buffer) {
+	b.WriteString("\nvar tokenDefs = []*scanner.TokenDef{\n")
+	for i, def := range p.defs {
+		tok := "token.USER_BEG"
+		if i > 0 {
+			tok += " + " + strconv.Itoa(i)
+		}
+		re := def.Regexp.String()
+		if strconv.CanBackquote(re) {
+			re = "`" + re + "`"
+		} else {
+			re = strconv.Quote(re)
+		}
+		fmt.Fprintf(b, "\t{Tok: %s, Name: %q, Regexp: regexp.MustCompile(%s)},\n", tok, def.Name, re)
+	}
+	b.WriteString(`}
+
+func (p *parser) matchDefined(src []*types.Token, i int) (n int, result any, err error) {
+	def := tokenDefs[i]
+	if len(src) == 0 {
+		return 0, nil, &expectError{pos: p.fileEnd, expect: def.Name}
+	}
+	t := src[0]
+	if t.Tok != def.Tok {
+		return 0, nil, &expectError{pos: t.Pos, expect: def.Name, got: t}
+	}
+	return 1, t, nil
+}
+`)
+}
+
+// ruleDecl generates the matching methods of a rule:
+//
+//	r_name: memoization (if any) => seed growing (if left-recursive) => m_name
+//	m_name: matching of the rule expression, and its action (if any)
+//
+// m_name is named r_name if there is neither memoization nor seed growing.
+func (p *generator) ruleDecl(idx int, r *ast.Rule) error {
+	v := p.rules[r.Name.Name]
+	name := p.names[r.Name.Name]
+	p.rule, p.nmethod = name, 0
+	act, _ := r.RetProc.(*action)
+	leftRec := v.LeftRec
+	memo := p.conf.Memo && act == nil
+	b := &p.body
+	match := "r_" + name
+	if memo || leftRec {
+		match = "m_" + name
+		fmt.Fprintf(b, "\nfunc (p *parser) r_%s(src []*types.Token) (int, any, error) {\n", name)
+		switch {
+		case memo && leftRec:
+			fmt.Fprintf(b, "\treturn p.memoize(%d, src, func(p *parser, src []*types.Token) (int, any, error) {\n", idx)
+			fmt.Fprintf(b, "\t\treturn p.growSeed(%d, src, (*parser).%s)\n\t})\n", idx, match)
+		case memo:
+			fmt.Fprintf(b, "\treturn p.memoize(%d, src, (*parser).%s)\n", idx, match)
+		default:
+			fmt.Fprintf(b, "\treturn p.growSeed(%d, src, (*parser).%s)\n", idx, match)
+		}
+		b.WriteString("}\n")
+	}
+
+	fmt.Fprintf(b, "\nfunc (p *parser) %s(src []*types.Token) (n int, result any, err error) {\n", match)
+	fmt.Fprintf(b, "\tn, result, err = %s\n", p.call(r.Expr, "src"))
+	if act != nil {
+		self := "result"
+		if r.IsList() {
+			self = "result.([]any)"
+		}
+		fmt.Fprintf(b, "\tif err == nil {\n\t\tdefer p.recoverAction(src, &err)\n\t\tresult = act_%s(%s)\n\t} else ", name, self)
+	} else {
+		b.WriteString("\t")
+	}
+	fmt.Fprintf(b, "if err == errMultiMismatch {\n\t\terr = p.expectRule(src, %q)\n\t}\n\treturn\n}\n", v.Name)
+
+	if act != nil {
+		selfType := "any"
+		if r.IsList() {
+			selfType = "[]any"
+		}
+		body, err := p.actionBody(act)
+		if err != nil {
+			return p.actionError(act, r.Name.Name, err)
+		}
+		fmt.Fprintf(b, "\nfunc act_%s(self %s) any %s\n", name, selfType, body)
+	}
+	b.Write(p.subs.Bytes())
+	p.subs.Reset()
+	return nil
+}
+
+// actionBody checks the body of an action, and converts references to
+// lowercase functions of imported packages (eg. tpl.binaryExpr) into their
+// exported names.
+func (p *generator) actionBody(act *action) (string, error) {
+	type tokenInfo struct {
+		off int
+		tok gotoken.Token
+		lit string
+	}
+	src := act.body
+	var s goscanner.Scanner
+	var errs goscanner.ErrorList
+	file := gotoken.NewFileSet().AddFile("", -1, len(src))
+	s.Init(file, []byte(src), errs.Add, 0)
+	var toks []tokenInfo
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == gotoken.EOF {
+			break
+		}
+		toks = append(toks, tokenInfo{file.Offset(pos), tok, lit})
+	}
+	if len(errs) > 0 {
+		return "", errs[0]
+	}
+	var b strings.Builder
+	var last int
+	for i := 0; i+2 < len(toks); i++ {
+		t := toks[i]
+		if t.tok != gotoken.IDENT || i > 0 && toks[i-1].tok == gotoken.PERIOD ||
+			toks[i+1].tok != gotoken.PERIOD || toks[i+2].tok != gotoken.IDENT {
+			continue
+		}
+		if ok, err := p.useImport(t.lit); err != nil {
+			return "", err
+		} else if !ok {
+			continue
+		}
+		if sel := toks[i+2]; sel.lit[0] >= 'a' && sel.lit[0] <= 'z' {
+			b.WriteString(src[last:sel.off])
+			b.WriteString(strings.ToUpper(sel.lit[:1]) + sel.lit[1:])
+			last = sel.off + len(sel.lit)
+		}
+	}
+	b.WriteString(src[last:])
+	body := b.String()
+	const prefix = "func(self any) any "
+	if _, err := goparser.ParseExpr(prefix + body); err != nil {
+		e := err.(goscanner.ErrorList)[0]
+		if e.Pos.Line == 1 {
+			e.Pos.Column -= len(prefix)
+		}
+		return "", e
+	}
+	return body, nil
+}
+
+// actionError converts an error of an action body into an error of the
+// grammar file.
+func (p *generator) actionError(act *action, rule string, err error) error {
+	pos := p.fset.Position(act.bodyPos)
+	msg := err.Error()
+	if e, ok := err.(*goscanner.Error); ok {
+		if e.Pos.Line == 1 {
+			pos.Column += e.Pos.Column - 1
+		} else {
+			pos.Line += e.Pos.Line - 1
+			pos.Column = e.Pos.Column
+		}
+		msg = e.Msg
+	}
+	return &scanner.Error{Pos: pos, Msg: "invalid action of rule " + rule + ": " + msg}
+}
+
+// useImport imports the package referred by name if it's a package.
+func (p *generator) useImport(name string) (ok bool, err error) {
+	path, ok := p.conf.Imports[name]
+	if !ok {
+		if path, ok = DefaultImports[name]; !ok {
+			return
+		}
+	}
+	if old, exists := p.imports[name]; exists && old != path {
+		return false, fmt.Errorf("import %s %q conflicts with %q", name, path, old)
+	}
+	p.imports[name] = path
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+func tokenExpr(tok token.Token) string {
+	if name := tok.Name(); name != "" && tok.Len() != 1 {
+		return "token." + name
+	}
+	return strconv.QuoteRune(rune(tok))
+}
+
+// call returns the Go expression which matches src with expression e. The
+// expressions are checked by cl.NewEx, so they are valid here.
+func (p *generator) call(e ast.Expr, src string) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		name := e.Name
+		if _, ok := p.rules[name]; ok {
+			return fmt.Sprintf("p.r_%s(%s)", p.names[name], src)
+		} else if i, ok := p.tokens[name]; ok {
+			return fmt.Sprintf("p.matchDefined(%s, %d)", src, i)
+		} else if tok, ok := token.Lookup(name); ok {
+			return fmt.Sprintf("p.matchToken(%s, %s)", src, tokenExpr(tok))
+		}
+		switch name {
+		case "RAWSTRING":
+			return fmt.Sprintf("p.matchString(%s, '`')", src)
+		case "QSTRING":
+			return fmt.Sprintf("p.matchString(%s, '\"')", src)
+		}
+		return fmt.Sprintf("p.matchSpace(%s)", src) // SPACE
+	case *ast.BasicLit:
+		var tok token.Token
+		if e.Kind == token.CHAR {
+			v, _, _, _ := strconv.UnquoteChar(e.Value[1:len(e.Value)-1], '\'')
+			tok = token.Token(v)
+		} else {
+			v, _ := strconv.Unquote(e.Value)
+			if v == "" {
+				return fmt.Sprintf("p.matchTrue(%s)", src)
+			}
+			if c := v[0]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' {
+				return fmt.Sprintf("p.matchLiteral(%s, token.IDENT, %q)", src, v)
+			}
+			tok, _ = token.Operator(v)
+		}
+		return fmt.Sprintf("p.matchToken(%s, %s)", src, tokenExpr(tok))
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case token.QUESTION:
+			return fmt.Sprintf("p.repeat01(%s, %s)", src, p.fn(e.X))
+		case token.MUL:
+			return fmt.Sprintf("p.repeat0(%s, %s)", src, p.fn(e.X))
+		case token.ADD:
+			return fmt.Sprintf("p.repeat1(%s, %s)", src, p.fn(e.X))
+		case token.AND:
+			return fmt.Sprintf("p.lookahead(%s, %s, false)", src, p.fn(e.X))
+		default: // token.NOT
+			return fmt.Sprintf("p.lookahead(%s, %s, true)", src, p.fn(e.X))
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.INC { // R1 ++ R2
+			return fmt.Sprintf("p.adjoin(%s, %s, %s)", src, p.fn(e.X), p.fn(e.Y))
+		}
+	}
+	return fmt.Sprintf("p.%s(%s)", p.method(e), src)
+}
+
+// fn returns the Go function (a method expression of type matchFunc) which
+// matches with expression e.
+func (p *generator) fn(e ast.Expr) string {
+	return "(*parser)." + p.method(e)
+}
+
+// method returns the name of the method which matches with expression e.
+func (p *generator) method(e ast.Expr) string {
+	if ident, ok := e.(*ast.Ident); ok {
+		if _, ok := p.rules[ident.Name]; ok {
+			return "r_" + p.names[ident.Name]
+		}
+	}
+	if name, ok := p.methods[e]; ok {
+		return name
+	}
+	p.nmethod++
+	name := fmt.Sprintf("x_%s_%d", p.rule, p.nmethod)
+	p.methods[e] = name
+	var b bytes.Buffer
+	switch e := e.(type) {
+	case *ast.Sequence:
+		p.sequence(&b, e.Items)
+	case *ast.Choice:
+		p.choice(&b, e)
+	default:
+		if e, ok := e.(*ast.BinaryExpr); ok && e.Op == token.REM { // R1 % R2 is equivalent to R1 *(R2 R1)
+			p.sequence(&b, []ast.Expr{e.X, &ast.UnaryExpr{
+				Op: token.MUL, X: &ast.Sequence{Items: []ast.Expr{e.Y, e.X}},
+			}})
+			break
+		}
+		fmt.Fprintf(&b, "\treturn %s\n", p.call(e, "src"))
+	}
+	fmt.Fprintf(&p.subs, "\nfunc (p *parser) %s(src []*types.Token) (n int, result any, err error) {\n", name)
+	p.subs.Write(b.Bytes())
+	p.subs.WriteString("}\n")
+	return name
+}
+
+func (p *generator) sequence(b *bytes.Buffer, items []ast.Expr) {
+	fmt.Fprintf(b, "\trets := make([]any, %d)\n\tvar n1 int\n\tvar err1 error\n", len(items))
+	for i, item := range items {
+		src := "src[n:]"
+		if i == 0 {
+			src = "src"
+		}
+		fmt.Fprintf(b, "\tn1, rets[%d], err1 = %s\n", i, p.call(item, src))
+		b.WriteString("\tif err1 != nil {\n\t\tif !isDyn(err1) {\n\t\t\treturn n + n1, nil, err1\n\t\t}\n\t\terr = err1\n\t}\n\tn += n1\n")
+	}
+	b.WriteString("\treturn n, rets, err\n")
+}
+
+func (p *generator) choice(b *bytes.Buffer, c *ast.Choice) {
+	b.WriteString("\tc := choiceState{nMax: -1, multiErr: true}\n")
+	stops := p.stops[c]
+	for i, option := range c.Options {
+		cond := "err == nil"
+		if stops[i] { // stop if the option matches some tokens
+			cond = "err == nil || n > 0"
+		}
+		fmt.Fprintf(b, "\tif n, result, err = %s; %s {\n\t\treturn\n\t}\n\tc.add(n, err)\n", p.call(option, "src"), cond)
+	}
+	b.WriteString("\treturn c.result()\n")
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,306 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gen_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/gen"
+	"github.com/goplus/gop/tpl/gen/gentest/adjoin"
+	"github.com/goplus/gop/tpl/gen/gentest/calc"
+	"github.com/goplus/gop/tpl/gen/gentest/imports"
+	"github.com/goplus/gop/tpl/gen/gentest/lookahead"
+	"github.com/goplus/gop/tpl/gen/gentest/mini"
+	"github.com/goplus/gop/tpl/gen/gentest/simple1"
+	"github.com/goplus/gop/tpl/gen/gentest/simple2"
+	"github.com/goplus/gop/tpl/gen/gentest/tokens"
+	"github.com/goplus/gop/tpl/token"
+	"github.com/goplus/gop/tpl/variant"
+
+	_ "github.com/goplus/gop/tpl/variant/math"
+)
+
+// cases are the grammars whose parsers are generated into gentest/<name>.
+var cases = []struct {
+	name string
+	file string
+	memo bool
+}{
+	{"simple1", "../parser/_testdata/simple1/in.gop", false},
+	{"simple2", "../parser/_testdata/simple2/in.gop", false},
+	{"adjoin", "../parser/_testdata/adjoin/in.gop", false},
+	{"lookahead", "../parser/_testdata/lookahead/in.gop", false},
+	{"tokens", "../parser/_testdata/tokens/in.gop", false},
+	{"mini", "../../doc/spec/mini/mini.gop", true},
+	{"calc", "_testdata/calc/in.gop", false},
+	{"imports", "_testdata/imports/in.tpl", false},
+}
+
+// grammar returns the grammar in file, which is a tpl`...` literal if file
+// is a Go+ source file.
+func grammar(t testing.TB, file string) []byte {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal("ReadFile:", err)
+	}
+	if i := bytes.Index(b, []byte("tpl`")); i >= 0 {
+		b = b[i+4:]
+		b = b[:bytes.IndexByte(b, '`')]
+	}
+	return b
+}
+
+func TestGen(t *testing.T) {
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			code, err := gen.FromFile(nil, c.file, grammar(t, c.file), &gen.Config{Package: c.name, Memo: c.memo})
+			if err != nil {
+				t.Fatal("gen.FromFile:", err)
+			}
+			dir := filepath.Join("gentest", c.name)
+			if b, _ := os.ReadFile(filepath.Join(dir, "parser.go")); !bytes.Equal(b, code) {
+				os.WriteFile(filepath.Join(dir, "result.txt"), code, 0666)
+				t.Fatal("unexpected code, see", dir+"/result.txt")
+			}
+		})
+	}
+}
+
+func TestActionErr(t *testing.T) {
+	_, err := gen.FromFile(nil, "foo.tpl", `expr = INT % "," => {
+	return [v for v in self]
+}
+`, nil)
+	if err == nil || err.Error() != "foo.tpl:2:12: invalid action of rule expr: expected ']', found 'for'" {
+		t.Fatal("gen.FromFile:", err)
+	}
+	_, err = gen.FromFile(nil, "foo.tpl", `expr = INT => {
+	return types.Typ[types.Int]
+}
+`, &gen.Config{Imports: map[string]string{"types": "go/types"}})
+	if err == nil || err.Error() != `foo.tpl:1:15: invalid action of rule expr: import types "go/types" conflicts with "github.com/goplus/gop/tpl/types"` {
+		t.Fatal("gen.FromFile:", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+type parseFunc = func(src string) (any, error)
+
+func compile(t *testing.T, name string, params ...any) tpl.Compiler {
+	for _, c := range cases {
+		if c.name == name {
+			conf := &cl.Config{
+				OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+				RetProcs:   make(map[string]any),
+			}
+			for i := 0; i < len(params); i += 2 {
+				conf.RetProcs[params[i].(string)] = params[i+1]
+			}
+			ret, err := tpl.FromFile(nil, c.file, grammar(t, c.file), conf)
+			if err != nil {
+				t.Fatal("tpl.FromFile:", err)
+			}
+			return ret
+		}
+	}
+	t.Fatal("grammar not found:", name)
+	return tpl.Compiler{}
+}
+
+// testParse checks that parse and parseExpr of a generated parser return the
+// same results and errors as Parse and ParseExprFrom of c.
+func testParse(t *testing.T, c tpl.Compiler, conf *tpl.Config, parse, parseExpr parseFunc, inputs ...string) {
+	t.Helper()
+	for _, in := range inputs {
+		ret1, err1 := c.Parse("", in, conf)
+		ret2, err2 := parse(in)
+		checkResult(t, "Parse", in, ret1, ret2, err1, err2)
+		ret1, err1 = c.ParseExprFrom("", in, conf)
+		ret2, err2 = parseExpr(in)
+		checkResult(t, "ParseExpr", in, ret1, ret2, err1, err2)
+	}
+}
+
+func checkResult(t *testing.T, op, in string, ret1, ret2 any, err1, err2 error) {
+	t.Helper()
+	if (err1 == nil) != (err2 == nil) || err1 != nil && err1.Error() != err2.Error() {
+		t.Fatalf("%s %q: error %v, expected %v", op, in, err2, err1)
+	}
+	if !reflect.DeepEqual(ret1, ret2) {
+		t.Fatalf("%s %q: result %v, expected %v", op, in, ret2, ret1)
+	}
+}
+
+var exprs = []string{
+	"1 + 2 * 3",
+	"-(1 - 2.5) / 3 * 4",
+	"((1))",
+	"1 +",
+	"1 2",
+	"(1",
+	"",
+}
+
+func TestSimple1(t *testing.T) {
+	testParse(t, compile(t, "simple1"), nil, func(src string) (any, error) {
+		return simple1.Parse("", src, nil)
+	}, func(src string) (any, error) {
+		return simple1.ParseExpr(src, nil)
+	}, exprs...)
+}
+
+func TestSimple2(t *testing.T) {
+	testParse(t, compile(t, "simple2"), nil, func(src string) (any, error) {
+		return simple2.Parse("", src, nil)
+	}, func(src string) (any, error) {
+		return simple2.ParseExpr(src, nil)
+	}, exprs...)
+}
+
+func TestAdjoin(t *testing.T) {
+	testParse(t, compile(t, "adjoin"), nil, func(src string) (any, error) {
+		return adjoin.Parse("", src, nil)
+	}, func(src string) (any, error) {
+		return adjoin.ParseExpr(src, nil)
+	}, "sql`select`", "sql `select`", "foo {}", "foo{}", "foo", "`select`")
+}
+
+func TestLookahead(t *testing.T) {
+	testParse(t, compile(t, "lookahead"), nil, func(src string) (any, error) {
+		return lookahead.Parse("", src, nil)
+	}, func(src string) (any, error) {
+		return lookahead.ParseExpr(src, nil)
+	}, "x", "if", "else x", "x y", "")
+}
+
+func TestTokens(t *testing.T) {
+	testParse(t, compile(t, "tokens"), nil, func(src string) (any, error) {
+		return tokens.Parse("", src, nil)
+	}, func(src string) (any, error) {
+		return tokens.ParseExpr(src, nil)
+	}, "module = github.com/goplus/gop\ngo = 1.18\n", "x = 1.", "= 1", "x = ;", "")
+}
+
+func TestImports(t *testing.T) {
+	testParse(t, compile(t, "imports"), nil, func(src string) (any, error) {
+		return imports.Parse("", src, nil)
+	}, func(src string) (any, error) {
+		return imports.ParseExpr(src, nil)
+	}, "x = 1 + (y + 2);", "x = (1 +);", "x = 1 y = 2;", "x = ;", "")
+}
+
+func TestMini(t *testing.T) {
+	files, err := filepath.Glob("../../demo/*/*.gop")
+	if err != nil || len(files) == 0 {
+		t.Fatal("Glob:", files, err)
+	}
+	inputs := []string{
+		"echo 1 +",
+		"func f(x int) { return x",
+		"import \"fmt\"; fmt.println [x*x for x in 1:10]",
+	}
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal("ReadFile:", err)
+		}
+		inputs = append(inputs, string(b))
+	}
+	testParse(t, compile(t, "mini"), &tpl.Config{Memo: true}, func(src string) (any, error) {
+		return mini.Parse("", src, nil)
+	}, func(src string) (any, error) {
+		return mini.ParseExpr(src, nil)
+	}, inputs...)
+}
+
+func TestCalc(t *testing.T) {
+	variant.InitUniverse("math")
+	c := compile(t, "calc",
+		"expr", func(self []any) any {
+			return tpl.BinaryOp(true, self, func(op *tpl.Token, x, y any) any {
+				return variant.MathOp(op.Tok, x, y)
+			})
+		},
+		"parenExpr", func(self []any) any {
+			return self[1]
+		},
+		"unaryExpr", func(self []any) any {
+			return variant.UnaryOp(token.SUB, self[1])
+		},
+		"callExpr", func(self []any) any {
+			fn := self[0].(*tpl.Token).Lit
+			return variant.Call(true, fn, self[2])
+		},
+		"basicLit", func(self any) any {
+			v, err := strconv.ParseFloat(self.(*tpl.Token).Lit, 64)
+			if err != nil {
+				panic(err.Error())
+			}
+			return v
+		},
+	)
+	testParse(t, c, nil, func(src string) (any, error) {
+		return calc.Parse("", src, nil)
+	}, func(src string) (any, error) {
+		return calc.ParseExpr(src, nil)
+	}, append(exprs, "max(1, 2) - abs(-3)", "sqrt()", "foo(1)", "1e1000")...)
+	if ret, err := calc.ParseExpr("(1 + 2) * max(3, 4)", nil); err != nil || ret != 12.0 {
+		t.Fatal("calc.ParseExpr:", ret, err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+func benchmarkMini(b *testing.B, parse parseFunc) {
+	src, err := os.ReadFile("../../demo/gop-parser/parser.gop")
+	if err != nil {
+		b.Fatal("ReadFile:", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parse(string(src)); err != nil {
+			b.Fatal("Parse:", err)
+		}
+	}
+}
+
+func BenchmarkMini(b *testing.B) {
+	c, err := tpl.FromFile(nil, "", grammar(b, "../../doc/spec/mini/mini.gop"), &cl.Config{
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+	})
+	if err != nil {
+		b.Fatal("tpl.FromFile:", err)
+	}
+	benchmarkMini(b, func(src string) (any, error) {
+		return c.Parse("", src, &tpl.Config{Memo: true})
+	})
+}
+
+func BenchmarkMiniGen(b *testing.B) {
+	benchmarkMini(b, func(src string) (any, error) {
+		return mini.Parse("", src, nil)
+	})
+}
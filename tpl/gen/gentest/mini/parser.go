@@ -0,0 +1,7384 @@
+// Code generated by gop tpl gen; DO NOT EDIT.
+
+package mini
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goplus/gop/parser/iox"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/scanner"
+	"github.com/goplus/gop/tpl/token"
+	"github.com/goplus/gop/tpl/types"
+)
+
+// Config represents a parsing configuration.
+type Config struct {
+	ScanErrorHandler scanner.ErrorHandler
+	ScanMode         scanner.Mode
+	Fset             *token.FileSet
+}
+
+// ParseExpr parses an expression.
+func ParseExpr(x string, conf *Config) (result any, err error) {
+	return ParseExprFrom("", x, conf)
+}
+
+// ParseExprFrom parses an expression from a file.
+func ParseExprFrom(filename string, src any, conf *Config) (result any, err error) {
+	p, result, err := match(filename, src, conf)
+	if err != nil {
+		return
+	}
+	if len(p.toks) == p.n || isEOL(p.toks[p.n].Tok) {
+		return
+	}
+	t := p.next()
+	err = p.newErrorf(t.Pos, "unexpected token: %v", t)
+	return
+}
+
+// Parse parses a source file.
+func Parse(filename string, src any, conf *Config) (result any, err error) {
+	p, result, err := match(filename, src, conf)
+	if err != nil {
+		return
+	}
+	if len(p.toks) > p.n {
+		t := p.next()
+		err = p.newErrorf(t.Pos, "unexpected token: %v", t)
+	}
+	return
+}
+
+func match(filename string, src any, conf *Config) (p *parser, result any, err error) {
+	b, err := iox.ReadSourceLocal(filename, src)
+	if err != nil {
+		return
+	}
+	if conf == nil {
+		conf = &Config{}
+	}
+	fset := conf.Fset
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	f := fset.AddFile(filename, fset.Base(), len(b))
+	var s scanner.Scanner
+	s.Init(f, b, conf.ScanErrorHandler, conf.ScanMode)
+	var toks []*types.Token
+	for {
+		t := s.Scan()
+		if t.Tok == token.EOF {
+			break
+		}
+		toks = append(toks, &t)
+	}
+	p = &parser{
+		fset:    fset,
+		fileEnd: token.Pos(f.Base() + len(b)),
+		toks:    toks,
+		left:    len(toks),
+		memo:    make(map[ruleKey]memoEntry),
+		seeds:   make(map[ruleKey]*memoEntry),
+	}
+	p.n, result, err = p.doc(toks)
+	p.setLastError(len(toks)-p.n, err)
+	if e, ok := err.(*expectError); ok {
+		err = &matcher.Error{Fset: fset, Pos: e.pos, Msg: e.Error()}
+	}
+	return
+}
+
+func isEOL(tok token.Token) bool {
+	return tok == token.SEMICOLON || tok == token.EOF
+}
+
+var (
+	errNoWhitespace  = errors.New("no whitespace")
+	errAdjoinEmpty   = errors.New("adjoin empty")
+	errMultiMismatch = errors.New("multiple mismatch")
+	errLeftRec       = errors.New("left recursion")
+)
+
+func isDyn(err error) bool {
+	if e, ok := err.(*matcher.Error); ok {
+		return e.Dyn
+	}
+	return false
+}
+
+type ruleKey struct {
+	rule int
+	left int // number of tokens left, that is, the token offset
+}
+
+type memoEntry struct {
+	n      int
+	result any
+	err    error
+}
+
+type parser struct {
+	fset    *token.FileSet
+	fileEnd token.Pos
+	toks    []*types.Token
+	n       int // number of matched tokens
+	left    int
+	lastErr error
+	memo    map[ruleKey]memoEntry
+	seeds   map[ruleKey]*memoEntry // seeds of left-recursive rules being grown
+	growing int                    // number of seeds being grown
+}
+
+func (p *parser) next() *types.Token {
+	if n := p.left; n > 0 {
+		return p.toks[len(p.toks)-n]
+	}
+	return &types.Token{Tok: token.EOF, Pos: p.fileEnd}
+}
+
+func (p *parser) setLastError(left int, err error) {
+	if left < p.left {
+		p.left, p.lastErr = left, err
+	}
+}
+
+func (p *parser) newErrorf(pos token.Pos, format string, args ...any) error {
+	return &matcher.Error{Fset: p.fset, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// expectError represents an error "expect X, but got Y". Its message is
+// formatted lazily, since most of matching errors are discarded when
+// backtracking.
+type expectError struct {
+	pos    token.Pos
+	expect string
+	got    *types.Token // nil means EOF
+	tok    bool         // got is printed as got.Tok
+	quote  bool         // EOF is quoted
+}
+
+func (e *expectError) Error() string {
+	var got string
+	switch {
+	case e.got == nil && !e.quote:
+		return "expect `" + e.expect + "`, but got EOF"
+	case e.got == nil:
+		got = "EOF"
+	case e.tok:
+		got = e.got.Tok.String()
+	default:
+		got = e.got.String()
+	}
+	return "expect `" + e.expect + "`, but got `" + got + "`"
+}
+
+func (p *parser) expectRule(src []*types.Token, name string) error {
+	if len(src) > 0 {
+		return &expectError{pos: src[0].Pos, expect: name, got: src[0]}
+	}
+	return &expectError{pos: p.fileEnd, expect: name, quote: true}
+}
+
+func (p *parser) recoverAction(src []*types.Token, err *error) {
+	if e := recover(); e != nil {
+		switch e := e.(type) {
+		case *matcher.Error:
+			if e.Fset == nil {
+				e.Fset = p.fset
+			}
+			*err = e
+		case string:
+			*err = &matcher.Error{Fset: p.fset, Pos: src[0].Pos, Msg: e, Dyn: true}
+		default:
+			*err = e.(error)
+		}
+	}
+}
+
+type matchFunc = func(p *parser, src []*types.Token) (n int, result any, err error)
+
+func (p *parser) memoize(rule int, src []*types.Token, match matchFunc) (n int, result any, err error) {
+	key := ruleKey{rule, len(src)}
+	if e, ok := p.memo[key]; ok {
+		return e.n, e.result, e.err
+	}
+	n, result, err = match(p, src)
+	if p.growing == 0 { // results depending on a growing seed can't be memoized
+		p.memo[key] = memoEntry{n, result, err}
+	}
+	return
+}
+
+func (p *parser) growSeed(rule int, src []*types.Token, match matchFunc) (n int, result any, err error) {
+	key := ruleKey{rule, len(src)}
+	if seed, ok := p.seeds[key]; ok { // left recursion
+		return seed.n, seed.result, seed.err
+	}
+	seed := &memoEntry{err: errLeftRec}
+	p.seeds[key] = seed
+	p.growing++
+	defer func() {
+		delete(p.seeds, key)
+		p.growing--
+	}()
+	for {
+		n, result, err = match(p, src)
+		failed := err != nil && !isDyn(err)
+		if seed.err != errLeftRec && (failed || n <= seed.n) {
+			break
+		}
+		*seed = memoEntry{n, result, err}
+		if failed {
+			break
+		}
+	}
+	return seed.n, seed.result, seed.err
+}
+
+func (p *parser) matchTrue(src []*types.Token) (n int, result any, err error) {
+	return 0, nil, nil
+}
+
+func (p *parser) matchSpace(src []*types.Token) (n int, result any, err error) {
+	if left := len(src); left > 0 {
+		if n := len(p.toks); n > left {
+			if p.toks[n-left-1].End() != src[0].Pos {
+				return 0, nil, nil
+			}
+		}
+	}
+	return 0, nil, errNoWhitespace
+}
+
+func (p *parser) matchString(src []*types.Token, quoteCh byte) (n int, result any, err error) {
+	typ := "RAWSTRING"
+	if quoteCh == '"' {
+		typ = "QSTRING"
+	}
+	if len(src) == 0 {
+		return 0, nil, &expectError{pos: p.fileEnd, expect: typ}
+	}
+	t := src[0]
+	if t.Tok != token.STRING || t.Lit[0] != quoteCh {
+		return 0, nil, &expectError{pos: t.Pos, expect: typ, got: t}
+	}
+	return 1, t, nil
+}
+
+func (p *parser) matchToken(src []*types.Token, tok token.Token) (n int, result any, err error) {
+	if len(src) == 0 {
+		return 0, nil, &expectError{pos: p.fileEnd, expect: tok.String()}
+	}
+	t := src[0]
+	if t.Tok != tok {
+		return 0, nil, &expectError{pos: t.Pos, expect: tok.String(), got: t, tok: true}
+	}
+	return 1, t, nil
+}
+
+func (p *parser) matchLiteral(src []*types.Token, tok token.Token, lit string) (n int, result any, err error) {
+	if len(src) == 0 {
+		return 0, nil, &expectError{pos: p.fileEnd, expect: lit}
+	}
+	t := src[0]
+	if t.Lit != lit || t.Tok != tok && t.Tok < token.USER_BEG { // a token rule may scan a keyword
+		return 0, nil, &expectError{pos: t.Pos, expect: lit, got: t}
+	}
+	return 1, t, nil
+}
+
+type choiceState struct {
+	nMax     int
+	errMax   error
+	multiErr bool
+}
+
+func (c *choiceState) add(n int, err error) {
+	if n >= c.nMax {
+		if n == c.nMax {
+			c.multiErr = true
+		} else {
+			c.nMax, c.errMax, c.multiErr = n, err, false
+		}
+	}
+}
+
+func (c *choiceState) result() (n int, result any, err error) {
+	if c.multiErr {
+		return c.nMax, nil, errMultiMismatch
+	}
+	return c.nMax, nil, c.errMax
+}
+
+func (p *parser) repeat0(src []*types.Token, g matchFunc) (n int, result any, err error) {
+	rets := make([]any, 0, 2)
+	for {
+		n1, ret1, err1 := g(p, src)
+		if err1 != nil {
+			if !isDyn(err1) {
+				p.setLastError(len(src)-n1, err1)
+				return n, rets, err
+			}
+			err = err1
+		}
+		rets = append(rets, ret1)
+		n += n1
+		src = src[n1:]
+	}
+}
+
+func (p *parser) repeat1(src []*types.Token, g matchFunc) (n int, result any, err error) {
+	n, ret0, err := g(p, src)
+	if err != nil {
+		return
+	}
+	rets := make([]any, 1, 2)
+	rets[0] = ret0
+	for {
+		n1, ret1, err1 := g(p, src[n:])
+		if err1 != nil {
+			if !isDyn(err1) {
+				p.setLastError(len(src)-n-n1, err1)
+				return n, rets, err
+			}
+			err = err1
+		}
+		rets = append(rets, ret1)
+		n += n1
+	}
+}
+
+func (p *parser) repeat01(src []*types.Token, g matchFunc) (n int, result any, err error) {
+	n, result, err = g(p, src)
+	if err != nil {
+		return 0, nil, nil
+	}
+	return
+}
+
+func (p *parser) lookahead(src []*types.Token, g matchFunc, not bool) (n int, result any, err error) {
+	left, lastErr := p.left, p.lastErr
+	_, _, err = g(p, src)
+	p.left, p.lastErr = left, lastErr
+	if not {
+		if err != nil {
+			return 0, nil, nil
+		}
+		if len(src) == 0 {
+			return 0, nil, p.newErrorf(p.fileEnd, "unexpected EOF")
+		}
+		return 0, nil, p.newErrorf(src[0].Pos, "unexpected `%v`", src[0])
+	}
+	return
+}
+
+func (p *parser) adjoin(src []*types.Token, a, b matchFunc) (n int, result any, err error) {
+	n, ret0, err := a(p, src)
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		return n, nil, errAdjoinEmpty
+	}
+	n1, ret1, err := b(p, src[n:])
+	if err != nil && !isDyn(err) {
+		return
+	}
+	if n1 == 0 {
+		return n, nil, errAdjoinEmpty
+	}
+	if src[n-1].End() != src[n].Pos {
+		return n, nil, p.newErrorf(src[n].Pos, "not adjoin")
+	}
+	return n + n1, []any{ret0, ret1}, err
+}
+
+func (p *parser) doc(src []*types.Token) (int, any, error) {
+	return p.r_SourceFile(src)
+}
+
+func (p *parser) r_SourceFile(src []*types.Token) (int, any, error) {
+	return p.memoize(0, src, (*parser).m_SourceFile)
+}
+
+func (p *parser) m_SourceFile(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_SourceFile_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "SourceFile")
+	}
+	return
+}
+
+func (p *parser) x_SourceFile_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ImportDecl(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_SourceFile_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_TopLevelDecl(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_SourceFile_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).r_PackageClause)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_SourceFile_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat0(src[n:], (*parser).x_SourceFile_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.repeat01(src[n:], (*parser).r_MainStmts)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_PackageClause(src []*types.Token) (int, any, error) {
+	return p.memoize(1, src, (*parser).m_PackageClause)
+}
+
+func (p *parser) m_PackageClause(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_PackageClause_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "PackageClause")
+	}
+	return
+}
+
+func (p *parser) x_PackageClause_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "package")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ImportDecl(src []*types.Token) (int, any, error) {
+	return p.memoize(2, src, (*parser).m_ImportDecl)
+}
+
+func (p *parser) m_ImportDecl(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ImportDecl_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ImportDecl")
+	}
+	return
+}
+
+func (p *parser) x_ImportDecl_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ImportSpec(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ImportDecl_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_ImportDecl_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ImportDecl_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_ImportSpec(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_ImportDecl_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ImportDecl_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "import")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_ImportDecl_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ImportSpec(src []*types.Token) (int, any, error) {
+	return p.memoize(3, src, (*parser).m_ImportSpec)
+}
+
+func (p *parser) m_ImportSpec(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ImportSpec_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ImportSpec")
+	}
+	return
+}
+
+func (p *parser) x_ImportSpec_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_ident(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '.'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ImportSpec_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).x_ImportSpec_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], token.STRING)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_TopLevelDecl(src []*types.Token) (int, any, error) {
+	return p.memoize(4, src, (*parser).m_TopLevelDecl)
+}
+
+func (p *parser) m_TopLevelDecl(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_TopLevelDecl_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "TopLevelDecl")
+	}
+	return
+}
+
+func (p *parser) x_TopLevelDecl_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_Declaration(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_FuncDecl(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_Declaration(src []*types.Token) (int, any, error) {
+	return p.memoize(5, src, (*parser).m_Declaration)
+}
+
+func (p *parser) m_Declaration(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Declaration_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Declaration")
+	}
+	return
+}
+
+func (p *parser) x_Declaration_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_ConstDecl(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_VarDecl(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_TypeDecl(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_ConstDecl(src []*types.Token) (int, any, error) {
+	return p.memoize(6, src, (*parser).m_ConstDecl)
+}
+
+func (p *parser) m_ConstDecl(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ConstDecl_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ConstDecl")
+	}
+	return
+}
+
+func (p *parser) x_ConstDecl_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ImplicitConstSpec(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ConstDecl_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ConstSpec(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat0(src[n:], (*parser).x_ConstDecl_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ConstDecl_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_ConstDecl_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ConstDecl_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_ConstSpec(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_ConstDecl_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ConstDecl_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "const")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_ConstDecl_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ConstSpec(src []*types.Token) (int, any, error) {
+	return p.memoize(7, src, (*parser).m_ConstSpec)
+}
+
+func (p *parser) m_ConstSpec(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ConstSpec_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ConstSpec")
+	}
+	return
+}
+
+func (p *parser) x_ConstSpec_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_IdentifierList(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_Type)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], '=')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.r_ExpressionList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ImplicitConstSpec(src []*types.Token) (int, any, error) {
+	return p.memoize(8, src, (*parser).m_ImplicitConstSpec)
+}
+
+func (p *parser) m_ImplicitConstSpec(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ImplicitConstSpec_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ImplicitConstSpec")
+	}
+	return
+}
+
+func (p *parser) x_ImplicitConstSpec_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).r_Type)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], '=')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_ExpressionList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ImplicitConstSpec_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_IdentifierList(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_ImplicitConstSpec_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_IdentifierList(src []*types.Token) (int, any, error) {
+	return p.memoize(9, src, (*parser).m_IdentifierList)
+}
+
+func (p *parser) m_IdentifierList(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_IdentifierList_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "IdentifierList")
+	}
+	return
+}
+
+func (p *parser) x_IdentifierList_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_IdentifierList_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_IdentifierList_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_LambdaExprList(src []*types.Token) (int, any, error) {
+	return p.memoize(10, src, (*parser).m_LambdaExprList)
+}
+
+func (p *parser) m_LambdaExprList(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_LambdaExprList_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "LambdaExprList")
+	}
+	return
+}
+
+func (p *parser) x_LambdaExprList_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaExprList_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_LambdaExpr(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_LambdaExprList_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ExpressionList(src []*types.Token) (int, any, error) {
+	return p.memoize(11, src, (*parser).m_ExpressionList)
+}
+
+func (p *parser) m_ExpressionList(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ExpressionList_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ExpressionList")
+	}
+	return
+}
+
+func (p *parser) x_ExpressionList_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Expression(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ExpressionList_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Expression(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_ExpressionList_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_VarDecl(src []*types.Token) (int, any, error) {
+	return p.memoize(12, src, (*parser).m_VarDecl)
+}
+
+func (p *parser) m_VarDecl(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_VarDecl_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "VarDecl")
+	}
+	return
+}
+
+func (p *parser) x_VarDecl_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_VarSpec(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_VarDecl_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_VarDecl_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_VarDecl_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_VarSpec(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_VarDecl_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_VarDecl_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "var")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_VarDecl_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_VarSpec(src []*types.Token) (int, any, error) {
+	return p.memoize(13, src, (*parser).m_VarSpec)
+}
+
+func (p *parser) m_VarSpec(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_VarSpec_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "VarSpec")
+	}
+	return
+}
+
+func (p *parser) x_VarSpec_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '=')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ExpressionList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_VarSpec_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '=')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExprList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_VarSpec_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Type(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_VarSpec_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_VarSpec_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_VarSpec_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_VarSpec_4(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_VarSpec_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_IdentifierList(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_VarSpec_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_TypeDecl(src []*types.Token) (int, any, error) {
+	return p.memoize(14, src, (*parser).m_TypeDecl)
+}
+
+func (p *parser) m_TypeDecl(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_TypeDecl_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "TypeDecl")
+	}
+	return
+}
+
+func (p *parser) x_TypeDecl_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_TypeSpec(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TypeDecl_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_TypeDecl_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TypeDecl_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_TypeSpec(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_TypeDecl_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_TypeDecl_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "type")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_TypeDecl_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_TypeSpec(src []*types.Token) (int, any, error) {
+	return p.memoize(15, src, (*parser).m_TypeSpec)
+}
+
+func (p *parser) m_TypeSpec(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_TypeSpec_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "TypeSpec")
+	}
+	return
+}
+
+func (p *parser) x_TypeSpec_2(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, '=')
+}
+
+func (p *parser) x_TypeSpec_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_TypeSpec_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_FuncDecl(src []*types.Token) (int, any, error) {
+	return p.memoize(16, src, (*parser).m_FuncDecl)
+}
+
+func (p *parser) m_FuncDecl(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_FuncDecl_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "FuncDecl")
+	}
+	return
+}
+
+func (p *parser) x_FuncDecl_2(src []*types.Token) (n int, result any, err error) {
+	return p.matchLiteral(src, token.IDENT, "async")
+}
+
+func (p *parser) x_FuncDecl_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_FuncDecl_3(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_Block(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.lookahead(src, (*parser).x_FuncDecl_4, true); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_FuncDecl_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 6)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).x_FuncDecl_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchLiteral(src[n:], token.IDENT, "func")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat01(src[n:], (*parser).r_Parameters)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[4], err1 = p.r_Signature(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[5], err1 = p.x_FuncDecl_3(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Signature(src []*types.Token) (int, any, error) {
+	return p.memoize(17, src, (*parser).m_Signature)
+}
+
+func (p *parser) m_Signature(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Signature_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Signature")
+	}
+	return
+}
+
+func (p *parser) x_Signature_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Parameters(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_Result)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Parameters(src []*types.Token) (int, any, error) {
+	return p.memoize(18, src, (*parser).m_Parameters)
+}
+
+func (p *parser) m_Parameters(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Parameters_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Parameters")
+	}
+	return
+}
+
+func (p *parser) x_Parameters_3(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, ',')
+}
+
+func (p *parser) x_Parameters_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ParameterList(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_Parameters_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Parameters_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_Parameters_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ParameterList(src []*types.Token) (int, any, error) {
+	return p.memoize(19, src, (*parser).m_ParameterList)
+}
+
+func (p *parser) m_ParameterList(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ParameterList_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ParameterList")
+	}
+	return
+}
+
+func (p *parser) x_ParameterList_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_IdentifierList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ParameterList_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Type(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_ParameterList_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ParameterList_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ParameterList_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_ParameterList_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.repeat0(src, (*parser).x_ParameterList_5); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ParameterList_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_IdentifierList(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_ParameterList_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Result(src []*types.Token) (int, any, error) {
+	return p.memoize(20, src, (*parser).m_Result)
+}
+
+func (p *parser) m_Result(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Result_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Result")
+	}
+	return
+}
+
+func (p *parser) x_Result_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_Parameters(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_NoParenType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_MainStmts(src []*types.Token) (int, any, error) {
+	return p.memoize(21, src, (*parser).m_MainStmts)
+}
+
+func (p *parser) m_MainStmts(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_MainStmts_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "MainStmts")
+	}
+	return
+}
+
+func (p *parser) x_MainStmts_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_FunctionLit(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_CallOrConversion(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_MainStmts_5(src []*types.Token) (n int, result any, err error) {
+	return p.matchLiteral(src, token.IDENT, "async")
+}
+
+func (p *parser) x_MainStmts_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).x_MainStmts_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchLiteral(src[n:], token.IDENT, "func")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_MainStmts_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_MainStmts_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.lookahead(src, (*parser).x_MainStmts_4, true); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_MainStmts_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_MainStmts_2(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_StatementList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Block(src []*types.Token) (int, any, error) {
+	return p.memoize(22, src, (*parser).m_Block)
+}
+
+func (p *parser) m_Block(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Block_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Block")
+	}
+	return
+}
+
+func (p *parser) x_Block_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_StatementList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_StatementList(src []*types.Token) (int, any, error) {
+	return p.memoize(23, src, (*parser).m_StatementList)
+}
+
+func (p *parser) m_StatementList(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.repeat0(src, (*parser).x_StatementList_1)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "StatementList")
+	}
+	return
+}
+
+func (p *parser) x_StatementList_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Statement(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Statement(src []*types.Token) (int, any, error) {
+	return p.memoize(24, src, (*parser).m_Statement)
+}
+
+func (p *parser) m_Statement(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Statement_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Statement")
+	}
+	return
+}
+
+func (p *parser) x_Statement_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.lookahead(src, (*parser).r_commandStart, true)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_SimpleStmt(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Statement_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_Declaration(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ReturnStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_BreakStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ContinueStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_GotoStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_FallthroughStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_IfStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_SwitchStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ForStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_DeferStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_Block(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_LabeledStmt(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_CommandStmt(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_Statement_2(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_ReturnStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(25, src, (*parser).m_ReturnStmt)
+}
+
+func (p *parser) m_ReturnStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ReturnStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ReturnStmt")
+	}
+	return
+}
+
+func (p *parser) x_ReturnStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "return")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_LambdaExprList)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_BreakStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(26, src, (*parser).m_BreakStmt)
+}
+
+func (p *parser) m_BreakStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_BreakStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "BreakStmt")
+	}
+	return
+}
+
+func (p *parser) x_BreakStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "break")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_ident)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ContinueStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(27, src, (*parser).m_ContinueStmt)
+}
+
+func (p *parser) m_ContinueStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ContinueStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ContinueStmt")
+	}
+	return
+}
+
+func (p *parser) x_ContinueStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "continue")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_ident)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_GotoStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(28, src, (*parser).m_GotoStmt)
+}
+
+func (p *parser) m_GotoStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_GotoStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "GotoStmt")
+	}
+	return
+}
+
+func (p *parser) x_GotoStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "goto")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_FallthroughStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(29, src, (*parser).m_FallthroughStmt)
+}
+
+func (p *parser) m_FallthroughStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.matchLiteral(src, token.IDENT, "fallthrough")
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "FallthroughStmt")
+	}
+	return
+}
+
+func (p *parser) r_IfStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(30, src, (*parser).m_IfStmt)
+}
+
+func (p *parser) m_IfStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_IfStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "IfStmt")
+	}
+	return
+}
+
+func (p *parser) x_IfStmt_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_SimpleStmt(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_IfStmt_4(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_IfStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_Block(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_IfStmt_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "else")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_IfStmt_4(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_IfStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 5)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "if")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_IfStmt_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_Expression(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.r_Block(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[4], err1 = p.repeat01(src[n:], (*parser).x_IfStmt_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_SwitchStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(31, src, (*parser).m_SwitchStmt)
+}
+
+func (p *parser) m_SwitchStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_SwitchStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "SwitchStmt")
+	}
+	return
+}
+
+func (p *parser) x_SwitchStmt_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_SimpleStmt(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_SwitchStmt_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_TypeSwitchGuard(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat0(src[n:], (*parser).r_TypeCaseClause)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_SwitchStmt_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).r_Expression)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat0(src[n:], (*parser).r_CaseClause)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_SwitchStmt_3(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_SwitchStmt_4(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_SwitchStmt_5(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_SwitchStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "switch")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_SwitchStmt_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.x_SwitchStmt_3(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_TypeSwitchGuard(src []*types.Token) (int, any, error) {
+	return p.memoize(32, src, (*parser).m_TypeSwitchGuard)
+}
+
+func (p *parser) m_TypeSwitchGuard(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_TypeSwitchGuard_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "TypeSwitchGuard")
+	}
+	return
+}
+
+func (p *parser) x_TypeSwitchGuard_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], token.DEFINE)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TypeSwitchGuard_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_PrimarySuffix(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.lookahead(src[n:], (*parser).r_PrimarySuffix, false)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TypeSwitchGuard_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 7)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).x_TypeSwitchGuard_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_primaryHead(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat0(src[n:], (*parser).x_TypeSwitchGuard_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], '.')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[4], err1 = p.matchToken(src[n:], '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[5], err1 = p.matchLiteral(src[n:], token.IDENT, "type")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[6], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_CaseClause(src []*types.Token) (int, any, error) {
+	return p.memoize(33, src, (*parser).m_CaseClause)
+}
+
+func (p *parser) m_CaseClause(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_CaseClause_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "CaseClause")
+	}
+	return
+}
+
+func (p *parser) x_CaseClause_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "case")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ExpressionList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_CaseClause_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_CaseClause_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "default"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_CaseClause_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_CaseClause_2(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_StatementList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_TypeCaseClause(src []*types.Token) (int, any, error) {
+	return p.memoize(34, src, (*parser).m_TypeCaseClause)
+}
+
+func (p *parser) m_TypeCaseClause(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_TypeCaseClause_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "TypeCaseClause")
+	}
+	return
+}
+
+func (p *parser) x_TypeCaseClause_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TypeCaseClause_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Type(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_TypeCaseClause_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TypeCaseClause_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "case")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_TypeCaseClause_4(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TypeCaseClause_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_TypeCaseClause_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "default"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_TypeCaseClause_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_TypeCaseClause_2(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_StatementList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ForStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(35, src, (*parser).m_ForStmt)
+}
+
+func (p *parser) m_ForStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ForStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ForStmt")
+	}
+	return
+}
+
+func (p *parser) x_ForStmt_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ForVars(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchLiteral(src[n:], token.IDENT, "in")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_RangeExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ForStmt_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_ForStmt_3(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.repeat01(src, (*parser).r_RangeExpr); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ForStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "for")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_ForStmt_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_Block(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ForVars(src []*types.Token) (int, any, error) {
+	return p.memoize(36, src, (*parser).m_ForVars)
+}
+
+func (p *parser) m_ForVars(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ForVars_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ForVars")
+	}
+	return
+}
+
+func (p *parser) x_ForVars_4(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_ident(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_TupleVars(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ForVars_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_ForVars_4(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ForVars_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_ForVars_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ForVars_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_ForVars_2(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_TupleVars(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_TupleVars(src []*types.Token) (int, any, error) {
+	return p.memoize(37, src, (*parser).m_TupleVars)
+}
+
+func (p *parser) m_TupleVars(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_TupleVars_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "TupleVars")
+	}
+	return
+}
+
+func (p *parser) x_TupleVars_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TupleVars_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat1(src[n:], (*parser).x_TupleVars_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_DeferStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(38, src, (*parser).m_DeferStmt)
+}
+
+func (p *parser) m_DeferStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_DeferStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "DeferStmt")
+	}
+	return
+}
+
+func (p *parser) x_DeferStmt_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_PrimarySuffix(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.lookahead(src[n:], (*parser).r_PrimarySuffix, false)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_DeferStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "defer")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_primaryHead(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat0(src[n:], (*parser).x_DeferStmt_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.r_CallOrConversion(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_LabeledStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(39, src, (*parser).m_LabeledStmt)
+}
+
+func (p *parser) m_LabeledStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_LabeledStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "LabeledStmt")
+	}
+	return
+}
+
+func (p *parser) x_LabeledStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_Statement(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_SimpleStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(40, src, (*parser).m_SimpleStmt)
+}
+
+func (p *parser) m_SimpleStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_SimpleStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "SimpleStmt")
+	}
+	return
+}
+
+func (p *parser) x_SimpleStmt_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_SendStmt(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ShortVarDecl(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_IncDecStmt(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_Assignment(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ExpressionStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_EmptyStmt(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_SendStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(41, src, (*parser).m_SendStmt)
+}
+
+func (p *parser) m_SendStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_SendStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "SendStmt")
+	}
+	return
+}
+
+func (p *parser) x_SendStmt_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '.')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_SendStmt_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_LambdaExpr(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], token.ELLIPSIS)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_SendStmt_3(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_SendStmt_4(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_LambdaExprList(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_SendStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_SendStmt_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], token.ARROW)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.x_SendStmt_3(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ShortVarDecl(src []*types.Token) (int, any, error) {
+	return p.memoize(42, src, (*parser).m_ShortVarDecl)
+}
+
+func (p *parser) m_ShortVarDecl(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ShortVarDecl_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ShortVarDecl")
+	}
+	return
+}
+
+func (p *parser) x_ShortVarDecl_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_IdentifierList(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], token.DEFINE)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_ExpressionList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_IncDecStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(43, src, (*parser).m_IncDecStmt)
+}
+
+func (p *parser) m_IncDecStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_IncDecStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "IncDecStmt")
+	}
+	return
+}
+
+func (p *parser) x_IncDecStmt_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, token.INC); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.DEC); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_IncDecStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Expression(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_IncDecStmt_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Assignment(src []*types.Token) (int, any, error) {
+	return p.memoize(44, src, (*parser).m_Assignment)
+}
+
+func (p *parser) m_Assignment(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Assignment_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Assignment")
+	}
+	return
+}
+
+func (p *parser) x_Assignment_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, '='); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.ADD_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.SUB_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.OR_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.XOR_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.MUL_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.QUO_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.REM_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.SHL_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.SHR_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.AND_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.AND_NOT_ASSIGN); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_Assignment_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ExpressionList(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_Assignment_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_LambdaExprList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ExpressionStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(45, src, (*parser).m_ExpressionStmt)
+}
+
+func (p *parser) m_ExpressionStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.r_Expression(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ExpressionStmt")
+	}
+	return
+}
+
+func (p *parser) r_CommandStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(46, src, (*parser).m_CommandStmt)
+}
+
+func (p *parser) m_CommandStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_CommandStmt_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "CommandStmt")
+	}
+	return
+}
+
+func (p *parser) x_CommandStmt_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '.')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_CommandStmt_3(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, token.ELLIPSIS)
+}
+
+func (p *parser) x_CommandStmt_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 5)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_CommandStmt_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchSpace(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.r_LambdaExprList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[4], err1 = p.repeat01(src[n:], (*parser).x_CommandStmt_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_commandStart(src []*types.Token) (int, any, error) {
+	return p.memoize(47, src, (*parser).m_commandStart)
+}
+
+func (p *parser) m_commandStart(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_commandStart_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "commandStart")
+	}
+	return
+}
+
+func (p *parser) x_commandStart_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '.')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_commandStart_3(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, '('); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '['); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '{'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '!'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_commandStart_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_commandStart_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchSpace(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.x_commandStart_3(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_EmptyStmt(src []*types.Token) (int, any, error) {
+	return p.memoize(48, src, (*parser).m_EmptyStmt)
+}
+
+func (p *parser) m_EmptyStmt(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.matchTrue(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "EmptyStmt")
+	}
+	return
+}
+
+func (p *parser) r_NoParenType(src []*types.Token) (int, any, error) {
+	return p.memoize(49, src, (*parser).m_NoParenType)
+}
+
+func (p *parser) m_NoParenType(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_NoParenType_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "NoParenType")
+	}
+	return
+}
+
+func (p *parser) x_NoParenType_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_TypeLit(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_TypeName(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_Type(src []*types.Token) (int, any, error) {
+	return p.memoize(50, src, (*parser).m_Type)
+}
+
+func (p *parser) m_Type(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Type_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Type")
+	}
+	return
+}
+
+func (p *parser) x_Type_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Type_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Type(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_Type_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Type_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_Type_3(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Type_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_TypeLit(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_TypeName(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_Type_2(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_TypeName(src []*types.Token) (int, any, error) {
+	return p.memoize(51, src, (*parser).m_TypeName)
+}
+
+func (p *parser) m_TypeName(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_TypeName_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "TypeName")
+	}
+	return
+}
+
+func (p *parser) x_TypeName_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '.')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_TypeName_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_TypeName_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_TypeLit(src []*types.Token) (int, any, error) {
+	return p.memoize(52, src, (*parser).m_TypeLit)
+}
+
+func (p *parser) m_TypeLit(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_TypeLit_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "TypeLit")
+	}
+	return
+}
+
+func (p *parser) x_TypeLit_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_PointerType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ArrayType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_MapType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_FuncType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_StructType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_InterfaceType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_PointerType(src []*types.Token) (int, any, error) {
+	return p.memoize(53, src, (*parser).m_PointerType)
+}
+
+func (p *parser) m_PointerType(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_PointerType_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "PointerType")
+	}
+	return
+}
+
+func (p *parser) x_PointerType_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '*')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ArrayType(src []*types.Token) (int, any, error) {
+	return p.memoize(54, src, (*parser).m_ArrayType)
+}
+
+func (p *parser) m_ArrayType(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ArrayType_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ArrayType")
+	}
+	return
+}
+
+func (p *parser) x_ArrayType_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '[')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_Expression)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ']')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_MapType(src []*types.Token) (int, any, error) {
+	return p.memoize(55, src, (*parser).m_MapType)
+}
+
+func (p *parser) m_MapType(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_MapType_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "MapType")
+	}
+	return
+}
+
+func (p *parser) x_MapType_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 5)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "map")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], '[')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], ']')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[4], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_FuncType(src []*types.Token) (int, any, error) {
+	return p.memoize(56, src, (*parser).m_FuncType)
+}
+
+func (p *parser) m_FuncType(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_FuncType_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "FuncType")
+	}
+	return
+}
+
+func (p *parser) x_FuncType_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "func")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Signature(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_StructType(src []*types.Token) (int, any, error) {
+	return p.memoize(57, src, (*parser).m_StructType)
+}
+
+func (p *parser) m_StructType(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_StructType_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "StructType")
+	}
+	return
+}
+
+func (p *parser) x_StructType_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_FieldDecl(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_StructType_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "struct")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat0(src[n:], (*parser).x_StructType_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_FieldDecl(src []*types.Token) (int, any, error) {
+	return p.memoize(58, src, (*parser).m_FieldDecl)
+}
+
+func (p *parser) m_FieldDecl(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_FieldDecl_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "FieldDecl")
+	}
+	return
+}
+
+func (p *parser) x_FieldDecl_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '*')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_TypeName(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_FieldDecl_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_FieldDecl_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_FieldsOrNonPtrEmbeddedField(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_FieldDecl_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_FieldDecl_2(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_Tag)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_FieldsOrNonPtrEmbeddedField(src []*types.Token) (int, any, error) {
+	return p.memoize(59, src, (*parser).m_FieldsOrNonPtrEmbeddedField)
+}
+
+func (p *parser) m_FieldsOrNonPtrEmbeddedField(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_FieldsOrNonPtrEmbeddedField_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "FieldsOrNonPtrEmbeddedField")
+	}
+	return
+}
+
+func (p *parser) x_FieldsOrNonPtrEmbeddedField_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '.')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_FieldsOrNonPtrEmbeddedField_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_FieldsOrNonPtrEmbeddedField_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat1(src, (*parser).x_FieldsOrNonPtrEmbeddedField_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_FieldsOrNonPtrEmbeddedField_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_FieldsOrNonPtrEmbeddedField_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_FieldsOrNonPtrEmbeddedField_4(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.repeat01(src, (*parser).r_Type); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_FieldsOrNonPtrEmbeddedField_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_FieldsOrNonPtrEmbeddedField_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Tag(src []*types.Token) (int, any, error) {
+	return p.memoize(60, src, (*parser).m_Tag)
+}
+
+func (p *parser) m_Tag(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.matchToken(src, token.STRING)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Tag")
+	}
+	return
+}
+
+func (p *parser) r_InterfaceType(src []*types.Token) (int, any, error) {
+	return p.memoize(61, src, (*parser).m_InterfaceType)
+}
+
+func (p *parser) m_InterfaceType(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_InterfaceType_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "InterfaceType")
+	}
+	return
+}
+
+func (p *parser) x_InterfaceType_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_InterfaceElem(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_InterfaceType_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "interface")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat0(src[n:], (*parser).x_InterfaceType_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_InterfaceElem(src []*types.Token) (int, any, error) {
+	return p.memoize(62, src, (*parser).m_InterfaceElem)
+}
+
+func (p *parser) m_InterfaceElem(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_InterfaceElem_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "InterfaceElem")
+	}
+	return
+}
+
+func (p *parser) x_InterfaceElem_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_MethodElem(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_TypeName(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_MethodElem(src []*types.Token) (int, any, error) {
+	return p.memoize(63, src, (*parser).m_MethodElem)
+}
+
+func (p *parser) m_MethodElem(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_MethodElem_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "MethodElem")
+	}
+	return
+}
+
+func (p *parser) x_MethodElem_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Signature(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_LambdaExpr(src []*types.Token) (int, any, error) {
+	return p.memoize(64, src, (*parser).m_LambdaExpr)
+}
+
+func (p *parser) m_LambdaExpr(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_LambdaExpr_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "LambdaExpr")
+	}
+	return
+}
+
+func (p *parser) x_LambdaExpr_6(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaExpr_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_ident(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_LambdaExpr_6)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaExpr_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_LambdaExpr_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaExpr_3(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_LambdaExpr_4(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.repeat01(src, (*parser).r_ident); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_LambdaExpr_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_LambdaExpr_3(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], token.DRARROW)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_LambdaBody(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaExpr_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_LambdaExpr_2(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_Expression(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_LambdaBody(src []*types.Token) (int, any, error) {
+	return p.memoize(65, src, (*parser).m_LambdaBody)
+}
+
+func (p *parser) m_LambdaBody(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_LambdaBody_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "LambdaBody")
+	}
+	return
+}
+
+func (p *parser) x_LambdaBody_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaBody_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_LambdaExpr(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_LambdaBody_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaBody_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_LambdaBody_3(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaBody_6(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, '(')
+}
+
+func (p *parser) x_LambdaBody_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.lookahead(src, (*parser).x_LambdaBody_6, true)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_LambdaBody_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_Block(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_LambdaBody_2(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_LambdaBody_5(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_RangeExpr(src []*types.Token) (int, any, error) {
+	return p.memoize(66, src, (*parser).m_RangeExpr)
+}
+
+func (p *parser) m_RangeExpr(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_RangeExpr_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "RangeExpr")
+	}
+	return
+}
+
+func (p *parser) x_RangeExpr_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Expression(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_rangeExprEnd)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_RangeExpr_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_rangeExprEnd(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_RangeExpr_2(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_rangeExprEnd(src []*types.Token) (int, any, error) {
+	return p.memoize(67, src, (*parser).m_rangeExprEnd)
+}
+
+func (p *parser) m_rangeExprEnd(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_rangeExprEnd_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "rangeExprEnd")
+	}
+	return
+}
+
+func (p *parser) x_rangeExprEnd_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Expression(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_rangeExprEnd_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Expression(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat01(src[n:], (*parser).x_rangeExprEnd_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Expression(src []*types.Token) (int, any, error) {
+	return p.memoize(68, src, (*parser).m_Expression)
+}
+
+func (p *parser) m_Expression(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Expression_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Expression")
+	}
+	return
+}
+
+func (p *parser) x_Expression_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, token.LAND)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_cmpExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Expression_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_cmpExpr(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_Expression_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Expression_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, token.LOR)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_Expression_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Expression_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_Expression_2(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_Expression_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_cmpExpr(src []*types.Token) (int, any, error) {
+	return p.memoize(69, src, (*parser).m_cmpExpr)
+}
+
+func (p *parser) m_cmpExpr(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_cmpExpr_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "cmpExpr")
+	}
+	return
+}
+
+func (p *parser) x_cmpExpr_3(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, token.EQ); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.NE); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '<'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.LE); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '>'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.GE); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_cmpExpr_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_cmpExpr_3(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_mathExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_cmpExpr_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_mathExpr(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_cmpExpr_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_mathExpr(src []*types.Token) (int, any, error) {
+	return p.memoize(70, src, (*parser).m_mathExpr)
+}
+
+func (p *parser) m_mathExpr(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_mathExpr_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "mathExpr")
+	}
+	return
+}
+
+func (p *parser) x_mathExpr_4(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, '*'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '/'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '%'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.SHL); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.SHR); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '&'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.AND_NOT); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_mathExpr_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_mathExpr_4(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_UnaryExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_mathExpr_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_UnaryExpr(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_mathExpr_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_mathExpr_6(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, '+'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '-'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '|'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '^'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_mathExpr_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_mathExpr_6(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_mathExpr_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_mathExpr_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_mathExpr_2(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_mathExpr_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_UnaryExpr(src []*types.Token) (int, any, error) {
+	return p.memoize(71, src, (*parser).m_UnaryExpr)
+}
+
+func (p *parser) m_UnaryExpr(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_UnaryExpr_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "UnaryExpr")
+	}
+	return
+}
+
+func (p *parser) x_UnaryExpr_4(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_ident(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '('); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_UnaryExpr_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "await")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchSpace(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.lookahead(src[n:], (*parser).x_UnaryExpr_4, false)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_UnaryExpr_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).x_UnaryExpr_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_PrimaryExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_UnaryExpr_6(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, '-'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '!'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '^'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '*'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '&'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, '+'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_UnaryExpr_5(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_UnaryExpr_6(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_UnaryExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_UnaryExpr_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_UnaryExpr_2(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_UnaryExpr_5(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_PrimaryExpr(src []*types.Token) (int, any, error) {
+	return p.memoize(72, src, (*parser).m_PrimaryExpr)
+}
+
+func (p *parser) m_PrimaryExpr(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_PrimaryExpr_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "PrimaryExpr")
+	}
+	return
+}
+
+func (p *parser) x_PrimaryExpr_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Operand(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).r_PrimarySuffix)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_PrimarySuffix(src []*types.Token) (int, any, error) {
+	return p.memoize(73, src, (*parser).m_PrimarySuffix)
+}
+
+func (p *parser) m_PrimarySuffix(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_PrimarySuffix_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "PrimarySuffix")
+	}
+	return
+}
+
+func (p *parser) x_PrimarySuffix_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_CallOrConversion(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_SelectorOrTypeAssertion(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_IndexOrSlice(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ErrWrap(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_primaryHead(src []*types.Token) (int, any, error) {
+	return p.memoize(74, src, (*parser).m_primaryHead)
+}
+
+func (p *parser) m_primaryHead(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_primaryHead_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "primaryHead")
+	}
+	return
+}
+
+func (p *parser) x_primaryHead_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Expression(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_primaryHead_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_ident(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_FunctionLit(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_primaryHead_2(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_Operand(src []*types.Token) (int, any, error) {
+	return p.memoize(75, src, (*parser).m_Operand)
+}
+
+func (p *parser) m_Operand(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Operand_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Operand")
+	}
+	return
+}
+
+func (p *parser) x_Operand_3(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, token.UNIT)
+}
+
+func (p *parser) x_Operand_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, token.INT)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_Operand_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Operand_5(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, token.UNIT)
+}
+
+func (p *parser) x_Operand_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, token.FLOAT)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_Operand_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Operand_8(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Operand_7(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.repeat1(src, (*parser).r_ForPhrase); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.repeat0(src, (*parser).x_Operand_8); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_Operand_6(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.x_Operand_7(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Operand_9(src []*types.Token) (n int, result any, err error) {
+	return p.matchLiteral(src, token.IDENT, "c")
+}
+
+func (p *parser) x_Operand_10(src []*types.Token) (n int, result any, err error) {
+	return p.matchString(src, '"')
+}
+
+func (p *parser) x_Operand_11(src []*types.Token) (n int, result any, err error) {
+	return p.matchLiteral(src, token.IDENT, "py")
+}
+
+func (p *parser) x_Operand_12(src []*types.Token) (n int, result any, err error) {
+	return p.matchString(src, '"')
+}
+
+func (p *parser) x_Operand_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_Operand_2(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_Operand_4(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.STRING); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.CHAR); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.RAT); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.IMAG); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_Operand_6(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ListComprehension(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_MapComprehension(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_LiteralValue(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_CompositeLit(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_FunctionLit(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_Env(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.adjoin(src, (*parser).x_Operand_9, (*parser).x_Operand_10); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.adjoin(src, (*parser).x_Operand_11, (*parser).x_Operand_12); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ListCompositeLit(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_DomainTextLit(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_NamedCompositeLit(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ident(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_Env(src []*types.Token) (int, any, error) {
+	return p.memoize(76, src, (*parser).m_Env)
+}
+
+func (p *parser) m_Env(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Env_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Env")
+	}
+	return
+}
+
+func (p *parser) x_Env_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ident(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_Env_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_Env_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_ident(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_Env_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '$')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_Env_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_DomainTextLit(src []*types.Token) (int, any, error) {
+	return p.memoize(77, src, (*parser).m_DomainTextLit)
+}
+
+func (p *parser) m_DomainTextLit(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.adjoin(src, (*parser).r_ident, (*parser).x_DomainTextLit_1)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "DomainTextLit")
+	}
+	return
+}
+
+func (p *parser) x_DomainTextLit_1(src []*types.Token) (n int, result any, err error) {
+	return p.matchString(src, '`')
+}
+
+func (p *parser) r_NamedCompositeLit(src []*types.Token) (int, any, error) {
+	return p.memoize(78, src, (*parser).m_NamedCompositeLit)
+}
+
+func (p *parser) m_NamedCompositeLit(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_NamedCompositeLit_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "NamedCompositeLit")
+	}
+	return
+}
+
+func (p *parser) x_NamedCompositeLit_2(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, '{')
+}
+
+func (p *parser) x_NamedCompositeLit_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.adjoin(src, (*parser).r_TypeName, (*parser).x_NamedCompositeLit_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ElementList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_CompositeLit(src []*types.Token) (int, any, error) {
+	return p.memoize(79, src, (*parser).m_CompositeLit)
+}
+
+func (p *parser) m_CompositeLit(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_CompositeLit_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "CompositeLit")
+	}
+	return
+}
+
+func (p *parser) x_CompositeLit_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_MapType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_StructType(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_CompositeLit_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_CompositeLit_2(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LiteralValue(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ListComprehension(src []*types.Token) (int, any, error) {
+	return p.memoize(80, src, (*parser).m_ListComprehension)
+}
+
+func (p *parser) m_ListComprehension(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ListComprehension_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ListComprehension")
+	}
+	return
+}
+
+func (p *parser) x_ListComprehension_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '[')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat1(src[n:], (*parser).r_ForPhrase)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.matchToken(src[n:], ']')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_MapComprehension(src []*types.Token) (int, any, error) {
+	return p.memoize(81, src, (*parser).m_MapComprehension)
+}
+
+func (p *parser) m_MapComprehension(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_MapComprehension_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "MapComprehension")
+	}
+	return
+}
+
+func (p *parser) x_MapComprehension_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Expression(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_MapComprehension_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 5)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_MapComprehension_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.repeat1(src[n:], (*parser).r_ForPhrase)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[4], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ForPhrase(src []*types.Token) (int, any, error) {
+	return p.memoize(82, src, (*parser).m_ForPhrase)
+}
+
+func (p *parser) m_ForPhrase(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ForPhrase_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ForPhrase")
+	}
+	return
+}
+
+func (p *parser) x_ForPhrase_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchLiteral(src, token.IDENT, "in"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, token.ARROW); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ForPhrase_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_SimpleStmt(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ';')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ForPhrase_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "if")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_ForPhrase_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_Expression(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ForPhrase_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 5)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchLiteral(src, token.IDENT, "for")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ForVars(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.x_ForPhrase_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.r_RangeExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[4], err1 = p.repeat01(src[n:], (*parser).x_ForPhrase_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ListCompositeLit(src []*types.Token) (int, any, error) {
+	return p.memoize(83, src, (*parser).m_ListCompositeLit)
+}
+
+func (p *parser) m_ListCompositeLit(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ListCompositeLit_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ListCompositeLit")
+	}
+	return
+}
+
+func (p *parser) x_ListCompositeLit_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ListCompositeLit_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_LambdaExpr(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_ListCompositeLit_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ListCompositeLit_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, token.ELLIPSIS); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_ListCompositeLit_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ListCompositeLit_7(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, ']')
+}
+
+func (p *parser) x_ListCompositeLit_6(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.adjoin(src, (*parser).x_ListCompositeLit_7, (*parser).r_Type)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LiteralValue(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ListCompositeLit_5(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_ListCompositeLit_6(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchToken(src, ']'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_ListCompositeLit_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '[')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_ListCompositeLit_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.x_ListCompositeLit_5(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_LiteralValue(src []*types.Token) (int, any, error) {
+	return p.memoize(84, src, (*parser).m_LiteralValue)
+}
+
+func (p *parser) m_LiteralValue(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_LiteralValue_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "LiteralValue")
+	}
+	return
+}
+
+func (p *parser) x_LiteralValue_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '{')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_ElementList(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], '}')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ElementList(src []*types.Token) (int, any, error) {
+	return p.memoize(85, src, (*parser).m_ElementList)
+}
+
+func (p *parser) m_ElementList(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.repeat01(src, (*parser).x_ElementList_1)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ElementList")
+	}
+	return
+}
+
+func (p *parser) x_ElementList_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_KeyedElement(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ElementList_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_KeyedElement(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_ElementList_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ElementList_4(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, ',')
+}
+
+func (p *parser) x_ElementList_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_ElementList_2(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_ElementList_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_KeyedElement(src []*types.Token) (int, any, error) {
+	return p.memoize(86, src, (*parser).m_KeyedElement)
+}
+
+func (p *parser) m_KeyedElement(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_KeyedElement_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "KeyedElement")
+	}
+	return
+}
+
+func (p *parser) x_KeyedElement_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Key(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_KeyedElement_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).x_KeyedElement_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Element(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_Key(src []*types.Token) (int, any, error) {
+	return p.memoize(87, src, (*parser).m_Key)
+}
+
+func (p *parser) m_Key(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Key_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Key")
+	}
+	return
+}
+
+func (p *parser) x_Key_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_LiteralValue(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_Expression(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_Element(src []*types.Token) (int, any, error) {
+	return p.memoize(88, src, (*parser).m_Element)
+}
+
+func (p *parser) m_Element(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_Element_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "Element")
+	}
+	return
+}
+
+func (p *parser) x_Element_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_LiteralValue(src); err == nil {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.r_LambdaExpr(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_FunctionLit(src []*types.Token) (int, any, error) {
+	return p.memoize(89, src, (*parser).m_FunctionLit)
+}
+
+func (p *parser) m_FunctionLit(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_FunctionLit_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "FunctionLit")
+	}
+	return
+}
+
+func (p *parser) x_FunctionLit_2(src []*types.Token) (n int, result any, err error) {
+	return p.matchLiteral(src, token.IDENT, "async")
+}
+
+func (p *parser) x_FunctionLit_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 4)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.repeat01(src, (*parser).x_FunctionLit_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchLiteral(src[n:], token.IDENT, "func")
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.r_Signature(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[3], err1 = p.r_Block(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_CallOrConversion(src []*types.Token) (int, any, error) {
+	return p.memoize(90, src, (*parser).m_CallOrConversion)
+}
+
+func (p *parser) m_CallOrConversion(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_CallOrConversion_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "CallOrConversion")
+	}
+	return
+}
+
+func (p *parser) x_CallOrConversion_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ',')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_LambdaExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_CallOrConversion_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_LambdaExpr(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat0(src[n:], (*parser).x_CallOrConversion_4)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_CallOrConversion_5(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, token.ELLIPSIS)
+}
+
+func (p *parser) x_CallOrConversion_6(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, ',')
+}
+
+func (p *parser) x_CallOrConversion_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.x_CallOrConversion_3(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_CallOrConversion_5)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.repeat01(src[n:], (*parser).x_CallOrConversion_6)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_CallOrConversion_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_CallOrConversion_2)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_SelectorOrTypeAssertion(src []*types.Token) (int, any, error) {
+	return p.memoize(91, src, (*parser).m_SelectorOrTypeAssertion)
+}
+
+func (p *parser) m_SelectorOrTypeAssertion(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_SelectorOrTypeAssertion_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "SelectorOrTypeAssertion")
+	}
+	return
+}
+
+func (p *parser) x_SelectorOrTypeAssertion_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '(')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_Type(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ')')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_SelectorOrTypeAssertion_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.r_ident(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "goto"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "break"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "continue"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "fallthrough"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "map"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_SelectorOrTypeAssertion_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_SelectorOrTypeAssertion_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '.')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_SelectorOrTypeAssertion_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_IndexOrSlice(src []*types.Token) (int, any, error) {
+	return p.memoize(92, src, (*parser).m_IndexOrSlice)
+}
+
+func (p *parser) m_IndexOrSlice(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_IndexOrSlice_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "IndexOrSlice")
+	}
+	return
+}
+
+func (p *parser) x_IndexOrSlice_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_Expression)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_IndexOrSlice_6(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).r_Expression)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_IndexOrSlice_7(src []*types.Token) (n int, result any, err error) {
+	return p.matchToken(src, ',')
+}
+
+func (p *parser) x_IndexOrSlice_5(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_IndexOrSlice_6(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.repeat01(src, (*parser).x_IndexOrSlice_7); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_IndexOrSlice_4(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.r_Expression(src)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_IndexOrSlice_5(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_IndexOrSlice_2(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.x_IndexOrSlice_3(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_IndexOrSlice_4(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) x_IndexOrSlice_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 3)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '[')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.x_IndexOrSlice_2(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[2], err1 = p.matchToken(src[n:], ']')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_ErrWrap(src []*types.Token) (int, any, error) {
+	return p.memoize(93, src, (*parser).m_ErrWrap)
+}
+
+func (p *parser) m_ErrWrap(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ErrWrap_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ErrWrap")
+	}
+	return
+}
+
+func (p *parser) x_ErrWrap_3(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, ':')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.r_UnaryExpr(src[n:])
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ErrWrap_2(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.matchToken(src, '?')
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.repeat01(src[n:], (*parser).x_ErrWrap_3)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) x_ErrWrap_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchToken(src, '!'); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.x_ErrWrap_2(src); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
+
+func (p *parser) r_ident(src []*types.Token) (int, any, error) {
+	return p.memoize(94, src, (*parser).m_ident)
+}
+
+func (p *parser) m_ident(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_ident_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "ident")
+	}
+	return
+}
+
+func (p *parser) x_ident_1(src []*types.Token) (n int, result any, err error) {
+	rets := make([]any, 2)
+	var n1 int
+	var err1 error
+	n1, rets[0], err1 = p.lookahead(src, (*parser).r_keyword, true)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	n1, rets[1], err1 = p.matchToken(src[n:], token.IDENT)
+	if err1 != nil {
+		if !isDyn(err1) {
+			return n + n1, nil, err1
+		}
+		err = err1
+	}
+	n += n1
+	return n, rets, err
+}
+
+func (p *parser) r_keyword(src []*types.Token) (int, any, error) {
+	return p.memoize(95, src, (*parser).m_keyword)
+}
+
+func (p *parser) m_keyword(src []*types.Token) (n int, result any, err error) {
+	n, result, err = p.x_keyword_1(src)
+	if err == errMultiMismatch {
+		err = p.expectRule(src, "keyword")
+	}
+	return
+}
+
+func (p *parser) x_keyword_1(src []*types.Token) (n int, result any, err error) {
+	c := choiceState{nMax: -1, multiErr: true}
+	if n, result, err = p.matchLiteral(src, token.IDENT, "break"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "case"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "chan"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "const"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "continue"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "default"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "defer"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "else"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "fallthrough"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "for"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "func"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "go"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "goto"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "if"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "import"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "interface"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "map"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "package"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "range"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "return"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "select"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "struct"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "switch"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "type"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	if n, result, err = p.matchLiteral(src, token.IDENT, "var"); err == nil || n > 0 {
+		return
+	}
+	c.add(n, err)
+	return c.result()
+}
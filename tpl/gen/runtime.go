@@ -0,0 +1,404 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gen
+
+// runtime is the runtime of generated parsers. It follows the matching
+// semantics of package tpl/matcher, so a generated parser and tpl.Compiler
+// produce the same results and errors.
+const runtime = `// Config represents a parsing configuration.
+type Config struct {
+	ScanErrorHandler scanner.ErrorHandler
+	ScanMode         scanner.Mode
+	Fset             *token.FileSet
+}
+
+// ParseExpr parses an expression.
+func ParseExpr(x string, conf *Config) (result any, err error) {
+	return ParseExprFrom("", x, conf)
+}
+
+// ParseExprFrom parses an expression from a file.
+func ParseExprFrom(filename string, src any, conf *Config) (result any, err error) {
+	p, result, err := match(filename, src, conf)
+	if err != nil {
+		return
+	}
+	if len(p.toks) == p.n || isEOL(p.toks[p.n].Tok) {
+		return
+	}
+	t := p.next()
+	err = p.newErrorf(t.Pos, "unexpected token: %v", t)
+	return
+}
+
+// Parse parses a source file.
+func Parse(filename string, src any, conf *Config) (result any, err error) {
+	p, result, err := match(filename, src, conf)
+	if err != nil {
+		return
+	}
+	if len(p.toks) > p.n {
+		t := p.next()
+		err = p.newErrorf(t.Pos, "unexpected token: %v", t)
+	}
+	return
+}
+
+func match(filename string, src any, conf *Config) (p *parser, result any, err error) {
+	b, err := iox.ReadSourceLocal(filename, src)
+	if err != nil {
+		return
+	}
+	if conf == nil {
+		conf = &Config{}
+	}
+	fset := conf.Fset
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	f := fset.AddFile(filename, fset.Base(), len(b))
+	var s scanner.Scanner
+	s.Init(f, b, conf.ScanErrorHandler, conf.ScanMode)
+	var toks []*types.Token
+	for {
+		t := s.Scan()
+		if t.Tok == token.EOF {
+			break
+		}
+		toks = append(toks, &t)
+	}
+	p = &parser{
+		fset:    fset,
+		fileEnd: token.Pos(f.Base() + len(b)),
+		toks:    toks,
+		left:    len(toks),
+		memo:    make(map[ruleKey]memoEntry),
+		seeds:   make(map[ruleKey]*memoEntry),
+	}
+	p.n, result, err = p.doc(toks)
+	p.setLastError(len(toks)-p.n, err)
+	if e, ok := err.(*expectError); ok {
+		err = &matcher.Error{Fset: fset, Pos: e.pos, Msg: e.Error()}
+	}
+	return
+}
+
+func isEOL(tok token.Token) bool {
+	return tok == token.SEMICOLON || tok == token.EOF
+}
+
+var (
+	errNoWhitespace  = errors.New("no whitespace")
+	errAdjoinEmpty   = errors.New("adjoin empty")
+	errMultiMismatch = errors.New("multiple mismatch")
+	errLeftRec       = errors.New("left recursion")
+)
+
+func isDyn(err error) bool {
+	if e, ok := err.(*matcher.Error); ok {
+		return e.Dyn
+	}
+	return false
+}
+
+type ruleKey struct {
+	rule int
+	left int // number of tokens left, that is, the token offset
+}
+
+type memoEntry struct {
+	n      int
+	result any
+	err    error
+}
+
+type parser struct {
+	fset    *token.FileSet
+	fileEnd token.Pos
+	toks    []*types.Token
+	n       int // number of matched tokens
+	left    int
+	lastErr error
+	memo    map[ruleKey]memoEntry
+	seeds   map[ruleKey]*memoEntry // seeds of left-recursive rules being grown
+	growing int                    // number of seeds being grown
+}
+
+func (p *parser) next() *types.Token {
+	if n := p.left; n > 0 {
+		return p.toks[len(p.toks)-n]
+	}
+	return &types.Token{Tok: token.EOF, Pos: p.fileEnd}
+}
+
+func (p *parser) setLastError(left int, err error) {
+	if left < p.left {
+		p.left, p.lastErr = left, err
+	}
+}
+
+func (p *parser) newErrorf(pos token.Pos, format string, args ...any) error {
+	return &matcher.Error{Fset: p.fset, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// expectError represents an error "expect X, but got Y". Its message is
+// formatted lazily, since most of matching errors are discarded when
+// backtracking.
+type expectError struct {
+	pos    token.Pos
+	expect string
+	got    *types.Token // nil means EOF
+	tok    bool         // got is printed as got.Tok
+	quote  bool         // EOF is quoted
+}
+
+func (e *expectError) Error() string {
+	var got string
+	switch {
+	case e.got == nil && !e.quote:
+		return "expect ` + "`" + `" + e.expect + "` + "`" + `, but got EOF"
+	case e.got == nil:
+		got = "EOF"
+	case e.tok:
+		got = e.got.Tok.String()
+	default:
+		got = e.got.String()
+	}
+	return "expect ` + "`" + `" + e.expect + "` + "`" + `, but got ` + "`" + `" + got + "` + "`" + `"
+}
+
+func (p *parser) expectRule(src []*types.Token, name string) error {
+	if len(src) > 0 {
+		return &expectError{pos: src[0].Pos, expect: name, got: src[0]}
+	}
+	return &expectError{pos: p.fileEnd, expect: name, quote: true}
+}
+
+func (p *parser) recoverAction(src []*types.Token, err *error) {
+	if e := recover(); e != nil {
+		switch e := e.(type) {
+		case *matcher.Error:
+			if e.Fset == nil {
+				e.Fset = p.fset
+			}
+			*err = e
+		case string:
+			*err = &matcher.Error{Fset: p.fset, Pos: src[0].Pos, Msg: e, Dyn: true}
+		default:
+			*err = e.(error)
+		}
+	}
+}
+
+type matchFunc = func(p *parser, src []*types.Token) (n int, result any, err error)
+
+func (p *parser) memoize(rule int, src []*types.Token, match matchFunc) (n int, result any, err error) {
+	key := ruleKey{rule, len(src)}
+	if e, ok := p.memo[key]; ok {
+		return e.n, e.result, e.err
+	}
+	n, result, err = match(p, src)
+	if p.growing == 0 { // results depending on a growing seed can't be memoized
+		p.memo[key] = memoEntry{n, result, err}
+	}
+	return
+}
+
+func (p *parser) growSeed(rule int, src []*types.Token, match matchFunc) (n int, result any, err error) {
+	key := ruleKey{rule, len(src)}
+	if seed, ok := p.seeds[key]; ok { // left recursion
+		return seed.n, seed.result, seed.err
+	}
+	seed := &memoEntry{err: errLeftRec}
+	p.seeds[key] = seed
+	p.growing++
+	defer func() {
+		delete(p.seeds, key)
+		p.growing--
+	}()
+	for {
+		n, result, err = match(p, src)
+		failed := err != nil && !isDyn(err)
+		if seed.err != errLeftRec && (failed || n <= seed.n) {
+			break
+		}
+		*seed = memoEntry{n, result, err}
+		if failed {
+			break
+		}
+	}
+	return seed.n, seed.result, seed.err
+}
+
+func (p *parser) matchTrue(src []*types.Token) (n int, result any, err error) {
+	return 0, nil, nil
+}
+
+func (p *parser) matchSpace(src []*types.Token) (n int, result any, err error) {
+	if left := len(src); left > 0 {
+		if n := len(p.toks); n > left {
+			if p.toks[n-left-1].End() != src[0].Pos {
+				return 0, nil, nil
+			}
+		}
+	}
+	return 0, nil, errNoWhitespace
+}
+
+func (p *parser) matchString(src []*types.Token, quoteCh byte) (n int, result any, err error) {
+	typ := "RAWSTRING"
+	if quoteCh == '"' {
+		typ = "QSTRING"
+	}
+	if len(src) == 0 {
+		return 0, nil, &expectError{pos: p.fileEnd, expect: typ}
+	}
+	t := src[0]
+	if t.Tok != token.STRING || t.Lit[0] != quoteCh {
+		return 0, nil, &expectError{pos: t.Pos, expect: typ, got: t}
+	}
+	return 1, t, nil
+}
+
+func (p *parser) matchToken(src []*types.Token, tok token.Token) (n int, result any, err error) {
+	if len(src) == 0 {
+		return 0, nil, &expectError{pos: p.fileEnd, expect: tok.String()}
+	}
+	t := src[0]
+	if t.Tok != tok {
+		return 0, nil, &expectError{pos: t.Pos, expect: tok.String(), got: t, tok: true}
+	}
+	return 1, t, nil
+}
+
+func (p *parser) matchLiteral(src []*types.Token, tok token.Token, lit string) (n int, result any, err error) {
+	if len(src) == 0 {
+		return 0, nil, &expectError{pos: p.fileEnd, expect: lit}
+	}
+	t := src[0]
+	if t.Lit != lit || t.Tok != tok && t.Tok < token.USER_BEG { // a token rule may scan a keyword
+		return 0, nil, &expectError{pos: t.Pos, expect: lit, got: t}
+	}
+	return 1, t, nil
+}
+
+type choiceState struct {
+	nMax     int
+	errMax   error
+	multiErr bool
+}
+
+func (c *choiceState) add(n int, err error) {
+	if n >= c.nMax {
+		if n == c.nMax {
+			c.multiErr = true
+		} else {
+			c.nMax, c.errMax, c.multiErr = n, err, false
+		}
+	}
+}
+
+func (c *choiceState) result() (n int, result any, err error) {
+	if c.multiErr {
+		return c.nMax, nil, errMultiMismatch
+	}
+	return c.nMax, nil, c.errMax
+}
+
+func (p *parser) repeat0(src []*types.Token, g matchFunc) (n int, result any, err error) {
+	rets := make([]any, 0, 2)
+	for {
+		n1, ret1, err1 := g(p, src)
+		if err1 != nil {
+			if !isDyn(err1) {
+				p.setLastError(len(src)-n1, err1)
+				return n, rets, err
+			}
+			err = err1
+		}
+		rets = append(rets, ret1)
+		n += n1
+		src = src[n1:]
+	}
+}
+
+func (p *parser) repeat1(src []*types.Token, g matchFunc) (n int, result any, err error) {
+	n, ret0, err := g(p, src)
+	if err != nil {
+		return
+	}
+	rets := make([]any, 1, 2)
+	rets[0] = ret0
+	for {
+		n1, ret1, err1 := g(p, src[n:])
+		if err1 != nil {
+			if !isDyn(err1) {
+				p.setLastError(len(src)-n-n1, err1)
+				return n, rets, err
+			}
+			err = err1
+		}
+		rets = append(rets, ret1)
+		n += n1
+	}
+}
+
+func (p *parser) repeat01(src []*types.Token, g matchFunc) (n int, result any, err error) {
+	n, result, err = g(p, src)
+	if err != nil {
+		return 0, nil, nil
+	}
+	return
+}
+
+func (p *parser) lookahead(src []*types.Token, g matchFunc, not bool) (n int, result any, err error) {
+	left, lastErr := p.left, p.lastErr
+	_, _, err = g(p, src)
+	p.left, p.lastErr = left, lastErr
+	if not {
+		if err != nil {
+			return 0, nil, nil
+		}
+		if len(src) == 0 {
+			return 0, nil, p.newErrorf(p.fileEnd, "unexpected EOF")
+		}
+		return 0, nil, p.newErrorf(src[0].Pos, "unexpected ` + "`%v`" + `", src[0])
+	}
+	return
+}
+
+func (p *parser) adjoin(src []*types.Token, a, b matchFunc) (n int, result any, err error) {
+	n, ret0, err := a(p, src)
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		return n, nil, errAdjoinEmpty
+	}
+	n1, ret1, err := b(p, src[n:])
+	if err != nil && !isDyn(err) {
+		return
+	}
+	if n1 == 0 {
+		return n, nil, errAdjoinEmpty
+	}
+	if src[n-1].End() != src[n].Pos {
+		return n, nil, p.newErrorf(src[n].Pos, "not adjoin")
+	}
+	return n + n1, []any{ret0, ret1}, err
+}
+`
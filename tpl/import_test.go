@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/token"
+)
+
+func init() {
+	tpl.Register("example.com/tpl/kv", `
+pair = IDENT "=" value
+
+value = INT | STRING
+`, "pair", func(self []any) any {
+		return self[0].(*tpl.Token).Lit
+	})
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(dir+"/expr.tpl", []byte(`
+expr = operand % "+"
+
+operand = INT | "(" expr ")"
+`), 0644)
+	os.WriteFile(dir+"/a.tpl", []byte(`import "b.tpl"`+"\n"+`a = INT`), 0644)
+	os.WriteFile(dir+"/b.tpl", []byte(`import "a.tpl"`+"\n"+`b = INT`), 0644)
+	os.WriteFile(dir+"/c.tpl", []byte(`operand = STRING`), 0644)
+	quiet := &cl.Config{
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+	}
+
+	// extend an imported rule, and the imported rules using it see the extension
+	c, err := tpl.FromFile(nil, dir+"/dialect.tpl", `
+import "expr.tpl"
+
+operand |= IDENT
+`, quiet)
+	if err != nil {
+		t.Fatal("FromFile:", err)
+	}
+	if _, err = c.ParseExpr("a + (1 + b)", nil); err != nil {
+		t.Fatal("Parse:", err)
+	}
+
+	// override an imported rule
+	c, err = tpl.FromFile(nil, dir+"/dialect.tpl", `
+import "expr.tpl"
+
+operand = INT | "-" operand | "(" expr ")"
+`, quiet)
+	if err != nil {
+		t.Fatal("FromFile:", err)
+	}
+	if _, err = c.ParseExpr("-1 + (2 + - -3)", nil); err != nil {
+		t.Fatal("Parse:", err)
+	}
+
+	// import a registered grammar with a prefix, whose RetProcs are kept
+	c, err = tpl.FromFile(nil, "", `
+import kv "example.com/tpl/kv"
+
+doc = kv.pair % ";"
+
+kv.value |= "true" | "false"
+`, quiet)
+	if err != nil {
+		t.Fatal("FromFile:", err)
+	}
+	ret, err := c.ParseExpr(`a = 1; b = "x"; c = true`, nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	if keys := tpl.List(ret.([]any)); len(keys) != 3 || keys[0] != "a" || keys[2] != "c" {
+		t.Fatal("Parse:", keys)
+	}
+	if c.Rules["kv.pair"] == nil || c.Rules["pair"] != nil {
+		t.Fatal("Rules:", c.Rules)
+	}
+
+	// a grammar imported with two prefixes is extended for one of them only
+	c, err = tpl.FromFile(nil, "", `
+import a "example.com/tpl/kv"
+import b "example.com/tpl/kv"
+
+doc = a.pair ";" b.pair
+
+a.value |= "true"
+`, quiet)
+	if err != nil {
+		t.Fatal("FromFile:", err)
+	}
+	if _, err = c.ParseExpr(`x = true; y = 1`, nil); err != nil {
+		t.Fatal("Parse:", err)
+	}
+	if _, err = c.ParseExpr(`x = 1; y = true`, nil); err == nil {
+		t.Fatal("Parse: no error")
+	}
+
+	for _, e := range []struct{ src, msg string }{
+		{"import \"none.tpl\"\ndoc = INT", "could not import none.tpl"},
+		{"import \"a.tpl\"\ndoc = a", "import cycle not allowed: a.tpl"},
+		{"import \"expr.tpl\"\nfoo |= INT", "2:1: `foo` is undefined"},
+		{"import \"expr.tpl\"\ndoc = x.expr", "2:7: `x.expr` is undefined"},
+		{"import \"expr.tpl\"\nimport \"c.tpl\"\ndoc = expr", "rule `operand` of c.tpl is already imported"},
+		{"import \"expr.tpl\"\nexpr = INT\nexpr = STRING", "duplicate rule `expr`"},
+	} {
+		_, err = tpl.FromFile(nil, dir+"/main.tpl", e.src, quiet)
+		if err == nil || !strings.Contains(err.Error(), e.msg) {
+			t.Fatal("FromFile:", e.src, err)
+		}
+	}
+}
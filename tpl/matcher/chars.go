@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package matcher
+
+import (
+	"io"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goplus/gop/tpl/token"
+	"github.com/goplus/gop/tpl/types"
+)
+
+// The matchers in this file are used in the scannerless mode, where every
+// token is a character (see scanner.ScanChars). They match the characters of
+// several tokens, and return them as a single token.
+
+// -----------------------------------------------------------------------------
+
+// charsText returns the text of CHAR tokens.
+func charsText(src []*types.Token) string {
+	var b strings.Builder
+	for _, t := range src {
+		b.WriteString(t.Lit)
+	}
+	return b.String()
+}
+
+func charsToken(tok token.Token, src []*types.Token) *types.Token {
+	return &types.Token{Tok: tok, Pos: src[0].Pos, Lit: charsText(src)}
+}
+
+func firstChar(s string) *MatchToken {
+	_, n := utf8.DecodeRuneInString(s)
+	return &MatchToken{Tok: token.CHAR, Lit: s[:n]}
+}
+
+// -----------------------------------------------------------------------------
+
+type gText MatchToken
+
+func (p *gText) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	text := p.Lit
+	for i := 0; i < len(text); n++ {
+		ctx.see(len(src) - n)
+		if n == len(src) {
+			if n == 0 {
+				return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", text)
+			}
+			return 0, nil, ctx.NewErrorf(src[0].Pos, "expect `%s`, but got `%s`", text, charsText(src))
+		}
+		lit := src[n].Lit
+		if lit == "" || !strings.HasPrefix(text[i:], lit) {
+			return 0, nil, ctx.NewErrorf(src[0].Pos, "expect `%s`, but got `%s`", text, charsText(src[:n+1]))
+		}
+		i += len(lit)
+	}
+	return n, charsToken(p.Tok, src[:n]), nil
+}
+
+func (p *gText) First(in []any) (first []any, mayEmpty bool) {
+	return append(in, firstChar(p.Lit)), false
+}
+
+// Text: a literal in the scannerless mode, eg. "if". It matches the characters
+// of text, and returns a token of kind tok. text must not be empty.
+func Text(tok token.Token, text string) Matcher {
+	return &gText{tok, text}
+}
+
+// -----------------------------------------------------------------------------
+
+type gRegexp struct {
+	tok   token.Token
+	name  string
+	re    *regexp.Regexp
+	first []any // nil means any character
+}
+
+// charReader reads the characters of CHAR tokens.
+type charReader struct {
+	src []*types.Token
+	i   int
+	eof bool // the end of tokens is hit
+}
+
+func (p *charReader) ReadRune() (r rune, size int, err error) {
+	if p.i == len(p.src) {
+		p.eof = true
+		return 0, 0, io.EOF
+	}
+	r, size = utf8.DecodeRuneInString(p.src[p.i].Lit)
+	p.i++
+	return
+}
+
+func (p *gRegexp) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	r := &charReader{src: src}
+	loc := p.re.FindReaderIndex(r)
+	if r.eof {
+		ctx.see(0)
+	} else if r.i > 0 {
+		ctx.see(len(src) - r.i + 1)
+	}
+	if loc == nil || loc[1] == 0 {
+		if len(src) == 0 {
+			return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", p.name)
+		}
+		return 0, nil, ctx.NewErrorf(src[0].Pos, "expect `%s`, but got `%v`", p.name, src[0])
+	}
+	for size := 0; size < loc[1]; n++ {
+		_, w := utf8.DecodeRuneInString(src[n].Lit)
+		size += w
+	}
+	return n, charsToken(p.tok, src[:n]), nil
+}
+
+func (p *gRegexp) First(in []any) (first []any, mayEmpty bool) {
+	if p.first == nil {
+		return append(in, token.CHAR), false
+	}
+	return append(in, p.first...), false
+}
+
+// Regexp: a token defined by a grammar in the scannerless mode, such as
+// NUMBER = /[0-9]+/. It matches the characters of the longest non-empty match
+// of re, which must be anchored at the beginning (eg. ^(?:[0-9]+)), and
+// returns a token of kind tok.
+func Regexp(tok token.Token, name string, re *regexp.Regexp) Matcher {
+	ret := &gRegexp{tok: tok, name: name, re: re}
+	if x, err := syntax.Parse(re.String(), syntax.Perl); err == nil {
+		if chars, _, ok := firstChars(x.Simplify()); ok && len(chars) > 0 {
+			seen := make(map[rune]bool, len(chars))
+			for _, c := range chars {
+				if !seen[c] {
+					seen[c] = true
+					ret.first = append(ret.first, &MatchToken{Tok: token.CHAR, Lit: string(c)})
+				}
+			}
+		}
+	}
+	return ret
+}
+
+const maxFirstChars = 128
+
+// firstChars returns the characters which a match of re may begin with, or
+// ok == false if they are unknown or too many.
+func firstChars(re *syntax.Regexp) (chars []rune, nullable, ok bool) {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText,
+		syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return nil, true, true
+	case syntax.OpLiteral:
+		if len(re.Rune) == 0 {
+			return nil, true, true
+		}
+		c := re.Rune[0]
+		chars = append(chars, c)
+		if re.Flags&syntax.FoldCase != 0 {
+			for f := unicode.SimpleFold(c); f != c; f = unicode.SimpleFold(f) {
+				chars = append(chars, f)
+			}
+		}
+		return chars, false, true
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			for c := re.Rune[i]; c <= re.Rune[i+1]; c++ {
+				if len(chars) == maxFirstChars {
+					return nil, false, false
+				}
+				chars = append(chars, c)
+			}
+		}
+		return chars, false, true
+	case syntax.OpCapture, syntax.OpPlus:
+		return firstChars(re.Sub[0])
+	case syntax.OpStar, syntax.OpQuest:
+		chars, _, ok = firstChars(re.Sub[0])
+		return chars, true, ok
+	case syntax.OpRepeat:
+		chars, nullable, ok = firstChars(re.Sub[0])
+		return chars, nullable || re.Min == 0, ok
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			subChars, subNullable, subOk := firstChars(sub)
+			if !subOk {
+				return nil, false, false
+			}
+			chars = append(chars, subChars...)
+			if !subNullable {
+				return chars, false, len(chars) <= maxFirstChars
+			}
+		}
+		return chars, true, len(chars) <= maxFirstChars
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			subChars, subNullable, subOk := firstChars(sub)
+			if !subOk {
+				return nil, false, false
+			}
+			chars = append(chars, subChars...)
+			nullable = nullable || subNullable
+		}
+		return chars, nullable, len(chars) <= maxFirstChars
+	}
+	return nil, false, false
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package matcher
+
+import (
+	"regexp"
+
+	"github.com/goplus/gop/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// Kind represents the kind of a matcher (see Describe).
+type Kind int
+
+const (
+	KindUnknown      Kind = iota
+	KindTrue              // True()
+	KindWhiteSpace        // WhiteSpace()
+	KindToken             // Token(Tok), or NamedToken(Tok, Name)
+	KindString            // String(quoteCh), Lit is the quote character
+	KindLiteral           // Literal(Tok, Lit)
+	KindText              // Text(Tok, Lit)
+	KindRegexp            // Regexp(Tok, Name, Regexp)
+	KindChoice            // Choice(Items...)
+	KindSequence          // Sequence(Items...)
+	KindRepeat0           // Repeat0(Items[0])
+	KindRepeat1           // Repeat1(Items[0])
+	KindRepeat01          // Repeat01(Items[0])
+	KindLookahead         // Lookahead(Items[0])
+	KindNotLookahead      // NotLookahead(Items[0])
+	KindAdjoin            // Adjoin(Items[0], Items[1])
+	KindVar               // a rule, Name is its name and Items[0] is its Elem
+)
+
+// Description describes a matcher.
+type Description struct {
+	Kind   Kind
+	Tok    token.Token
+	Name   string
+	Lit    string
+	Regexp *regexp.Regexp
+	Items  []Matcher
+}
+
+// Describe describes a matcher created by this package, so that a compiled
+// grammar can be walked, eg. to generate sentences of it. It returns a
+// description of KindUnknown for other matchers.
+func Describe(m Matcher) Description {
+	switch m := m.(type) {
+	case gTrue:
+		return Description{Kind: KindTrue}
+	case gWS:
+		return Description{Kind: KindWhiteSpace}
+	case *gToken:
+		return Description{Kind: KindToken, Tok: m.tok, Name: m.name}
+	case gString:
+		return Description{Kind: KindString, Tok: token.STRING, Lit: string(rune(m))}
+	case *gLiteral:
+		return Description{Kind: KindLiteral, Tok: m.Tok, Lit: m.Lit}
+	case *gText:
+		return Description{Kind: KindText, Tok: m.Tok, Lit: m.Lit}
+	case *gRegexp:
+		return Description{Kind: KindRegexp, Tok: m.tok, Name: m.name, Regexp: m.re}
+	case *Choices:
+		return Description{Kind: KindChoice, Items: m.options}
+	case *gSequence:
+		return Description{Kind: KindSequence, Items: m.items}
+	case *gRepeat0:
+		return Description{Kind: KindRepeat0, Items: []Matcher{m.r}}
+	case *gRepeat1:
+		return Description{Kind: KindRepeat1, Items: []Matcher{m.r}}
+	case *gRepeat01:
+		return Description{Kind: KindRepeat01, Items: []Matcher{m.r}}
+	case *gLookahead:
+		kind := KindLookahead
+		if m.not {
+			kind = KindNotLookahead
+		}
+		return Description{Kind: kind, Items: []Matcher{m.r}}
+	case *gList:
+		return Describe(m.seq)
+	case *gAdjoin:
+		return Description{Kind: KindAdjoin, Items: []Matcher{m.a, m.b}}
+	case *Var:
+		return Description{Kind: KindVar, Name: m.Name, Items: []Matcher{m.Elem}}
+	}
+	return Description{}
+}
+
+// -----------------------------------------------------------------------------
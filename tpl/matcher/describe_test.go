@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package matcher_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/token"
+)
+
+func TestDescribe(t *testing.T) {
+	c := compile(t, `
+doc = +stmt
+
+stmt = ("let" | "var") IDENT ?("=" expr) ";" | !"end" expr ";"
+
+expr = INT ++ UNIT | QSTRING | *"-" INT
+`)
+	stmt := matcher.Describe(c.Rules["stmt"])
+	if stmt.Kind != matcher.KindVar || stmt.Name != "stmt" {
+		t.Fatal("Describe stmt:", stmt)
+	}
+	choice := matcher.Describe(stmt.Items[0])
+	if choice.Kind != matcher.KindChoice || len(choice.Items) != 2 {
+		t.Fatal("Describe choice:", choice)
+	}
+	seq := matcher.Describe(choice.Items[0])
+	if seq.Kind != matcher.KindSequence || len(seq.Items) != 4 {
+		t.Fatal("Describe sequence:", seq)
+	}
+	kinds := func(items []matcher.Matcher) (ret []matcher.Kind) {
+		for _, item := range items {
+			ret = append(ret, matcher.Describe(item).Kind)
+		}
+		return
+	}
+	if ret := kinds(seq.Items); !reflect.DeepEqual(ret, []matcher.Kind{
+		matcher.KindChoice, matcher.KindToken, matcher.KindRepeat01, matcher.KindToken}) {
+		t.Fatal("Describe sequence items:", ret)
+	}
+	if d := matcher.Describe(matcher.Describe(seq.Items[0]).Items[1]); d.Tok != token.IDENT || d.Lit != "var" {
+		t.Fatal("Describe literal:", d)
+	}
+	if ret := kinds(matcher.Describe(choice.Items[1]).Items); !reflect.DeepEqual(ret, []matcher.Kind{
+		matcher.KindNotLookahead, matcher.KindVar, matcher.KindToken}) {
+		t.Fatal("Describe lookahead:", ret)
+	}
+	expr := matcher.Describe(matcher.Describe(c.Rules["expr"]).Items[0])
+	if ret := kinds(expr.Items); !reflect.DeepEqual(ret, []matcher.Kind{
+		matcher.KindAdjoin, matcher.KindString, matcher.KindSequence}) {
+		t.Fatal("Describe expr:", ret)
+	}
+	if d := matcher.Describe(expr.Items[1]); d.Lit != `"` {
+		t.Fatal("Describe QSTRING:", d)
+	}
+	if d := matcher.Describe(c.Doc); d.Kind != matcher.KindVar || matcher.Describe(d.Items[0]).Kind != matcher.KindRepeat1 {
+		t.Fatal("Describe doc:", d)
+	}
+	if d := matcher.Describe(nil); d.Kind != matcher.KindUnknown {
+		t.Fatal("Describe nil:", d)
+	}
+}
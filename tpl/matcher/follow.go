@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package matcher
+
+import (
+	"github.com/goplus/gop/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// InitFollow computes the FOLLOW sets of the repetitions (*R and +R) and lists
+// (R1 % R2) of the rules reachable from doc, that is, the tokens which may come
+// after them, where token.EOF stands for the end of tokens. In the recovery
+// mode, they tell whether a repetition ends at a token or fails there (see
+// Context.EnableRecover). All rules must be assigned.
+func InitFollow(doc *Var) {
+	f := &follows{vars: map[*Var][]any{doc: {token.EOF}}}
+	for f.changed = true; f.changed; {
+		f.changed = false
+		for i := 0; i < len(f.order)+1; i++ { // f.order grows while walking
+			v := doc
+			if i > 0 {
+				v = f.order[i-1]
+			}
+			f.walk(v.Elem, f.vars[v])
+		}
+	}
+}
+
+type follows struct {
+	vars    map[*Var][]any
+	order   []*Var // vars referred to, except doc
+	changed bool
+}
+
+// walk adds follow, the tokens which may come after m, to the FOLLOW sets of
+// the rules, repetitions and lists in m.
+func (p *follows) walk(m Matcher, follow []any) {
+	switch m := m.(type) {
+	case *Var:
+		old, ok := p.vars[m]
+		if !ok {
+			p.order = append(p.order, m)
+		}
+		if set, grown := union(old, follow); grown || !ok {
+			p.vars[m], p.changed = set, true
+		}
+	case *Choices:
+		for _, g := range m.options {
+			p.walk(g, follow)
+		}
+	case *gSequence:
+		for i, g := range m.items {
+			next, mayEmpty := (&gSequence{m.items[i+1:]}).First(nil)
+			if mayEmpty {
+				next, _ = union(next, follow)
+			}
+			p.walk(g, next)
+		}
+	case *gRepeat0:
+		m.follow, _ = union(m.follow, follow)
+		p.walk(m.r, repeat(m.r, follow))
+	case *gRepeat1:
+		m.follow, _ = union(m.follow, follow)
+		p.walk(m.r, repeat(m.r, follow))
+	case *gRepeat01:
+		p.walk(m.r, follow)
+	case *gList:
+		m.follow, _ = union(m.follow, follow)
+		first, _ := m.a.First(nil)
+		next, _ := m.b.First(nil)
+		next, _ = union(next, follow)
+		p.walk(m.a, next)
+		p.walk(m.b, first)
+	case *gAdjoin:
+		next, _ := m.b.First(nil)
+		p.walk(m.a, next)
+		p.walk(m.b, follow)
+	}
+}
+
+// repeat returns the tokens which may come after item r of a repetition, whose
+// FOLLOW set is follow.
+func repeat(r Matcher, follow []any) []any {
+	next, _ := r.First(nil)
+	next, _ = union(next, follow)
+	return next
+}
+
+// union adds the tokens of b, which aren't in a, to a. It reports whether a
+// has grown.
+func union(a, b []any) (ret []any, grown bool) {
+	ret = a
+	for _, t := range b {
+		if !hasToken(ret, t) {
+			ret, grown = append(ret, t), true
+		}
+	}
+	return
+}
+
+func hasToken(set []any, t any) bool {
+	for _, s := range set {
+		switch s := s.(type) {
+		case token.Token:
+			if t, ok := t.(token.Token); ok && t == s {
+				return true
+			}
+		case *MatchToken:
+			if t, ok := t.(*MatchToken); ok && t.Tok == s.Tok && t.Lit == s.Lit {
+				return true
+			}
+		case *exceptToken:
+			if t, ok := t.(*exceptToken); ok && t.Tok == s.Tok && sameLiterals(t.Except, s.Except) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sameLiterals(a, b []*MatchToken) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, lit := range a {
+		if !hasLiteral(b, lit.Tok, lit.Lit) {
+			return false
+		}
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"strings"
 
 	"github.com/goplus/gop/tpl/token"
 	"github.com/goplus/gop/tpl/types"
@@ -32,6 +34,7 @@ var (
 	errNoWhitespace  = errors.New("no whitespace")
 	errAdjoinEmpty   = errors.New("adjoin empty")
 	errMultiMismatch = errors.New("multiple mismatch")
+	errLeftRec       = errors.New("left recursion")
 )
 
 // -----------------------------------------------------------------------------
@@ -76,6 +79,8 @@ func isDyn(err error) bool {
 }
 
 // RecursiveError represents a recursive error.
+//
+// Deprecated: left recursion is supported now, so it isn't reported any more.
 type RecursiveError struct {
 	*Var
 }
@@ -84,13 +89,46 @@ func (e RecursiveError) Error() string {
 	return "recursive variable " + e.Name
 }
 
+// UndefinedRuleError represents an error of a rule whose Elem isn't assigned.
+type UndefinedRuleError struct {
+	*Var
+}
+
+func (e UndefinedRuleError) Error() string {
+	return "undefined rule " + e.Name
+}
+
 // -----------------------------------------------------------------------------
 
+type memoKey struct {
+	v    *Var
+	left int // number of tokens left, that is, the token offset
+}
+
+type memoEntry struct {
+	n      int
+	result any
+	err    error
+
+	exam    int   // number of tokens left at the farthest token examined
+	left    int   // the last error set (see SetLastError) when matching
+	lastErr error // (left is math.MaxInt if no error is set)
+}
+
 // Context represents the context of a matching process.
 type Context struct {
 	Fset    *token.FileSet
 	FileEnd token.Pos
 	toks    []*types.Token
+	memo    map[memoKey]memoEntry
+	pure    map[*Var]bool          // rule => its results can be memoized
+	seeds   map[memoKey]*memoEntry // seeds of left-recursive rules being grown
+	growing int                    // number of seeds being grown
+	errs    []*Error               // errors recovered from (see EnableRecover)
+	recover bool
+	hitEnd  bool // see HitEnd
+	exam    int  // number of tokens left at the farthest token examined
+	tracer  Tracer
 
 	Left    int
 	LastErr error
@@ -102,10 +140,263 @@ func NewContext(fset *token.FileSet, fileEnd token.Pos, toks []*types.Token) *Co
 		Fset:    fset,
 		FileEnd: fileEnd,
 		toks:    toks,
+		exam:    math.MaxInt,
 		Left:    len(toks),
 	}
 }
 
+// EnableMemo enables packrat memoization: the matching result of a rule at a
+// token offset is cached, so the rule isn't matched again at the same offset
+// when a choice backtracks. Only rules, all RetProcs of which (including the
+// ones of rules they refer to) are nil or pure (see Var.Pure), are memoized.
+func (p *Context) EnableMemo() {
+	if p.memo == nil {
+		p.memo = make(map[memoKey]memoEntry)
+	}
+}
+
+// ReuseMemo reuses the memoized results of prev, a context of matching the
+// tokens before an edit, which replaces tokens [start, end) of prev with new
+// ones, and moves the tokens from end on by offset (whose positions must have
+// been updated). The tokens before start and from end on must be the same ones
+// in p, so a result is reused if it only depends on the tokens before start,
+// or on the tokens from end on and consists of them. Memoization must be
+// enabled.
+func (p *Context) ReuseMemo(prev *Context, start, end int, offset token.Pos) {
+	oldN, delta := len(prev.toks), len(p.toks)-len(prev.toks)
+	for key, e := range prev.memo {
+		i := oldN - key.left // index of the first token
+		switch {
+		case oldN-e.exam < start: // before the edit
+			key.left += delta
+			e.exam += delta
+			if e.left != math.MaxInt {
+				e.left += delta
+			}
+			e.err, e.lastErr = p.moveError(e.err, 0), p.moveError(e.lastErr, 0)
+		case i > end && isTokens(e.result, prev.toks[i:i+e.n]): // after the edit
+			e.err, e.lastErr = p.moveError(e.err, offset), p.moveError(e.lastErr, offset)
+		default:
+			continue
+		}
+		p.memo[key] = e
+	}
+}
+
+func (p *Context) moveError(err error, offset token.Pos) error {
+	if e, ok := err.(*Error); ok {
+		return &Error{p.Fset, e.Pos + offset, e.Msg, e.Dyn}
+	}
+	return err
+}
+
+func isTokens(v any, toks []*types.Token) bool {
+	_, ok := isTokenTree(v, toks)
+	return ok
+}
+
+// isTokenTree reports whether v only consists of toks (in order), which are
+// the tokens matched.
+func isTokenTree(v any, toks []*types.Token) ([]*types.Token, bool) {
+	switch v := v.(type) {
+	case nil:
+		return toks, true
+	case *types.Token:
+		for i, t := range toks {
+			if t == v {
+				return toks[i+1:], true
+			}
+		}
+	case []any:
+		ok := true
+		for _, item := range v {
+			if toks, ok = isTokenTree(item, toks); !ok {
+				break
+			}
+		}
+		return toks, ok
+	}
+	return nil, false
+}
+
+// EnableRecover enables the recovery mode: when an item R of a repetition (*R
+// or +R) fails, the error is recorded (see Errors) and the tokens up to a
+// synchronization point are skipped as an ErrorNode, then matching continues.
+// If R ends with an operator or a keyword (eg. ";" of `*(stmt ";")`), the
+// tokens up to the next such token are skipped, otherwise the ones up to the
+// next token R may start with. Likewise, when an element of a list (R1 % R2)
+// fails, the tokens up to the next separator R2 are skipped, so the list
+// continues with it. Skipping stops before a token which may come after the
+// repetition or the list (see InitFollow).
+//
+// An item (or an element) that fails without matching any tokens ends the
+// repetition as usual if the token may come after it, or if it's the first
+// one, since the repetition might not be there at all. Otherwise the failure
+// is recovered from, too. If the FOLLOW sets aren't computed, only failures
+// after matching some tokens are recovered from.
+func (p *Context) EnableRecover() {
+	p.recover = true
+}
+
+// HitEnd reports whether a matcher has hit the end of tokens, in which case
+// more tokens might change the matching result. It's used to match a prefix
+// of a source being read (see tpl.Stream).
+func (p *Context) HitEnd() bool {
+	return p.hitEnd
+}
+
+// see records that a matcher has examined the first of left tokens, or the
+// end of tokens if left is 0.
+func (p *Context) see(left int) {
+	if left < p.exam {
+		p.exam = left
+	}
+	if left == 0 {
+		p.hitEnd = true
+	}
+}
+
+// Errors returns the errors recovered from in the recovery mode.
+func (p *Context) Errors() []*Error {
+	return p.errs
+}
+
+// ErrorNode represents tokens which are skipped to recover from an error.
+type ErrorNode struct {
+	Pos token.Pos // position of the first skipped token
+	End token.Pos // end of the last skipped token
+	Err *Error
+}
+
+// recoverFrom recovers from an error of item g of a repetition, whose FOLLOW
+// set is follow, which fails after matching n tokens of src. more reports
+// whether g is matched after other items of the repetition. It returns the
+// number of tokens to skip.
+func (p *Context) recoverFrom(g Matcher, follow []any, src []*types.Token, n int, more bool, err error) (skip int, node *ErrorNode, ok bool) {
+	if !p.recover || isDyn(err) || !p.failed(follow, src, n, more) {
+		return
+	}
+	var first []any
+	sync := syncToken(g)
+	if sync == nil {
+		if first, _ = g.First(nil); len(first) == 0 && follow == nil {
+			return
+		}
+	}
+	e := p.recordError(src, n, err)
+	skip = len(src)
+	for i := n; i < len(src); i++ {
+		if sync != nil {
+			if _, _, err := sync.Match(src[i:i+1], p); err == nil {
+				skip = i + 1
+				break
+			}
+		} else if i > 0 && startsWith(first, src[i]) {
+			skip = i
+			break
+		}
+		if i > 0 && startsWith(follow, src[i]) {
+			skip = i
+			break
+		}
+	}
+	return skip, &ErrorNode{Pos: src[0].Pos, End: src[skip-1].End(), Err: e}, true
+}
+
+// recoverTo recovers from an error of an element of a list, whose FOLLOW set
+// is follow, which fails after matching n tokens of src. more reports whether
+// the element comes after a separator. The tokens up to the next separator,
+// whose first tokens are sync, are skipped.
+func (p *Context) recoverTo(sync, follow []any, src []*types.Token, n int, more bool, err error) (skip int, node *ErrorNode, ok bool) {
+	if !p.recover || len(sync) == 0 || !p.failed(follow, src, n, more) {
+		return
+	}
+	e := p.recordError(src, n, err)
+	skip = len(src)
+	for i := n; i < len(src); i++ {
+		if startsWith(sync, src[i]) || i > 0 && startsWith(follow, src[i]) {
+			skip = i
+			break
+		}
+	}
+	node = &ErrorNode{Pos: p.posOf(src, 0), Err: e}
+	if skip > 0 {
+		node.End = src[skip-1].End()
+	} else {
+		node.End = node.Pos
+	}
+	return skip, node, true
+}
+
+// failed reports whether an item of a repetition (or an element of a list),
+// which fails after matching n tokens of src, is an error to recover from
+// rather than the end of the repetition (see EnableRecover).
+func (p *Context) failed(follow []any, src []*types.Token, n int, more bool) bool {
+	if n > 0 {
+		return true
+	}
+	return more && follow != nil && len(src) > 0 && !startsWith(follow, src[0])
+}
+
+func (p *Context) recordError(src []*types.Token, n int, err error) *Error {
+	e, ok := err.(*Error)
+	if !ok {
+		e = p.NewError(p.posOf(src, n), err.Error())
+	}
+	p.errs = append(p.errs, e)
+	return e
+}
+
+func (p *Context) posOf(src []*types.Token, i int) token.Pos {
+	if i < len(src) {
+		return src[i].Pos
+	}
+	return p.FileEnd
+}
+
+// startsWith reports whether t is one of the first tokens.
+func startsWith(first []any, t *types.Token) bool {
+	for _, f := range first {
+		switch f := f.(type) {
+		case token.Token:
+			if t.Tok == f {
+				return true
+			}
+		case *MatchToken:
+			if t.Tok == f.Tok && t.Lit == f.Lit {
+				return true
+			}
+		case *exceptToken:
+			if t.Tok == f.Tok && !hasLiteral(f.Except, t.Tok, t.Lit) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// syncToken returns the last token of g if it's an operator or a keyword.
+func syncToken(g Matcher) Matcher {
+	for i := 0; i < 8; i++ { // limit the depth to stop recursion
+		switch m := g.(type) {
+		case *Var:
+			g = m.Elem
+		case *gSequence:
+			g = m.items[len(m.items)-1]
+		case *gToken:
+			if m.tok.Len() > 0 {
+				return m
+			}
+			return nil
+		case *gLiteral:
+			return m
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
 // SetLastError sets the last error.
 func (p *Context) SetLastError(left int, err error) {
 	if left < p.Left {
@@ -135,17 +426,45 @@ func (p *MatchToken) String() string {
 	return p.Lit
 }
 
-func hasConflictToken(me token.Token, next []any) bool {
+// exceptToken represents a token of kind Tok, except the literals excluded by
+// a negative lookahead, eg. IDENT of `!keyword IDENT`.
+type exceptToken struct {
+	Tok    token.Token
+	Except []*MatchToken
+}
+
+func (p *exceptToken) String() string {
+	lits := make([]string, len(p.Except))
+	for i, e := range p.Except {
+		lits[i] = e.Lit
+	}
+	return p.Tok.String() + "-" + strings.Join(lits, "-")
+}
+
+func hasLiteral(lits []*MatchToken, tok token.Token, lit string) bool {
+	for _, e := range lits {
+		if e.Tok == tok && e.Lit == lit {
+			return true
+		}
+	}
+	return false
+}
+
+func hasConflictToken(me token.Token, except *exceptToken, next []any) bool {
 	for _, n := range next {
 		switch n := n.(type) {
 		case *MatchToken:
-			if n.Tok == me {
+			if n.Tok == me && (except == nil || !hasLiteral(except.Except, n.Tok, n.Lit)) {
 				return true
 			}
 		case token.Token:
 			if n == me {
 				return true
 			}
+		case *exceptToken:
+			if n.Tok == me {
+				return true
+			}
 		default:
 			panic("unreachable")
 		}
@@ -160,7 +479,7 @@ func hasConflictMatchToken(me *MatchToken, next []any) bool {
 			if n.Tok == me.Tok && n.Lit == me.Lit {
 				return true
 			}
-		case token.Token:
+		case token.Token, *exceptToken:
 		default:
 			panic("unreachable")
 		}
@@ -171,13 +490,69 @@ func hasConflictMatchToken(me *MatchToken, next []any) bool {
 func hasConflictMe(me any, next []any) bool {
 	switch me := me.(type) {
 	case token.Token:
-		return hasConflictToken(me, next)
+		return hasConflictToken(me, nil, next)
 	case *MatchToken:
 		return hasConflictMatchToken(me, next)
+	case *exceptToken:
+		return hasConflictToken(me.Tok, me, next)
 	}
 	panic("unreachable")
 }
 
+// exclude excludes the literals of a negative lookahead from first[from:],
+// the first tokens of the items after it.
+func exclude(first []any, from int, lits []*MatchToken) []any {
+	ret := first[:from:from]
+	for _, f := range first[from:] {
+		switch f := f.(type) {
+		case *MatchToken:
+			if hasLiteral(lits, f.Tok, f.Lit) {
+				continue
+			}
+		case token.Token:
+			var except []*MatchToken
+			for _, lit := range lits {
+				if lit.Tok == f {
+					except = append(except, lit)
+				}
+			}
+			if except != nil {
+				ret = append(ret, &exceptToken{f, except})
+				continue
+			}
+		}
+		ret = append(ret, f)
+	}
+	return ret
+}
+
+// literals returns the literals that m matches if m matches a single literal
+// token, such as keyword of `keyword = "if" | "else"`.
+func literals(m Matcher, visited map[*Var]bool) (lits []*MatchToken, ok bool) {
+	d := Describe(m)
+	switch d.Kind {
+	case KindLiteral:
+		return []*MatchToken{{d.Tok, d.Lit}}, true
+	case KindVar:
+		v := m.(*Var)
+		if visited[v] || v.Elem == nil {
+			return nil, false
+		}
+		visited[v] = true
+		return literals(v.Elem, visited)
+	case KindChoice:
+		for _, option := range d.Items {
+			sub, ok := literals(option, visited)
+			if !ok {
+				return nil, false
+			}
+			lits = append(lits, sub...)
+		}
+		return lits, true
+	}
+	return nil, false
+}
+
 func hasConflict(me []any, next []any) bool {
 	for _, m := range me {
 		if hasConflictMe(m, next) {
@@ -227,7 +602,9 @@ func True() Matcher {
 type gWS struct{}
 
 func (p gWS) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
-	if left := len(src); left > 0 {
+	left := len(src)
+	ctx.see(left)
+	if left > 0 {
 		toks := ctx.toks
 		if n := len(toks); n > left {
 			last := n - left - 1
@@ -253,6 +630,7 @@ func WhiteSpace() Matcher {
 type gString byte
 
 func (p gString) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	ctx.see(len(src))
 	if len(src) == 0 {
 		return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", stringType(p))
 	}
@@ -282,10 +660,15 @@ func stringType(quoteCh gString) string {
 // -----------------------------------------------------------------------------
 
 type gToken struct {
-	tok token.Token
+	tok  token.Token
+	name string // name of a token defined by a grammar
 }
 
 func (p *gToken) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if p.name != "" {
+		return p.matchNamed(src, ctx)
+	}
+	ctx.see(len(src))
 	if len(src) == 0 {
 		return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", p.tok)
 	}
@@ -296,13 +679,31 @@ func (p *gToken) Match(src []*types.Token, ctx *Context) (n int, result any, err
 	return 1, t, nil
 }
 
+func (p *gToken) matchNamed(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	ctx.see(len(src))
+	if len(src) == 0 {
+		return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", p.name)
+	}
+	t := src[0]
+	if t.Tok != p.tok {
+		return 0, nil, ctx.NewErrorf(t.Pos, "expect `%s`, but got `%v`", p.name, t)
+	}
+	return 1, t, nil
+}
+
 func (p *gToken) First(in []any) (first []any, mayEmpty bool) {
 	return append(in, p.tok), false
 }
 
 // Token: ADD, SUB, IDENT, INT, FLOAT, CHAR, STRING, etc.
 func Token(tok token.Token) Matcher {
-	return &gToken{tok}
+	return &gToken{tok: tok}
+}
+
+// NamedToken: a token defined by a grammar, such as NUMBER = /[0-9]+/, whose
+// kind is tok (see scanner.TokenDef).
+func NamedToken(tok token.Token, name string) Matcher {
+	return &gToken{tok, name}
 }
 
 // -----------------------------------------------------------------------------
@@ -310,11 +711,12 @@ func Token(tok token.Token) Matcher {
 type gLiteral MatchToken
 
 func (p *gLiteral) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	ctx.see(len(src))
 	if len(src) == 0 {
 		return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", p.Lit)
 	}
 	t := src[0]
-	if t.Tok != p.Tok || t.Lit != p.Lit {
+	if t.Lit != p.Lit || t.Tok != p.Tok && t.Tok < token.USER_BEG { // a token rule may scan a keyword
 		return 0, nil, ctx.NewErrorf(t.Pos, "expect `%s`, but got `%v`", p.Lit, t)
 	}
 	return 1, t, nil
@@ -337,26 +739,84 @@ type Choices struct {
 	stops   []bool
 }
 
-func (p *Choices) CheckConflicts(conflict func(firsts [][]any, i, at int)) {
+// CheckConflicts checks if an option of p may start with the same token as a
+// later one, and calls conflict for the first such option at of each option i.
+// Options that start with p itself (the growing options of a left-recursive
+// rule, such as expr "-" INT in expr = expr "-" INT | INT) always conflict
+// with the others, so they aren't reported.
+//
+// It returns whether matching stops at each option if it matches some tokens
+// but fails, that is, the option isn't a growing one and isn't reported.
+func (p *Choices) CheckConflicts(conflict func(firsts [][]any, i, at int)) (stops []bool) {
 	options := p.options
 	n := len(options)
 	firsts := make([][]any, n)
+	growing := make([]bool, n)
 	for i, g := range options {
 		firsts[i], _ = g.First(nil)
+		growing[i] = leftCorner(g, p, make(map[Matcher]bool))
 	}
-	stops := make([]bool, n)
+	stops = make([]bool, n)
 	for i, me := range firsts {
-		at := conflictWith(me, firsts, i+1)
-		if at >= 0 {
-			conflict(firsts, i, at)
-		} else {
-			stops[i] = true
+		if growing[i] {
+			continue
+		}
+		stops[i] = true
+		for at := conflictWith(me, firsts, i+1); at >= 0; at = conflictWith(me, firsts, at+1) {
+			if !growing[at] {
+				conflict(firsts, i, at)
+				stops[i] = false
+				break
+			}
 		}
 	}
 	p.stops = stops
+	return
+}
+
+// leftCorner reports whether target may be matched at the start of m.
+func leftCorner(m, target Matcher, visited map[Matcher]bool) bool {
+	if m == target {
+		return true
+	}
+	if visited[m] {
+		return false
+	}
+	visited[m] = true
+	d := Describe(m)
+	switch d.Kind {
+	case KindSequence:
+		for _, item := range d.Items {
+			if leftCorner(item, target, visited) {
+				return true
+			}
+			if _, mayEmpty := item.First(nil); !mayEmpty {
+				break
+			}
+		}
+		return false
+	case KindAdjoin:
+		return leftCorner(d.Items[0], target, visited)
+	}
+	for _, item := range d.Items {
+		if item != nil && leftCorner(item, target, visited) {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *Choices) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if t := ctx.tracer; t != nil {
+		t.Enter(TraceChoice, "|", src)
+		n, result, err = p.match(src, ctx)
+		t.Leave(n, nil, err)
+		return
+	}
+	return p.match(src, ctx)
+}
+
+func (p *Choices) match(src []*types.Token, ctx *Context) (n int, result any, err error) {
 	var nMax = -1
 	var errMax error
 	var multiErr = true
@@ -423,7 +883,14 @@ func (p *gSequence) Match(src []*types.Token, ctx *Context) (n int, result any,
 }
 
 func (p *gSequence) First(in []any) (first []any, mayEmpty bool) {
-	for _, g := range p.items {
+	for i, g := range p.items {
+		if la, ok := g.(*gLookahead); ok && la.not && i+1 < len(p.items) {
+			if lits, ok := literals(la.r, make(map[*Var]bool)); ok { // eg. !keyword IDENT
+				from := len(in)
+				in, mayEmpty = (&gSequence{p.items[i+1:]}).First(in)
+				return exclude(in, from, lits), mayEmpty
+			}
+		}
 		if in, mayEmpty = g.First(in); !mayEmpty {
 			break
 		}
@@ -441,10 +908,21 @@ func Sequence(items ...Matcher) Matcher {
 // -----------------------------------------------------------------------------
 
 type gRepeat0 struct {
-	r Matcher
+	r      Matcher
+	follow []any // see InitFollow
 }
 
 func (p *gRepeat0) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if t := ctx.tracer; t != nil {
+		t.Enter(TraceRepeat, "*", src)
+		n, result, err = p.match(src, ctx)
+		t.Leave(n, nil, err)
+		return
+	}
+	return p.match(src, ctx)
+}
+
+func (p *gRepeat0) match(src []*types.Token, ctx *Context) (n int, result any, err error) {
 	g := p.r
 	rets := make([]any, 0, 2)
 	for {
@@ -452,6 +930,8 @@ func (p *gRepeat0) Match(src []*types.Token, ctx *Context) (n int, result any, e
 		if err1 != nil {
 			if isDyn(err1) {
 				err = err1
+			} else if skip, node, ok := ctx.recoverFrom(g, p.follow, src, n1, len(rets) > 0, err1); ok {
+				n1, ret1 = skip, node
 			} else {
 				ctx.SetLastError(len(src)-n1, err1)
 				result = rets
@@ -472,20 +952,35 @@ func (p *gRepeat0) First(in []any) (first []any, mayEmpty bool) {
 
 // Repeat0: *R
 func Repeat0(r Matcher) Matcher {
-	return &gRepeat0{r}
+	return &gRepeat0{r: r}
 }
 
 // -----------------------------------------------------------------------------
 
 type gRepeat1 struct {
-	r Matcher
+	r      Matcher
+	follow []any // see InitFollow
 }
 
 func (p *gRepeat1) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if t := ctx.tracer; t != nil {
+		t.Enter(TraceRepeat, "+", src)
+		n, result, err = p.match(src, ctx)
+		t.Leave(n, nil, err)
+		return
+	}
+	return p.match(src, ctx)
+}
+
+func (p *gRepeat1) match(src []*types.Token, ctx *Context) (n int, result any, err error) {
 	g := p.r
 	n, ret0, err := g.Match(src, ctx)
 	if err != nil {
-		return
+		skip, node, ok := ctx.recoverFrom(g, p.follow, src, n, false, err)
+		if !ok {
+			return
+		}
+		n, ret0, err = skip, node, nil
 	}
 
 	rets := make([]any, 1, 2)
@@ -495,6 +990,8 @@ func (p *gRepeat1) Match(src []*types.Token, ctx *Context) (n int, result any, e
 		if err1 != nil {
 			if isDyn(err1) {
 				err = err1
+			} else if skip, node, ok := ctx.recoverFrom(g, p.follow, src[n:], n1, true, err1); ok {
+				n1, ret1 = skip, node
 			} else {
 				ctx.SetLastError(len(src)-n-n1, err1)
 				result = rets
@@ -512,7 +1009,7 @@ func (p *gRepeat1) First(in []any) (first []any, mayEmpty bool) {
 
 // Repeat1: +R
 func Repeat1(r Matcher) Matcher {
-	return &gRepeat1{r}
+	return &gRepeat1{r: r}
 }
 
 // -----------------------------------------------------------------------------
@@ -522,6 +1019,16 @@ type gRepeat01 struct {
 }
 
 func (p *gRepeat01) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if t := ctx.tracer; t != nil {
+		t.Enter(TraceRepeat, "?", src)
+		n, result, err = p.match(src, ctx)
+		t.Leave(n, nil, err)
+		return
+	}
+	return p.match(src, ctx)
+}
+
+func (p *gRepeat01) match(src []*types.Token, ctx *Context) (n int, result any, err error) {
 	n, result, err = p.r.Match(src, ctx)
 	if err != nil {
 		return 0, nil, nil
@@ -540,11 +1047,128 @@ func Repeat01(r Matcher) Matcher {
 	return &gRepeat01{r}
 }
 
+// Repetition returns R and the minimum number of times to match it, if m is
+// *R or +R, or a rule whose expression is *R or +R.
+func Repetition(m Matcher) (r Matcher, min int, ok bool) {
+	if v, isVar := m.(*Var); isVar {
+		m = v.Elem
+	}
+	switch m := m.(type) {
+	case *gRepeat0:
+		return m.r, 0, true
+	case *gRepeat1:
+		return m.r, 1, true
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+type gLookahead struct {
+	r   Matcher
+	not bool
+}
+
+func (p *gLookahead) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	left, lastErr, recover := ctx.Left, ctx.LastErr, ctx.recover
+	ctx.recover = false // don't recover when looking ahead
+	_, _, err = p.r.Match(src, ctx)
+	ctx.Left, ctx.LastErr, ctx.recover = left, lastErr, recover
+	if p.not {
+		if err != nil {
+			return 0, nil, nil
+		}
+		ctx.see(len(src))
+		if len(src) == 0 {
+			return 0, nil, ctx.NewError(ctx.FileEnd, "unexpected EOF")
+		}
+		return 0, nil, ctx.NewErrorf(src[0].Pos, "unexpected `%v`", src[0])
+	}
+	return
+}
+
+// First returns in as is, since a lookahead consumes no tokens. For &R, the
+// first set of &R X is a subset of X's one, so it's conservative to ignore R.
+// For !R, if R matches a single literal token, such as keyword of
+// `!keyword IDENT`, the literals are excluded from the first set of X (see
+// gSequence.First).
+func (p *gLookahead) First(in []any) (first []any, mayEmpty bool) {
+	return in, true
+}
+
+// Lookahead: &R, matches if R matches, but consumes no tokens.
+func Lookahead(r Matcher) Matcher {
+	return &gLookahead{r, false}
+}
+
+// NotLookahead: !R, matches if R doesn't match, and consumes no tokens.
+func NotLookahead(r Matcher) Matcher {
+	return &gLookahead{r, true}
+}
+
 // -----------------------------------------------------------------------------
 
+type gList struct {
+	a, b   Matcher
+	seq    Matcher // a *(b a)
+	follow []any   // see InitFollow
+}
+
+func (p *gList) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if !ctx.recover {
+		return p.seq.Match(src, ctx)
+	}
+	return p.matchRecover(src, ctx)
+}
+
+// matchRecover matches the list in the recovery mode: when an element fails,
+// the tokens up to the next separator are skipped as an ErrorNode (see
+// Context.EnableRecover).
+func (p *gList) matchRecover(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	a, b := p.a, p.b
+	sync, _ := b.First(nil)
+	n, ret0, err := a.Match(src, ctx)
+	if err != nil && !isDyn(err) {
+		skip, node, ok := ctx.recoverTo(sync, p.follow, src, n, false, err)
+		if !ok {
+			return
+		}
+		n, ret0, err = skip, node, nil
+	}
+	rets := make([]any, 0, 2)
+	for {
+		n1, ret1, err1 := b.Match(src[n:], ctx)
+		if err1 != nil {
+			if !isDyn(err1) {
+				ctx.SetLastError(len(src)-n-n1, err1)
+				break
+			}
+			err = err1
+		}
+		n2, ret2, err2 := a.Match(src[n+n1:], ctx)
+		if err2 != nil {
+			if isDyn(err2) {
+				err = err2
+			} else if skip, node, ok := ctx.recoverTo(sync, p.follow, src[n+n1:], n2, true, err2); ok {
+				n2, ret2 = skip, node
+			} else {
+				ctx.SetLastError(len(src)-n-n1-n2, err2)
+				break
+			}
+		}
+		rets = append(rets, []any{ret1, ret2})
+		n += n1 + n2
+	}
+	return n, []any{ret0, rets}, err
+}
+
+func (p *gList) First(in []any) (first []any, mayEmpty bool) {
+	return p.seq.First(in)
+}
+
 // List: R1 % R2 is equivalent to R1 *(R2 R1)
 func List(a, b Matcher) Matcher {
-	return Sequence(a, Repeat0(Sequence(b, a)))
+	return &gList{a: a, b: b, seq: Sequence(a, Repeat0(Sequence(b, a)))}
 }
 
 // -----------------------------------------------------------------------------
@@ -600,9 +1224,129 @@ type Var struct {
 	Pos  token.Pos
 
 	RetProc any
+	Pure    bool // RetProc is side-effect-free, so its results can be memoized
+	LeftRec bool // is left-recursive (set by First)
+
+	inFirst bool
+}
+
+// Pure represents a side-effect-free RetProc (or ListRetProc).
+type Pure struct {
+	RetProc any
 }
 
 func (p *Var) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if t := ctx.tracer; t != nil {
+		t.Enter(TraceVar, p.Name, src)
+		n, result, err = p.matchMemo(src, ctx)
+		var ret any
+		if p.RetProc != nil && err == nil {
+			ret = result
+		}
+		t.Leave(n, ret, err)
+		return
+	}
+	return p.matchMemo(src, ctx)
+}
+
+func (p *Var) matchMemo(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if ctx.memo != nil && ctx.memoizable(p) {
+		key := memoKey{p, len(src)}
+		if e, ok := ctx.memo[key]; ok {
+			ctx.see(e.exam)
+			ctx.SetLastError(e.left, e.lastErr)
+			return e.n, e.result, e.err
+		}
+		exam, left, lastErr := ctx.exam, ctx.Left, ctx.LastErr
+		ctx.exam, ctx.Left = len(src), math.MaxInt
+		n, result, err = p.matchRec(src, ctx)
+		if ctx.growing == 0 { // results depending on a growing seed can't be memoized
+			ctx.memo[key] = memoEntry{n, result, err, ctx.exam, ctx.Left, ctx.LastErr}
+		}
+		ctx.see(exam)
+		if left <= ctx.Left {
+			ctx.Left, ctx.LastErr = left, lastErr
+		}
+		return
+	}
+	return p.matchRec(src, ctx)
+}
+
+// memoizable reports whether the results of matching v can be memoized, that
+// is, whether all RetProcs reachable from v are nil or pure. A rule without a
+// RetProc can't be memoized if a rule it refers to has an impure one, which
+// would not be called again.
+func (p *Context) memoizable(v *Var) bool {
+	pure, ok := p.pure[v]
+	if !ok {
+		if p.pure == nil {
+			p.pure = make(map[*Var]bool)
+		}
+		pure = pureRetProcs(v, make(map[*Var]bool))
+		p.pure[v] = pure
+	}
+	return pure
+}
+
+func pureRetProcs(m Matcher, visited map[*Var]bool) bool {
+	if v, ok := m.(*Var); ok {
+		if visited[v] {
+			return true
+		}
+		visited[v] = true
+		if v.RetProc != nil && !v.Pure {
+			return false
+		}
+	}
+	for _, item := range Describe(m).Items {
+		if item != nil && !pureRetProcs(item, visited) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Var) matchRec(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if p.LeftRec {
+		return p.growSeed(src, ctx)
+	}
+	return p.match(src, ctx)
+}
+
+// growSeed matches a left-recursive rule by growing a seed: the recursive
+// matching of this rule at the same token offset returns the seed, which is
+// failure at first, and then the result of the previous matching. It stops
+// growing when the matching doesn't consume more tokens.
+func (p *Var) growSeed(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	key := memoKey{p, len(src)}
+	if seed, ok := ctx.seeds[key]; ok { // left recursion
+		return seed.n, seed.result, seed.err
+	}
+	if ctx.seeds == nil {
+		ctx.seeds = make(map[memoKey]*memoEntry)
+	}
+	seed := &memoEntry{err: errLeftRec}
+	ctx.seeds[key] = seed
+	ctx.growing++
+	defer func() {
+		delete(ctx.seeds, key)
+		ctx.growing--
+	}()
+	for {
+		n, result, err = p.match(src, ctx)
+		failed := err != nil && !isDyn(err)
+		if seed.err != errLeftRec && (failed || n <= seed.n) {
+			break
+		}
+		*seed = memoEntry{n: n, result: result, err: err}
+		if failed {
+			break
+		}
+	}
+	return seed.n, seed.result, seed.err
+}
+
+func (p *Var) match(src []*types.Token, ctx *Context) (n int, result any, err error) {
 	g := p.Elem
 	if g == nil {
 		return 0, nil, ctx.NewErrorf(p.Pos, "variable `%s` not assigned", p.Name)
@@ -654,14 +1398,26 @@ func (p *Var) Match(src []*types.Token, ctx *Context) (n int, result any, err er
 
 func (p *Var) First(in []any) (first []any, mayEmpty bool) {
 	elem := p.Elem
-	if elem != nil {
-		p.Elem = nil // to stop recursion
-		first, mayEmpty = elem.First(in)
-		p.Elem = elem
+	if elem == nil {
+		panic(UndefinedRuleError{p})
+	}
+	if p.inFirst { // left recursion
+		p.LeftRec = true
+		return in, false
+	}
+	p.inFirst = true
+	defer func() { p.inFirst = false }()
+	return elem.First(in)
+}
+
+// SetRetProc sets the RetProc of this variable. retProc can be a RetProc, a
+// ListRetProc or a Pure one.
+func (p *Var) SetRetProc(retProc any) {
+	if pure, ok := retProc.(Pure); ok {
+		p.RetProc, p.Pure = pure.RetProc, true
 	} else {
-		panic(RecursiveError{p})
+		p.RetProc, p.Pure = retProc, false
 	}
-	return
 }
 
 // Assign assigns a value to this variable.
@@ -0,0 +1,481 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package matcher_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/scanner"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// ambiguous is a grammar whose choices share the same prefix, so matching
+// backtracks exponentially with the nesting depth of parentheses.
+const ambiguous = `
+expr = term "+" expr | term "-" expr | term
+
+term = factor "*" term | factor "/" term | factor
+
+factor = INT | "(" expr ")"
+`
+
+func newCompiler(t testing.TB, params ...any) tpl.Compiler {
+	return compile(t, ambiguous, params...)
+}
+
+func compile(t testing.TB, src string, params ...any) tpl.Compiler {
+	conf := &cl.Config{
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+		RetProcs:   make(map[string]any),
+	}
+	for i := 0; i < len(params); i += 2 {
+		conf.RetProcs[params[i].(string)] = params[i+1]
+	}
+	c, err := tpl.FromFile(nil, "", src, conf)
+	if err != nil {
+		t.Fatal("tpl.FromFile:", err)
+	}
+	return c
+}
+
+func nested(depth int) string {
+	return strings.Repeat("(", depth) + "1" + strings.Repeat(")", depth)
+}
+
+func TestMemo(t *testing.T) {
+	c := newCompiler(t)
+	src := nested(4) + " + 2 * " + nested(3)
+	ret1, err := c.ParseExprFrom("", src, nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	ret2, err := c.ParseExprFrom("", src, &tpl.Config{Memo: true})
+	if err != nil {
+		t.Fatal("Parse with memo:", err)
+	}
+	var b1, b2 strings.Builder
+	tpl.Fdump(&b1, ret1, "", "  ", false)
+	tpl.Fdump(&b2, ret2, "", "  ", false)
+	if b1.String() != b2.String() {
+		t.Fatal("Parse with memo:", b2.String())
+	}
+	if _, err = c.ParseExprFrom("", nested(3)+" +", &tpl.Config{Memo: true}); err == nil {
+		t.Fatal("Parse with memo: no error")
+	}
+}
+
+func TestMemoRetProc(t *testing.T) {
+	var calls int
+	factor := func(self any) any {
+		calls++
+		return self
+	}
+	parse := func(src string, memo bool, params ...any) int {
+		calls = 0
+		c := compile(t, src, params...)
+		if _, err := c.ParseExprFrom("", nested(3), &tpl.Config{Memo: memo}); err != nil {
+			t.Fatal("Parse:", err)
+		}
+		return calls
+	}
+	if n, n0 := parse(ambiguous, true, "factor", factor), parse(ambiguous, false, "factor", factor); n != n0 {
+		t.Fatal("RetProc is memoized:", n, n0)
+	}
+	if n := parse(ambiguous, true, "factor", tpl.Pure(factor)); n != 4 {
+		t.Fatal("pure RetProc isn't memoized:", n)
+	}
+
+	// term has no RetProc, but factor in it has an impure one
+	const inner = `
+expr = term "+" expr | term
+
+term = factor "*" term | factor
+
+factor = INT | "(" expr ")"
+`
+	if n, n0 := parse(inner, true, "factor", factor), parse(inner, false, "factor", factor); n != n0 {
+		t.Fatal("RetProc of an inner rule is memoized:", n, n0)
+	}
+	if n, n0 := parse(inner, true, "factor", tpl.Pure(factor)), parse(inner, false, "factor", tpl.Pure(factor)); n >= n0 {
+		t.Fatal("pure RetProc of an inner rule isn't memoized:", n, n0)
+	}
+}
+
+func intOf(v any) any {
+	if t, ok := v.(*tpl.Token); ok {
+		n, _ := strconv.Atoi(t.Lit)
+		return n
+	}
+	return v
+}
+
+func sub(self []any) any {
+	return intOf(self[0]).(int) - intOf(self[2]).(int)
+}
+
+func TestLeftRec(t *testing.T) {
+	direct := compile(t, `
+expr = expr "-" INT | INT
+`, "expr", intOf, "expr", tpl.Pure(func(self any) any {
+		if v, ok := self.([]any); ok {
+			return sub(v)
+		}
+		return intOf(self)
+	}))
+	indirect := compile(t, `
+expr = binary | INT
+
+binary = expr "-" INT
+`, "expr", tpl.Pure(intOf), "binary", tpl.Pure(sub))
+	for _, c := range []tpl.Compiler{direct, indirect} {
+		for _, memo := range []bool{false, true} {
+			conf := &tpl.Config{Memo: memo}
+			ret, err := c.ParseExprFrom("", "10 - 3 - 2", conf)
+			if err != nil || ret != 5 {
+				t.Fatal("Parse:", ret, err)
+			}
+			if ret, err = c.ParseExprFrom("", "10", conf); err != nil || ret != 10 {
+				t.Fatal("Parse:", ret, err)
+			}
+			if _, err = c.ParseExprFrom("", "10 -", conf); err == nil {
+				t.Fatal("Parse: no error")
+			}
+		}
+	}
+}
+
+func TestLeftRecCalc(t *testing.T) {
+	c := compile(t, `
+expr = expr ("+" | "-") term | term
+
+term = term ("*" | "/") factor | factor
+
+factor = INT | "(" expr ")"
+`, "expr", binaryOp, "term", binaryOp, "factor", func(self any) any {
+		if v, ok := self.([]any); ok {
+			return v[1]
+		}
+		return intOf(self)
+	})
+	ret, err := c.ParseExprFrom("", "100 / 10 / 5 - 2 * (3 - 1) - 1", nil)
+	if err != nil || ret != -3 {
+		t.Fatal("Parse:", ret, err)
+	}
+}
+
+// conflicts returns the conflicts (i, at) of choices of grammar src.
+func conflicts(t *testing.T, src string) (ret [][2]int) {
+	conf := &cl.Config{
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {
+			ret = append(ret, [2]int{i, at})
+		},
+	}
+	if _, err := tpl.FromFile(nil, "", src, conf); err != nil {
+		t.Fatal("tpl.FromFile:", err)
+	}
+	return
+}
+
+func TestLeftRecConflicts(t *testing.T) {
+	for _, src := range []string{
+		`expr = expr "-" INT | INT`,
+		`expr = binary | INT; binary = expr "-" INT`,
+		`expr = expr "+" term | expr "-" term | term; term = INT`,
+	} {
+		if ret := conflicts(t, src); ret != nil {
+			t.Fatal("conflicts of", src, ":", ret)
+		}
+	}
+	if ret := conflicts(t, `expr = expr "-" INT | INT "+" | INT`); !reflect.DeepEqual(ret, [][2]int{{1, 2}}) {
+		t.Fatal("conflicts:", ret)
+	}
+}
+
+func TestUndefinedRule(t *testing.T) {
+	a, b := matcher.NewVar(0, "a"), matcher.NewVar(0, "b")
+	a.Elem = matcher.Sequence(b, matcher.Token(token.INT))
+	func() {
+		defer func() {
+			if e, ok := recover().(matcher.UndefinedRuleError); !ok || e.Error() != "undefined rule b" {
+				t.Fatal("First:", e)
+			}
+		}()
+		a.First(nil)
+	}()
+	b.Elem = matcher.Token(token.IDENT)
+	if first, _ := a.First(nil); len(first) != 1 || first[0] != token.IDENT || a.LeftRec {
+		t.Fatal("First:", first, a.LeftRec)
+	}
+}
+
+func binaryOp(self any) any {
+	v, ok := self.([]any)
+	if !ok {
+		return self
+	}
+	x, y := v[0].(int), v[2].(int)
+	switch v[1].(*tpl.Token).Tok {
+	case '+':
+		return x + y
+	case '-':
+		return x - y
+	case '*':
+		return x * y
+	}
+	return x / y
+}
+
+func benchmarkParse(b *testing.B, depth int, memo bool) {
+	c := newCompiler(b)
+	src := nested(depth)
+	conf := &tpl.Config{Memo: memo}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.ParseExprFrom("", src, conf); err != nil {
+			b.Fatal("Parse:", err)
+		}
+	}
+}
+
+func BenchmarkNested4(b *testing.B)     { benchmarkParse(b, 4, false) }
+func BenchmarkNested4Memo(b *testing.B) { benchmarkParse(b, 4, true) }
+func BenchmarkNested5(b *testing.B)     { benchmarkParse(b, 5, false) }
+func BenchmarkNested5Memo(b *testing.B) { benchmarkParse(b, 5, true) }
+func BenchmarkNested32Memo(b *testing.B) {
+	benchmarkParse(b, 32, true)
+}
+
+func TestRecover(t *testing.T) {
+	c := compile(t, `
+stmts = *(stmt ";")
+
+stmt = IDENT "=" expr
+
+expr = INT % "+"
+`)
+	src := `a = 1 + 2
+b = + 3
+c = 4
+d = 5 6
+e 7
+f = 8
+`
+	if _, err := c.Parse("foo.txt", src, nil); err == nil {
+		t.Fatal("Parse: no error")
+	}
+	ret, err := c.Parse("foo.txt", src, &tpl.Config{Recover: true})
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		t.Fatal("Parse:", err)
+	}
+	if msg := list.Error(); msg != "foo.txt:2:5: expect `INT`, but got `+` (and 2 more errors)" {
+		t.Fatal("Parse:", msg)
+	}
+	if msg := list[2].Error(); msg != "foo.txt:5:3: expect `=`, but got `INT`" {
+		t.Fatal("Parse:", msg)
+	}
+	stmts := ret.([]any)
+	if len(stmts) != 6 {
+		t.Fatal("Parse:", len(stmts))
+	}
+	for i, stmt := range stmts {
+		_, isErr := stmt.(*tpl.ErrorNode)
+		if isErr != (i == 1 || i == 3 || i == 4) {
+			t.Fatal("Parse: stmt", i, stmt)
+		}
+	}
+	var b strings.Builder
+	tpl.Fdump(&b, stmts[1], "", "  ", false)
+	if b.String() != "ERROR expect `INT`, but got `+`\n" {
+		t.Fatal("Fdump:", b.String())
+	}
+}
+
+func TestRecoverFollow(t *testing.T) {
+	c := compile(t, `
+doc = *(stmt ";") ?(block ";")
+
+block = "{" *stmt "}"
+
+stmt = IDENT "=" INT | "if" IDENT block
+`)
+	src := `a = 1
+) x
+c = 2
+ d = +
+{ if x { e = 3 } 4 f = 5 }
+`
+	ret, err := c.Parse("foo.txt", src, &tpl.Config{Recover: true})
+	list, ok := err.(scanner.ErrorList)
+	if !ok || len(list) != 3 {
+		t.Fatal("Parse:", err)
+	}
+	if msg := list[0].Error(); msg != "foo.txt:2:1: expect `stmt`, but got `)`" {
+		t.Fatal("Parse:", msg)
+	}
+	if msg := list[1].Error(); msg != "foo.txt:4:6: expect `INT`, but got `+`" {
+		t.Fatal("Parse:", msg)
+	}
+	if msg := list[2].Error(); msg != "foo.txt:5:18: expect `stmt`, but got `4`" {
+		t.Fatal("Parse:", msg)
+	}
+	doc := ret.([]any)
+	var b strings.Builder
+	for _, item := range doc[0].([]any) {
+		_, isErr := item.(*tpl.ErrorNode)
+		fmt.Fprint(&b, isErr, " ")
+	}
+	block := doc[1].([]any)[0].([]any)
+	for _, stmt := range block[1].([]any) {
+		_, isErr := stmt.(*tpl.ErrorNode)
+		fmt.Fprint(&b, isErr, " ")
+	}
+	if b.String() != "false true false true false true false " {
+		t.Fatal("Parse:", b.String())
+	}
+}
+
+func TestRecoverList(t *testing.T) {
+	c := compile(t, `
+stmts = stmt % "," ";"
+
+stmt = IDENT "=" INT % "+"
+`)
+	src := `a = + 1, b = 2 + 3, c 4, d = x, e = 6`
+	ret, err := c.Parse("foo.txt", src, &tpl.Config{Recover: true})
+	list, ok := err.(scanner.ErrorList)
+	if !ok || len(list) != 3 {
+		t.Fatal("Parse:", err)
+	}
+	if msg := list[0].Error(); msg != "foo.txt:1:5: expect `INT`, but got `+`" {
+		t.Fatal("Parse:", msg)
+	}
+	if msg := list[2].Error(); msg != "foo.txt:1:30: expect `INT`, but got `IDENT`" {
+		t.Fatal("Parse:", msg)
+	}
+	v := ret.([]any)[0].([]any)
+	if _, ok := v[0].(*tpl.ErrorNode); !ok {
+		t.Fatal("Parse: head", v[0])
+	}
+	var b strings.Builder
+	for _, item := range v[1].([]any) {
+		_, isErr := item.([]any)[1].(*tpl.ErrorNode)
+		fmt.Fprint(&b, isErr, " ")
+	}
+	if b.String() != "false true true false " {
+		t.Fatal("Parse:", b.String())
+	}
+}
+
+func TestLookahead(t *testing.T) {
+	c := compile(t, `
+stmt = "if" IDENT | ident "=" INT
+
+ident = !keyword IDENT
+
+keyword = "if" | "else"
+`)
+	if _, err := c.ParseExprFrom("", "x = 1", nil); err != nil {
+		t.Fatal("Parse:", err)
+	}
+	if _, err := c.ParseExprFrom("", "if x", nil); err != nil {
+		t.Fatal("Parse:", err)
+	}
+	if _, err := c.ParseExprFrom("", "else = 1", nil); err == nil {
+		t.Fatal("Parse: no error")
+	}
+	const keywords = `
+ident = !keyword IDENT
+
+keyword = "if" | "else"
+`
+	if ret := conflicts(t, `stmt = ident "=" INT | "if" IDENT`+keywords); ret != nil {
+		t.Fatal("conflicts:", ret)
+	}
+	if ret := conflicts(t, `stmt = ident "=" INT | "for" IDENT | IDENT`+keywords); !reflect.DeepEqual(ret, [][2]int{{0, 1}}) {
+		t.Fatal("conflicts:", ret)
+	}
+	c = compile(t, `
+call = IDENT &"(" args
+
+args = "(" ?(IDENT % ",") ")"
+`)
+	ret, err := c.ParseExprFrom("", "f(x, y)", nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	if v := ret.([]any); len(v) != 3 || v[1] != nil {
+		t.Fatal("Parse:", ret)
+	}
+	if _, err = c.ParseExprFrom("", "f", nil); err == nil || err.Error() != "1:2: expect `(`, but got `;`" {
+		t.Fatal("Parse:", err)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	c := compile(t, `
+expr = term % "+"
+
+term = INT | "(" expr ")"
+`, "term", func(self any) any {
+		return intOf(self)
+	})
+	rec := &matcher.Recorder{Fset: token.NewFileSet()}
+	if _, err := c.ParseExprFrom("foo.txt", "1 + (2", &tpl.Config{Fset: rec.Fset, Tracer: rec}); err == nil {
+		t.Fatal("Parse: no error")
+	}
+	var b strings.Builder
+	rec.Fprint(&b)
+	if ret := b.String(); ret != "expr [0:1] ok `1`\n"+
+		"  term [0:1] ok `1` => 1\n"+
+		"    | [0:1] ok `1`\n"+
+		"  * [1:1] ok\n"+
+		"    term [2:4] fail `( 2`: foo.txt:1:7: expect `)`, but got `;`\n"+
+		"      | [2:4] fail `( 2`: foo.txt:1:7: expect `)`, but got `;`\n"+
+		"        expr [3:4] ok `2`\n"+
+		"          term [3:4] ok `2` => 2\n"+
+		"            | [3:4] ok `2`\n"+
+		"          * [4:4] ok\n" {
+		t.Fatal("Fprint:", ret)
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		t.Fatal("json.Marshal:", err)
+	}
+	var traces []struct {
+		Name string
+		Pos  string
+		Sub  []struct {
+			Name, Result string
+		}
+	}
+	if err = json.Unmarshal(data, &traces); err != nil {
+		t.Fatal("json.Unmarshal:", err)
+	}
+	if len(traces) != 1 || traces[0].Pos != "foo.txt:1:1" || traces[0].Sub[0].Result != "1" {
+		t.Fatal("MarshalJSON:", string(data))
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goplus/gop/tpl/token"
+	"github.com/goplus/gop/tpl/types"
+)
+
+// -----------------------------------------------------------------------------
+
+// TraceKind represents the kind of a matching attempt.
+type TraceKind string
+
+const (
+	TraceVar    TraceKind = "var"    // a rule
+	TraceChoice TraceKind = "choice" // R1 | R2 | ... | Rn
+	TraceRepeat TraceKind = "repeat" // *R, +R or ?R
+)
+
+// Tracer receives the matching attempts of rules, choices and repetitions
+// (see Context.SetTracer).
+type Tracer interface {
+	// Enter is called when an attempt starts to match src. name is the name of
+	// a rule, or the operator ("|", "*", "+" or "?") of others.
+	Enter(kind TraceKind, name string, src []*types.Token)
+
+	// Leave is called when the last entered attempt ends. result is the result
+	// returned by RetProc of a rule, or nil if there is no RetProc.
+	Leave(n int, result any, err error)
+}
+
+// SetTracer sets the tracer of matching attempts.
+func (p *Context) SetTracer(t Tracer) {
+	p.tracer = t
+}
+
+// -----------------------------------------------------------------------------
+
+// Trace represents a matching attempt and the attempts in it.
+type Trace struct {
+	Kind   TraceKind
+	Name   string
+	From   int   // index of the first token to match
+	To     int   // index after the last matched token
+	Err    error // nil means success
+	Result any   // result returned by RetProc of a rule
+	Sub    []*Trace
+	Parent *Trace
+
+	src  []*types.Token
+	done bool
+}
+
+// Tokens returns the tokens matched by this attempt.
+func (p *Trace) Tokens() []*types.Token {
+	return p.src[:p.To-p.From]
+}
+
+// Recorder is a Tracer which records all the matching attempts as a tree.
+// The first attempt must start from the first token, as tpl.Compiler does.
+type Recorder struct {
+	Fset   *token.FileSet
+	Traces []*Trace // top-level attempts
+
+	cur  *Trace // the attempt being matched
+	ntok int    // number of all tokens
+}
+
+// Current returns the attempt being matched, or nil.
+func (p *Recorder) Current() *Trace {
+	return p.cur
+}
+
+// Enter implements Tracer.
+func (p *Recorder) Enter(kind TraceKind, name string, src []*types.Token) {
+	if p.cur == nil && p.Traces == nil {
+		p.ntok = len(src)
+	}
+	from := p.ntok - len(src)
+	t := &Trace{Kind: kind, Name: name, From: from, To: from, Parent: p.cur, src: src}
+	if p.cur == nil {
+		p.Traces = append(p.Traces, t)
+	} else {
+		p.cur.Sub = append(p.cur.Sub, t)
+	}
+	p.cur = t
+}
+
+// Leave implements Tracer.
+func (p *Recorder) Leave(n int, result any, err error) {
+	t := p.cur
+	t.To, t.Result, t.Err, t.done = t.From+n, result, err, true
+	p.cur = t.Parent
+}
+
+// -----------------------------------------------------------------------------
+
+const maxTraceToks = 8 // max number of tokens printed for an attempt
+
+// TokensText returns the text of tokens, which is truncated if there are too
+// many tokens.
+func TokensText(toks []*types.Token) string {
+	var b strings.Builder
+	for i, t := range toks {
+		if i == maxTraceToks {
+			b.WriteString(" ...")
+			break
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if t.Lit != "" && t.Tok != token.SEMICOLON {
+			b.WriteString(t.Lit)
+		} else {
+			b.WriteString(t.Tok.String())
+		}
+	}
+	return b.String()
+}
+
+// String returns a line describing the attempt, such as:
+//
+//	expr [0:3] ok `1 + 2` => 3
+func (p *Trace) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s [%d:%d]", p.Name, p.From, p.To)
+	if !p.done {
+		b.WriteString(" ...")
+	} else if p.Err != nil {
+		b.WriteString(" fail")
+		if p.To > p.From {
+			b.WriteString(" `" + TokensText(p.Tokens()) + "`")
+		}
+		b.WriteString(": " + p.Err.Error())
+	} else {
+		b.WriteString(" ok")
+		if p.To > p.From {
+			b.WriteString(" `" + TokensText(p.Tokens()) + "`")
+		}
+		if p.Result != nil {
+			fmt.Fprintf(&b, " => %v", p.Result)
+		}
+	}
+	return b.String()
+}
+
+// Fprint prints the attempts as an indented tree.
+func (p *Recorder) Fprint(w io.Writer) {
+	for _, t := range p.Traces {
+		t.Fprint(w, "")
+	}
+}
+
+// Fprint prints the attempt and the attempts in it as an indented tree.
+func (p *Trace) Fprint(w io.Writer, indent string) {
+	fmt.Fprintln(w, indent+p.String())
+	for _, sub := range p.Sub {
+		sub.Fprint(w, indent+"  ")
+	}
+}
+
+type traceJSON struct {
+	Kind   TraceKind    `json:"kind"`
+	Name   string       `json:"name"`
+	From   int          `json:"from"`
+	To     int          `json:"to"`
+	Pos    string       `json:"pos,omitempty"`
+	Text   string       `json:"text,omitempty"`
+	Ok     bool         `json:"ok"`
+	Err    string       `json:"error,omitempty"`
+	Result string       `json:"result,omitempty"`
+	Sub    []*traceJSON `json:"sub,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The attempts are marshaled as an
+// array of objects, where the position of the first token, the matched text
+// and the result are strings.
+func (p *Recorder) MarshalJSON() ([]byte, error) {
+	ret := make([]*traceJSON, len(p.Traces))
+	for i, t := range p.Traces {
+		ret[i] = p.toJSON(t)
+	}
+	return json.Marshal(ret)
+}
+
+func (p *Recorder) toJSON(t *Trace) *traceJSON {
+	ret := &traceJSON{Kind: t.Kind, Name: t.Name, From: t.From, To: t.To, Ok: t.Err == nil}
+	if len(t.src) > 0 && p.Fset != nil {
+		ret.Pos = p.Fset.Position(t.src[0].Pos).String()
+	}
+	if t.To > t.From {
+		ret.Text = TokensText(t.Tokens())
+	}
+	if t.Err != nil {
+		ret.Err = t.Err.Error()
+	}
+	if t.Result != nil {
+		ret.Result = fmt.Sprint(t.Result)
+	}
+	if t.Sub != nil {
+		ret.Sub = make([]*traceJSON, len(t.Sub))
+		for i, sub := range t.Sub {
+			ret.Sub[i] = p.toJSON(sub)
+		}
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
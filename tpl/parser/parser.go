@@ -135,11 +135,11 @@ func (p *parser) parseFile() *ast.File {
 	}
 
 	for p.tok != token.EOF {
-		rule := p.parseRule()
-		if rule == nil {
+		decl := p.parseDecl()
+		if decl == nil {
 			break
 		}
-		file.Decls = append(file.Decls, rule)
+		file.Decls = append(file.Decls, decl)
 	}
 
 	return file
@@ -157,18 +157,71 @@ func (p *parser) parseIdent() *ast.Ident {
 	return &ast.Ident{NamePos: pos, Name: name}
 }
 
-// parseRule parses a rule:
-//
-//	IDENT '=' expr ';'
-//	IDENT '=' expr => { ... } ';'
-func (p *parser) parseRule() *ast.Rule {
+// parseQualifiedIdent parses IDENT or IDENT '.' IDENT, which refers to a rule
+// of an imported grammar. There is no space around '.'.
+func (p *parser) parseQualifiedIdent() *ast.Ident {
+	ident := p.parseIdent()
+	if p.tok == token.PERIOD && p.pos == ident.End() {
+		p.next()
+		if p.tok == token.IDENT && p.pos == ident.End()+1 {
+			ident.Name += "." + p.lit
+			p.next()
+		} else {
+			p.errorExpected(p.pos, "'IDENT'")
+		}
+	}
+	return ident
+}
+
+// parseDecl parses an import declaration or a rule.
+func (p *parser) parseDecl() ast.Decl {
 	if p.tok != token.IDENT {
 		p.errorExpected(p.pos, "'IDENT'")
 		return nil
 	}
+	if p.lit == "import" {
+		pos := p.pos
+		p.next()
+		switch p.tok {
+		case token.STRING, token.IDENT: // import "path" or import name "path"
+			return p.parseImport(pos)
+		}
+		return p.parseRule(&ast.Ident{NamePos: pos, Name: "import"})
+	}
+	return p.parseRule(p.parseQualifiedIdent())
+}
 
-	name := p.parseIdent()
-	tokPos := p.expect(token.ASSIGN)
+// parseImport parses an import declaration:
+//
+//	'import' STRING ';'
+//	'import' IDENT STRING ';'
+func (p *parser) parseImport(pos token.Pos) *ast.ImportDecl {
+	imp := &ast.ImportDecl{ImportPos: pos}
+	if p.tok == token.IDENT {
+		imp.Name = p.parseIdent()
+	}
+	imp.Path = &ast.BasicLit{ValuePos: p.pos, Kind: token.STRING, Value: p.lit}
+	if p.tok != token.STRING {
+		p.errorExpected(p.pos, "import path")
+		imp.Path.Value = `""`
+	}
+	p.next()
+	p.expect(token.SEMICOLON)
+	return imp
+}
+
+// parseRule parses a rule:
+//
+//	IDENT '=' expr ';'
+//	IDENT '=' expr => { ... } ';'
+//	IDENT '|=' expr ';'
+//	IDENT '|=' expr => { ... } ';'
+func (p *parser) parseRule(name *ast.Ident) *ast.Rule {
+	var tok token.Token = token.ASSIGN
+	if p.tok == token.OR_ASSIGN {
+		tok = token.OR_ASSIGN
+	}
+	tokPos := p.expect(tok)
 	expr := p.parseExpr()
 	if expr == nil {
 		return nil
@@ -195,6 +248,7 @@ func (p *parser) parseRule() *ast.Rule {
 	return &ast.Rule{
 		Name:    name,
 		TokPos:  tokPos,
+		Tok:     tok,
 		Expr:    expr,
 		RetProc: retProc,
 	}
@@ -311,16 +365,11 @@ func (p *parser) parseTerm2() (ast.Expr, bool) {
 	return x, true
 }
 
-// parseFactor: IDENT | CHAR | STRING | ('*' | '+' | '?') factor | '(' expr ')'
+// parseFactor: IDENT | IDENT '.' IDENT | CHAR | STRING | REGEXP | ('*' | '+' | '?' | '&' | '!') factor | '(' expr ')'
 func (p *parser) parseFactor() (ast.Expr, bool) {
 	switch tok := p.tok; tok {
 	case token.IDENT:
-		ident := &ast.Ident{
-			NamePos: p.pos,
-			Name:    p.lit,
-		}
-		p.next()
-		return ident, true
+		return p.parseQualifiedIdent(), true
 
 	case token.CHAR, token.STRING:
 		lit := &ast.BasicLit{
@@ -331,7 +380,16 @@ func (p *parser) parseFactor() (ast.Expr, bool) {
 		p.next()
 		return lit, true
 
-	case token.MUL, token.ADD, token.QUESTION:
+	case token.QUO, token.QUO_ASSIGN: // /regexp/
+		lit := &ast.BasicLit{
+			ValuePos: p.pos,
+			Kind:     token.REGEXP,
+			Value:    p.scanner.ScanRegexp(p.pos),
+		}
+		p.next()
+		return lit, true
+
+	case token.MUL, token.ADD, token.QUESTION, token.AND, token.NOT:
 		opPos := p.pos
 		p.next()
 
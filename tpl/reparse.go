@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/scanner"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// Edit represents a text edit, which replaces the bytes [Start, End) of a
+// source with Text.
+type Edit struct {
+	Start, End int
+	Text       string
+}
+
+// Reparse matches the source of prev (see [Compiler.Match]) after an edit,
+// and returns the same results as matching the new source from scratch.
+//
+// Only the tokens around the edit are scanned again, and the tokens after it
+// are reused and moved. If memoization is enabled (see [Config.Memo]), the
+// results of rules which only depend on the tokens before the edit, or which
+// only consist of the tokens after it, are reused too, so only the affected
+// region is matched again.
+//
+// prev must be returned by Match or Reparse of the same Compiler, and it can't
+// be used anymore, since its tokens are moved. The new source is added to a
+// new FileSet, with the same name and base as the previous one, and
+// conf.Fset is ignored. If conf.Scanner or conf.Recover is specified, the new
+// source is matched from scratch.
+func (p *Compiler) Reparse(prev *MatchState, edit Edit, conf *Config) (ms MatchState, result any, err error) {
+	if prev.file == nil {
+		err = errors.New("tpl.Reparse: prev isn't returned by Match or Reparse")
+		return
+	}
+	old := prev.src
+	if edit.Start < 0 || edit.Start > edit.End || edit.End > len(old) {
+		err = fmt.Errorf("tpl.Reparse: invalid edit [%d, %d) of a %d-byte source", edit.Start, edit.End, len(old))
+		return
+	}
+	src := make([]byte, 0, len(old)-(edit.End-edit.Start)+len(edit.Text))
+	src = append(src, old[:edit.Start]...)
+	src = append(src, edit.Text...)
+	src = append(src, old[edit.End:]...)
+	if conf == nil {
+		conf = &Config{}
+	}
+	if conf.Scanner != nil || conf.Recover {
+		return p.Match(prev.file.Name(), src, conf)
+	}
+
+	fset := token.NewFileSet()
+	base := prev.file.Base()
+	f := fset.AddFile(prev.file.Name(), base, len(src))
+	f.SetLinesForContent(src)
+
+	// the scanner has no state after a semicolon (or any character if it's
+	// scannerless), so it rescans from the last one before the edit, and
+	// stops at the first one after the edit which is the same as before.
+	all := prev.all
+	start, from := 0, 0 // index of the first token to rescan, and its offset
+	for i, t := range all {
+		if int(t.Pos)-base+len(t.Lit) > edit.Start {
+			break
+		}
+		if resume, read, ok := p.boundary(t, old, base); ok && read <= edit.Start {
+			start, from = i+1, resume
+		}
+	}
+
+	mode := conf.ScanMode
+	if p.Scannerless {
+		mode |= scanner.ScanChars
+	}
+	s := new(scanner.Scanner)
+	s.SetTokens(p.Tokens)
+	s.InitEx(f, src, from, conf.ScanErrorHandler, mode)
+
+	delta := len(edit.Text) - (edit.End - edit.Start)
+	after := edit.Start + len(edit.Text) // offset of the source after the edit
+	toks := append(make([]*Token, 0, len(all)+8), all[:start]...)
+	end, j := len(all), start
+	for {
+		t := s.Scan()
+		if t.Tok == token.EOF {
+			break
+		}
+		toks = append(toks, &t)
+		offs := int(t.Pos) - base
+		if offs < after {
+			continue
+		}
+		if _, _, ok := p.boundary(&t, src, base); !ok {
+			continue
+		}
+		for j < len(all) && int(all[j].Pos)-base < offs-delta {
+			j++
+		}
+		if j < len(all) && int(all[j].Pos)-base == offs-delta && all[j].Tok == t.Tok && all[j].Lit == t.Lit {
+			end = j + 1
+			break
+		}
+	}
+	suffix := all[end:]
+	for _, t := range suffix {
+		t.Pos += token.Pos(delta)
+	}
+	toks = append(toks, suffix...)
+
+	var reuse func(ctx *matcher.Context)
+	if conf.Memo && prev.Ctx != nil {
+		reuse = func(ctx *matcher.Context) {
+			ctx.ReuseMemo(prev.Ctx, start, end, token.Pos(delta))
+		}
+	}
+	return p.match(fset, f, src, toks, conf, reuse)
+}
+
+// boundary reports whether the scanner has no state after scanning t, and if
+// so, returns the offset to resume scanning from, and the end of the bytes
+// read to scan t.
+func (p *Compiler) boundary(t *Token, src []byte, base int) (resume, read int, ok bool) {
+	offs := int(t.Pos) - base
+	if p.Scannerless {
+		end := offs + len(t.Lit)
+		return end, end, true
+	}
+	if t.Tok != token.SEMICOLON {
+		return
+	}
+	switch {
+	case t.Lit == ";":
+		return offs + 1, offs + 1, true
+	case offs >= len(src): // inserted at EOF
+		return
+	case src[offs] == '\n':
+		return offs + 1, offs + 1, true
+	case src[offs] == '#': // inserted before a comment
+		return offs, offs + 1, true
+	case src[offs] == '/' && offs+1 < len(src) && src[offs+1] == '/':
+		return offs, offs + 2, true
+	}
+	return // a /*-style comment is read to find a newline
+}
+
+// -----------------------------------------------------------------------------
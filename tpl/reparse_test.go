@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/cl"
+)
+
+func TestReparse(t *testing.T) {
+	calls := 0
+	c := compile(t, `
+doc = *stmt
+
+stmt = IDENT "=" expr ";"
+
+expr = operand *(("+" | "-") operand)
+
+operand = INT | IDENT | "(" expr ")"
+`, "stmt", tpl.Pure(func(self []any) any {
+		calls++
+		return []any{self[0].(*tpl.Token).Lit, self[2]}
+	}))
+
+	var b strings.Builder
+	for i := 0; i < 20; i++ {
+		b.WriteString("x" + strconv.Itoa(i) + " = (a + " + strconv.Itoa(i) + ") - b // comment\n")
+	}
+	src := b.String()
+	edits := []tpl.Edit{
+		{Start: 6, End: 7, Text: "10"},                           // change a token
+		{Start: 0, End: 2, Text: "y"},                            // at the beginning
+		{Start: 40, End: 40, Text: "\n"},                         // a newline inserts a semicolon
+		{Start: 40, End: 41},                                     // undo it
+		{Start: 104, End: 107, Text: "abc"},                      // in a comment
+		{Start: 62, End: 63, Text: "c z"},                        // split a token
+		{Start: 62, End: 65, Text: "cz"},                         // merge tokens
+		{Start: 141, End: 197},                                   // remove lines
+		{Start: 141, End: 141, Text: "w = (1 + 2) + 3; v = w\n"}, // add statements
+		{Start: -1, Text: "z = -"},                               // at the end
+	}
+	testReparse(t, c, src, edits, &calls)
+
+	ms, _, _ := c.Match("", src, nil)
+	if _, _, err := c.Reparse(&ms, tpl.Edit{Start: 1, End: 0}, nil); err == nil {
+		t.Fatal("Reparse: no error")
+	}
+
+	// scannerless: tokens matched by regular expressions are created by the
+	// matcher, so they can't be reused after the edit
+	c, err := tpl.FromFile(nil, "", `
+doc = *(line '\n')
+
+line = NAME ?" " "=" ?" " (NUMBER | NAME)
+
+NAME = /[a-z]+/
+
+NUMBER = /[0-9]+(\.[0-9]+)?/
+`, &cl.Config{Scannerless: true})
+	if err != nil {
+		t.Fatal("tpl.FromFile:", err)
+	}
+	testReparse(t, c, "x = 1.5\ny=abc\nzz = 3\n", []tpl.Edit{
+		{Start: 5, End: 5, Text: "2"},
+		{Start: 9, End: 10, Text: "qq"},
+		{Start: 0, End: 1, Text: "ab"},
+		{Start: 7, End: 8},
+		{Start: 3, End: 3, Text: "  "},
+	}, new(int))
+}
+
+// testReparse applies edits to src one by one, and checks that reparsing
+// returns the same results as parsing from scratch. calls is the number of
+// calls of a pure RetProc, which should be less when memoization is enabled.
+func testReparse(t *testing.T, c tpl.Compiler, src string, edits []tpl.Edit, calls *int) {
+	t.Helper()
+	for _, memo := range []bool{false, true} {
+		conf := &tpl.Config{Memo: memo}
+		cur := src
+		ms, _, err := c.Match("", cur, conf)
+		if err != nil {
+			t.Fatal("Match:", err)
+		}
+		for _, e := range edits {
+			if e.Start < 0 {
+				e.Start, e.End = len(cur), len(cur)
+			}
+			cur = cur[:e.Start] + e.Text + cur[e.End:]
+			*calls = 0
+			ms2, ret2, err2 := c.Reparse(&ms, e, conf)
+			reparsed := *calls
+			*calls = 0
+			ms3, ret, err := c.Match("", cur, conf)
+			if memo && err == nil && e.Start > 40 && reparsed >= *calls { // results before the edit are reused
+				t.Fatal("Reparse: results aren't reused", e, reparsed, *calls)
+			}
+			if (err == nil) != (err2 == nil) || err != nil && err.Error() != err2.Error() {
+				t.Fatal("Reparse:", e, err, err2)
+			}
+			if ms3.N != ms2.N || ms3.Ctx.Left != ms2.Ctx.Left || !reflect.DeepEqual(ms3.Toks, ms2.Toks) || !reflect.DeepEqual(ret, ret2) {
+				t.Fatalf("Reparse %v:\n%v\n%v", e, ret, ret2)
+			}
+			ms = ms2
+		}
+	}
+}
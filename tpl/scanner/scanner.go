@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"unicode"
 	"unicode/utf8"
@@ -44,6 +45,7 @@ type Scanner struct {
 	src  []byte       // source
 	err  ErrorHandler // error reporting; or nil
 	mode Mode         // scanning mode
+	defs []*TokenDef  // tokens defined by a grammar
 
 	// scanning state
 	ch         rune // current character
@@ -113,8 +115,29 @@ const (
 
 	// NoInsertSemis means don't automatically insert semicolons
 	NoInsertSemis
+
+	// ScanChars means returning each character, including whitespace, as a
+	// CHAR token whose literal is the character itself (unquoted), for
+	// scannerless parsing.
+	ScanChars
 )
 
+// TokenDef defines a token by a regular expression, such as the token rule
+// NUMBER = /[0-9]+/ of a grammar (see package tpl/cl).
+type TokenDef struct {
+	Tok    token.Token // token.USER_BEG, token.USER_BEG+1, ...
+	Name   string
+	Regexp *regexp.Regexp // anchored at the beginning, eg. ^(?:[0-9]+)
+}
+
+// SetTokens sets the tokens defined by a grammar. They take precedence over
+// the builtin tokens: at every token position, the longest match of them is
+// returned (the first defined one if there are several), and the builtin
+// tokens are scanned only if none of them matches.
+func (s *Scanner) SetTokens(defs []*TokenDef) {
+	s.defs = defs
+}
+
 // Init prepares the scanner s to tokenize the text src by setting the
 // scanner at the beginning of src. The scanner uses the file set file
 // for position information and it adds line information for each line.
@@ -158,6 +181,55 @@ func (s *Scanner) InitEx(file *token.File, src []byte, offset int, err ErrorHand
 	}
 }
 
+// scanDef scans a token defined by SetTokens.
+func (s *Scanner) scanDef() (tok token.Token, lit string) {
+	src := s.src[s.offset:]
+	n := 0
+	for _, def := range s.defs {
+		if loc := def.Regexp.FindIndex(src); loc != nil && loc[1] > n {
+			tok, n = def.Tok, loc[1]
+		}
+	}
+	if n == 0 {
+		return
+	}
+	for end := s.offset + n; s.offset < end && s.ch >= 0; {
+		s.next()
+	}
+	return tok, string(src[:n])
+}
+
+// ScanRegexp scans the rest of a regular expression literal /.../, whose
+// opening '/' has been returned by Scan as a QUO (or QUO_ASSIGN) token at pos,
+// and returns the whole literal.
+func (s *Scanner) ScanRegexp(pos token.Pos) string {
+	offs := s.file.Offset(pos)
+	inClass := false
+	for {
+		ch := s.ch
+		if ch == '\n' || ch < 0 {
+			s.error(offs, "regexp literal not terminated")
+			break
+		}
+		s.next()
+		if ch == '\\' {
+			if s.ch != '\n' && s.ch >= 0 {
+				s.next()
+			}
+		} else if ch == '[' {
+			inClass = true
+		} else if ch == ']' {
+			inClass = false
+		} else if ch == '/' && !inClass {
+			break
+		}
+	}
+	if s.mode&NoInsertSemis == 0 {
+		s.insertSemi = true
+	}
+	return string(s.src[offs:s.offset])
+}
+
 // CodeTo returns the source code snippet for the given end.
 func (s *Scanner) CodeTo(end int) []byte {
 	return s.src[:end]
@@ -746,6 +818,18 @@ func (s *Scanner) tokSEMICOLON() token.Token {
 // set with Init. Token positions are relative to that file
 // and thus relative to the file set.
 func (s *Scanner) Scan() (t types.Token) {
+	if s.mode&ScanChars != 0 {
+		t.Pos = s.file.Pos(s.offset)
+		if s.ch < 0 {
+			t.Tok = token.EOF
+			return
+		}
+		offs := s.offset
+		s.next()
+		t.Tok, t.Lit = token.CHAR, string(s.src[offs:s.offset])
+		return
+	}
+
 scanAgain:
 	s.skipWhitespace()
 
@@ -761,6 +845,13 @@ scanAgain:
 		s.unitVal = ""
 		goto done
 	}
+	if s.defs != nil && s.ch >= 0 && s.ch != '\n' {
+		if tok, lit := s.scanDef(); lit != "" {
+			insertSemi = true
+			t.Tok, t.Lit = tok, lit
+			goto done
+		}
+	}
 	switch ch := s.ch; {
 	case isLetter(ch):
 		insertSemi = true
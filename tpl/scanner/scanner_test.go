@@ -17,6 +17,8 @@
 package scanner
 
 import (
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/goplus/gop/tpl/token"
@@ -196,3 +198,90 @@ world !=
 		t.Fatalf("len(expected) != i: %d, %d\n", len(expected), i)
 	}
 }
+
+func scanAll(s *Scanner, src string, defs []*TokenDef, mode Mode) []Token {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	s.SetTokens(defs)
+	s.Init(file, []byte(src), nil, mode)
+	var ret []Token
+	for {
+		c := s.Scan()
+		if c.Tok == token.EOF {
+			return ret
+		}
+		ret = append(ret, c)
+	}
+}
+
+func TestTokenDefs(t *testing.T) {
+	defs := []*TokenDef{
+		{Tok: token.USER_BEG, Name: "VERSION", Regexp: regexp.MustCompile(`^(?:v[0-9]+(\.[0-9]+)*)`)},
+		{Tok: token.USER_BEG + 1, Name: "PATH", Regexp: regexp.MustCompile(`^(?:[a-z]+(/[a-z]+)+)`)},
+	}
+	var s Scanner
+	toks := scanAll(&s, "require github/goplus v1.2 (x)\n", defs, 0)
+	expected := []Token{
+		{Tok: token.IDENT, Pos: 1, Lit: "require"},
+		{Tok: token.USER_BEG + 1, Pos: 9, Lit: "github/goplus"},
+		{Tok: token.USER_BEG, Pos: 23, Lit: "v1.2"},
+		{Tok: '(', Pos: 28},
+		{Tok: token.IDENT, Pos: 29, Lit: "x"},
+		{Tok: ')', Pos: 30},
+		{Tok: ';', Pos: 31, Lit: "\n"},
+	}
+	if !reflect.DeepEqual(toks, expected) {
+		t.Fatal("Scan:", toks)
+	}
+}
+
+func TestScanChars(t *testing.T) {
+	var s Scanner
+	toks := scanAll(&s, "a =\n中", nil, ScanChars)
+	expected := []Token{
+		{Tok: token.CHAR, Pos: 1, Lit: "a"},
+		{Tok: token.CHAR, Pos: 2, Lit: " "},
+		{Tok: token.CHAR, Pos: 3, Lit: "="},
+		{Tok: token.CHAR, Pos: 4, Lit: "\n"},
+		{Tok: token.CHAR, Pos: 5, Lit: "中"},
+	}
+	if !reflect.DeepEqual(toks, expected) {
+		t.Fatal("Scan:", toks)
+	}
+}
+
+func TestScanRegexp(t *testing.T) {
+	const src = `NUMBER = /[0-9]+(\.[0-9]+)?/
+PATH = /[a-z\/]+|[/]/
+BAD = /abc
+`
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	var errs []string
+	var s Scanner
+	s.Init(file, []byte(src), func(pos token.Position, msg string) {
+		errs = append(errs, pos.String()+": "+msg)
+	}, 0)
+	var lits []string
+	for {
+		c := s.Scan()
+		if c.Tok == token.EOF {
+			break
+		}
+		if c.Tok == token.QUO {
+			c.Lit = s.ScanRegexp(c.Pos)
+		}
+		lits = append(lits, c.String())
+	}
+	expected := []string{
+		"NUMBER", "=", `/[0-9]+(\.[0-9]+)?/`, "\n",
+		"PATH", "=", `/[a-z\/]+|[/]/`, "\n",
+		"BAD", "=", "/abc", "\n",
+	}
+	if !reflect.DeepEqual(lits, expected) {
+		t.Fatal("Scan:", lits)
+	}
+	if len(errs) != 1 || errs[0] != "3:7: regexp literal not terminated" {
+		t.Fatal("errors:", errs)
+	}
+}
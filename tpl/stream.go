@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/scanner"
+	"github.com/goplus/gop/tpl/token"
+)
+
+const (
+	streamChunkSize    = 64 << 10 // size of a read from the source
+	streamMaxLookahead = 1 << 20  // default of Config.MaxLookahead
+)
+
+// Stream parses a source read from an io.Reader incrementally, whose root rule
+// is *R or +R: it returns the result of R match by match, so the source isn't
+// held in memory as a whole (see [Compiler.NewStream]).
+type Stream struct {
+	item     matcher.Matcher
+	min      int // minimum number of matches of item
+	count    int // number of matches returned
+	conf     *Config
+	fset     *token.FileSet
+	scanner  Scanner
+	filename string
+	r        io.Reader
+
+	buf  []byte // source read but not scanned yet
+	line int    // line of buf[0]
+	eof  bool   // all the source is read
+
+	toks    []*Token  // the last matched token (if any), and tokens not matched yet
+	i       int       // index of the first token not matched yet
+	fileEnd token.Pos // end of the source scanned
+	err     error
+}
+
+// NewStream creates a stream to parse the source read from r. The root rule of
+// the grammar must be *R or +R, whose RetProc isn't called. Each call of
+// [Stream.Next] returns the result of the next match of R.
+//
+// The source is read and scanned line by line when more tokens are needed,
+// and the tokens matched are released, so a token can't span lines unless
+// it's a raw string or a comment.
+//
+// The lines scanned are added to the file set as files, so that positions of
+// all the results stay valid. Hence the file set isn't released but grows
+// with the number of lines of the source, about 8 bytes per line.
+//
+// conf.Recover isn't supported.
+func (p *Compiler) NewStream(filename string, r io.Reader, conf *Config) (*Stream, error) {
+	item, min, ok := matcher.Repetition(p.Doc)
+	if !ok {
+		return nil, fmt.Errorf("tpl.NewStream: root rule `%s` isn't in form of *R or +R", p.Doc.Name)
+	}
+	if conf == nil {
+		conf = &Config{}
+	} else if conf.Recover {
+		return nil, errors.New("tpl.NewStream: conf.Recover isn't supported")
+	}
+	s := conf.Scanner
+	if s == nil {
+		ts := new(scanner.Scanner)
+		ts.SetTokens(p.Tokens)
+		s = ts
+	}
+	if p.Scannerless {
+		c := *conf
+		c.ScanMode |= scanner.ScanChars
+		conf = &c
+	}
+	fset := conf.Fset
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	return &Stream{
+		item: item, min: min, conf: conf, fset: fset, scanner: s,
+		filename: filename, r: r, line: 1,
+	}, nil
+}
+
+// Fset returns the file set of positions of tokens.
+func (p *Stream) Fset() *token.FileSet {
+	return p.fset
+}
+
+// Next returns the result of the next match of R, where the root rule is *R
+// or +R. It returns io.EOF if there are no more matches.
+func (p *Stream) Next() (result any, err error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	for {
+		toks := p.toks[p.i:]
+		if len(toks) == 0 && !p.eof {
+			if err = p.fill(); err != nil {
+				break
+			}
+			continue
+		}
+		if len(toks) == 0 && p.count >= p.min {
+			return nil, io.EOF
+		}
+		ctx := p.newContext()
+		var n int
+		n, result, err = p.item.Match(toks, ctx)
+		if ctx.HitEnd() && !p.eof {
+			if err = p.checkLookahead(); err == nil {
+				err = p.fill()
+			}
+			if err != nil {
+				break
+			}
+			continue
+		}
+		if err == nil && n == 0 {
+			err = ctx.NewErrorf(toks[0].Pos, "unexpected token: %v", toks[0])
+		} else if err != nil && p.count >= p.min {
+			if e, ok := err.(*matcher.Error); !ok || !e.Dyn {
+				err = p.unexpected(ctx, toks, n, err)
+			}
+		}
+		if err != nil {
+			break
+		}
+		if p.i > 0 { // release the matched tokens, except the last one
+			p.toks[p.i-1] = nil
+		}
+		for i := range toks[:n-1] {
+			toks[i] = nil
+		}
+		p.i += n
+		p.count++
+		return
+	}
+	p.err = err
+	return nil, err
+}
+
+// unexpected returns the error of failing to match R after the first min
+// matches, which is reported as the unexpected token, as [Compiler.Parse] does.
+func (p *Stream) unexpected(ctx *matcher.Context, toks []*Token, n int, err error) error {
+	ctx.SetLastError(len(toks)-n, err)
+	if left := ctx.Left; left > 0 {
+		t := toks[len(toks)-left]
+		return ctx.NewErrorf(t.Pos, "unexpected token: %v", t)
+	}
+	return ctx.NewErrorf(p.fileEnd, "unexpected token: %v", &Token{Tok: token.EOF, Pos: p.fileEnd})
+}
+
+func (p *Stream) newContext() *matcher.Context {
+	// the last matched token is kept for matching adjacency of the first token
+	ctx := matcher.NewContext(p.fset, p.fileEnd, p.toks)
+	ctx.Left = len(p.toks) - p.i
+	if p.conf.Memo {
+		ctx.EnableMemo()
+	}
+	if p.conf.Tracer != nil {
+		ctx.SetTracer(p.conf.Tracer)
+	}
+	return ctx
+}
+
+func (p *Stream) maxLookahead() int {
+	if n := p.conf.MaxLookahead; n > 0 {
+		return n
+	}
+	return streamMaxLookahead
+}
+
+// checkLookahead checks the size of the source buffered to match R.
+func (p *Stream) checkLookahead() error {
+	if p.i == len(p.toks) {
+		return nil
+	}
+	first, last := p.toks[p.i], p.toks[len(p.toks)-1]
+	if max := p.maxLookahead(); int(last.End()-first.Pos)+len(p.buf) > max {
+		return &matcher.Error{Fset: p.fset, Pos: first.Pos, Msg: fmt.Sprintf("lookahead exceeds %d bytes", max)}
+	}
+	return nil
+}
+
+// fill reads more of the source, and scans the complete lines of it.
+func (p *Stream) fill() error {
+	var chunk [streamChunkSize]byte
+	for !p.eof {
+		n, err := p.r.Read(chunk[:])
+		p.buf = append(p.buf, chunk[:n]...)
+		if err == io.EOF {
+			p.eof = true
+		} else if err != nil {
+			return err
+		}
+		end := len(p.buf)
+		if !p.eof {
+			end = bytes.LastIndexByte(p.buf, '\n') + 1
+		}
+		if (end > 0 || p.eof) && p.scan(end) {
+			return nil
+		}
+		if len(p.buf) > p.maxLookahead() {
+			return fmt.Errorf("%s:%d: lookahead exceeds %d bytes", p.filename, p.line, p.maxLookahead())
+		}
+	}
+	return nil
+}
+
+type scanError struct {
+	pos token.Position
+	msg string
+}
+
+// scan scans buf[:end], unless there are errors and more of the source may
+// fix them, eg. a raw string which spans the lines read and the lines to read.
+func (p *Stream) scan(end int) bool {
+	var errs []scanError
+	src := p.buf[:end]
+	f := p.fset.AddFile(p.filename, -1, end)
+	f.AddLineColumnInfo(0, p.filename, p.line, 1)
+	p.scanner.Init(f, src, func(pos token.Position, msg string) {
+		errs = append(errs, scanError{pos, msg})
+	}, p.conf.ScanMode)
+	var toks []*Token
+	for {
+		t := p.scanner.Scan()
+		if t.Tok == token.EOF {
+			break
+		}
+		toks = append(toks, &t)
+	}
+	if errs != nil && !p.eof && len(p.buf) <= p.maxLookahead() {
+		return false
+	}
+	if h := p.conf.ScanErrorHandler; h != nil {
+		for _, e := range errs {
+			h(e.pos, e.msg)
+		}
+	}
+	if p.i > 1 {
+		p.toks = p.toks[:copy(p.toks, p.toks[p.i-1:])]
+		p.i = 1
+	}
+	p.toks = append(p.toks, toks...)
+	p.fileEnd = token.Pos(f.Base() + end)
+	p.line += bytes.Count(src, []byte{'\n'})
+	p.buf = p.buf[:copy(p.buf, p.buf[end:])]
+	return true
+}
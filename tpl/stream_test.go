@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/goplus/gop/tpl"
+)
+
+func TestStream(t *testing.T) {
+	c, err := tpl.New(`
+doc = *stmt
+
+stmt = IDENT "=" value ";"
+
+value = INT | STRING | "[" value % "," "]"
+`, "stmt", func(self []any) any {
+		return self[0].(*tpl.Token).Lit
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	src := "a = 1;\nb = `x\ny`; c = [1,\n2,\n3];\n\nd = \"e\";\n"
+	want, err := c.Parse("", src, nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	s, err := c.NewStream("foo.txt", iotest.OneByteReader(strings.NewReader(src)), nil)
+	if err != nil {
+		t.Fatal("NewStream:", err)
+	}
+	var got []any
+	for {
+		v, err := s.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal("Next:", err)
+		}
+		got = append(got, v)
+	}
+	if w := want.([]any); len(w) != len(got) || w[3] != got[3] || got[0] != "a" || got[3] != "d" {
+		t.Fatal("Next:", got, want)
+	}
+	if _, err = s.Next(); err != io.EOF {
+		t.Fatal("Next after EOF:", err)
+	}
+
+	s, _ = c.NewStream("foo.txt", strings.NewReader("a = 1;\nb = [1,\n2 = 3];\n"), nil)
+	if v, err := s.Next(); err != nil || v != "a" {
+		t.Fatal("Next:", v, err)
+	}
+	if _, err = s.Next(); err == nil || err.Error() != "foo.txt:3:3: unexpected token: =" {
+		t.Fatal("Next:", err)
+	}
+	if _, err = c.NewStream("", strings.NewReader(""), nil); err != nil {
+		t.Fatal("NewStream:", err)
+	}
+	if _, err = c.NewStream("", strings.NewReader(""), &tpl.Config{Recover: true}); err == nil ||
+		err.Error() != "tpl.NewStream: conf.Recover isn't supported" {
+		t.Fatal("NewStream:", err)
+	}
+
+	s, _ = c.NewStream("", iotest.OneByteReader(strings.NewReader("a = [1,\n2,\n3,\n4];\n")), &tpl.Config{MaxLookahead: 8})
+	if _, err = s.Next(); err == nil || err.Error() != "1:1: lookahead exceeds 8 bytes" {
+		t.Fatal("Next:", err)
+	}
+
+	c, _ = tpl.New(`doc = +INT`)
+	s, _ = c.NewStream("", strings.NewReader(""), nil)
+	if _, err = s.Next(); err == nil || err.Error() != "1:1: expect `INT`, but got EOF" {
+		t.Fatal("Next:", err)
+	}
+	c, _ = tpl.New(`doc = INT ";"`)
+	if _, err = c.NewStream("", strings.NewReader(""), nil); err == nil {
+		t.Fatal("NewStream: no error")
+	}
+}
@@ -38,7 +38,7 @@ func (tok Token) String() (s string) {
 // 1) len of is token literal, if token is an operator.
 // 2) 0 for else.
 func (tok Token) Len() int {
-	if tok > ' ' && tok <= Token(len(tokens)) {
+	if tok > ' ' && tok < operator_end {
 		return len(tokens[tok])
 	}
 	return 0
@@ -136,8 +136,14 @@ const (
 	POW       // **
 
 	operator_end
+
+	REGEXP // /[0-9]+/, a regular expression in TPL grammars
 )
 
+// USER_BEG is the first token defined by a TPL grammar, such as
+// NUMBER = /[0-9]+/ (see package tpl/cl).
+const USER_BEG Token = 0x100
+
 // -----------------------------------------------------------------------------
 
 var tokens = [...]string{
@@ -220,6 +226,8 @@ var tokens = [...]string{
 	SRARROW:   "->",
 	BIDIARROW: "<>",
 	POW:       "**",
+
+	REGEXP: "REGEXP",
 }
 
 const (
@@ -242,3 +250,98 @@ func ForEach(from Token, f func(tok Token, lit string) int) {
 }
 
 // -----------------------------------------------------------------------------
+
+var names = [...]string{
+	EOF:     "EOF",
+	COMMENT: "COMMENT",
+
+	IDENT:  "IDENT",
+	INT:    "INT",
+	FLOAT:  "FLOAT",
+	IMAG:   "IMAG",
+	CHAR:   "CHAR",
+	STRING: "STRING",
+	RAT:    "RAT",
+	UNIT:   "UNIT",
+
+	LPAREN: "LPAREN",
+	LBRACK: "LBRACK",
+	LBRACE: "LBRACE",
+	RPAREN: "RPAREN",
+	RBRACK: "RBRACK",
+	RBRACE: "RBRACE",
+
+	SHL:     "SHL",
+	SHR:     "SHR",
+	AND_NOT: "AND_NOT",
+
+	ADD_ASSIGN: "ADD_ASSIGN",
+	SUB_ASSIGN: "SUB_ASSIGN",
+	MUL_ASSIGN: "MUL_ASSIGN",
+	QUO_ASSIGN: "QUO_ASSIGN",
+	REM_ASSIGN: "REM_ASSIGN",
+
+	AND_ASSIGN:     "AND_ASSIGN",
+	OR_ASSIGN:      "OR_ASSIGN",
+	XOR_ASSIGN:     "XOR_ASSIGN",
+	SHL_ASSIGN:     "SHL_ASSIGN",
+	SHR_ASSIGN:     "SHR_ASSIGN",
+	AND_NOT_ASSIGN: "AND_NOT_ASSIGN",
+
+	LAND:  "LAND",
+	LOR:   "LOR",
+	ARROW: "ARROW",
+	INC:   "INC",
+	DEC:   "DEC",
+
+	EQ:       "EQ",
+	NE:       "NE",
+	LE:       "LE",
+	GE:       "GE",
+	DEFINE:   "DEFINE",
+	ELLIPSIS: "ELLIPSIS",
+
+	DRARROW:   "DRARROW",
+	SRARROW:   "SRARROW",
+	BIDIARROW: "BIDIARROW",
+	POW:       "POW",
+}
+
+// Name returns the name of tok in this package, such as IDENT or SHL, or ""
+// if tok has no name.
+func (tok Token) Name() string {
+	if tok < Token(len(names)) {
+		return names[tok]
+	}
+	return ""
+}
+
+// Lookup returns the token of a token class name of TPL grammars, such as
+// IDENT, STRING or LPAREN.
+func Lookup(name string) (tok Token, ok bool) {
+	for i, s := range names[:operator_beg] {
+		if s == name && s != "" {
+			return Token(i), true
+		}
+	}
+	return
+}
+
+// Operator returns the token of an operator literal, such as "+" or "<<=". A
+// literal of one byte always returns the token of the byte, so callers check
+// tok.Len() > 0 if it must be a valid operator.
+func Operator(lit string) (tok Token, ok bool) {
+	if len(lit) == 1 {
+		return Token(lit[0]), true
+	}
+	ForEach(0, func(t Token, s string) int {
+		if s == lit {
+			tok, ok = t, true
+			return Break
+		}
+		return 0
+	})
+	return
+}
+
+// -----------------------------------------------------------------------------
@@ -62,3 +62,27 @@ func TestToken(t *testing.T) {
 		return Break
 	})
 }
+
+func TestLookup(t *testing.T) {
+	if tok, ok := Lookup("LPAREN"); !ok || tok != LPAREN {
+		t.Fatal("Lookup LPAREN:", tok, ok)
+	}
+	if _, ok := Lookup("SHL"); ok {
+		t.Fatal("Lookup SHL: operators aren't token classes")
+	}
+	if _, ok := Lookup(""); ok {
+		t.Fatal(`Lookup ""`)
+	}
+	if tok, ok := Operator("<<="); !ok || tok != SHL_ASSIGN {
+		t.Fatal("Operator <<=:", tok, ok)
+	}
+	if tok, ok := Operator("#"); !ok || tok.Len() != 0 {
+		t.Fatal("Operator #:", tok, ok)
+	}
+	if _, ok := Operator("+++"); ok {
+		t.Fatal("Operator +++")
+	}
+	if SHL.Name() != "SHL" || UNIT.Name() != "UNIT" || Token('+').Name() != "" || USER_BEG.Name() != "" {
+		t.Fatal("Name")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 
 	"github.com/goplus/gop/parser/iox"
@@ -49,6 +50,9 @@ func onConflictHidden(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at
 // -----------------------------------------------------------------------------
 
 func relocatePos(ePos *token.Position, filename string, line, col int) {
+	if ePos.Filename != "" { // in an imported file
+		return
+	}
 	ePos.Filename = filename
 	if ePos.Line == line {
 		ePos.Column += col - 1
@@ -104,6 +108,7 @@ func New(src any, params ...any) (ret Compiler, err error) {
 func NewEx(src any, filename string, line, col int, params ...any) (ret Compiler, err error) {
 	conf := &cl.Config{
 		RetProcs: retProcs(params),
+		Dir:      filepath.Dir(filename),
 	}
 	if showConflict {
 		conf.OnConflict = func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {
@@ -137,6 +142,31 @@ func FromFile(fset *token.FileSet, filename string, src any, conf *cl.Config) (r
 	return
 }
 
+// Register registers a grammar, so that it can be imported by path.
+// params: ruleName1, retProc1, ..., ruleNameN, retProcN
+//
+// It's usually called in the init function of a Go package to share its
+// rules, eg.
+//
+//	package json
+//
+//	func init() {
+//		tpl.Register("github.com/foo/grammars/json", src, "value", func(self any) any { ... })
+//	}
+//
+// and then a grammar can import it, eg. import json "github.com/foo/grammars/json".
+func Register(path string, src any, params ...any) {
+	cl.Register(path, src, retProcs(params))
+}
+
+// Pure marks a RetProc as side-effect-free, so that its results can be
+// memoized (see [Config.Memo]):
+//
+//	tpl.New(src, "expr", tpl.Pure(func(self any) any { ... }))
+func Pure(retProc any) any {
+	return matcher.Pure{RetProc: retProc}
+}
+
 func retProcs(params []any) map[string]any {
 	n := len(params)
 	if n == 0 {
@@ -160,6 +190,9 @@ type Error = matcher.Error
 // A Token is a lexical unit returned by Scan.
 type Token = types.Token
 
+// ErrorNode represents tokens which are skipped to recover from an error.
+type ErrorNode = matcher.ErrorNode
+
 // Scanner represents a TPL scanner.
 type Scanner interface {
 	Scan() Token
@@ -168,10 +201,32 @@ type Scanner interface {
 
 // Config represents a parsing configuration of [Compiler.Parse].
 type Config struct {
+	// Scanner tokenizes the source, which is a scanner.Scanner by default.
+	// A custom Scanner must scan the tokens defined by the grammar (see
+	// [cl.Result.Tokens]) itself.
 	Scanner          Scanner
 	ScanErrorHandler scanner.ErrorHandler
 	ScanMode         scanner.Mode
 	Fset             *token.FileSet
+
+	// Memo enables packrat memoization, which avoids exponential backtracking
+	// of ambiguous grammars. Only rules, all RetProcs of which (including the
+	// ones of rules they refer to) are nil or pure (see [Pure]), are memoized.
+	Memo bool
+
+	// Recover enables the recovery mode (see [matcher.Context.EnableRecover]).
+	// Parsing continues after errors, and returns the partial result, in which
+	// skipped tokens are represented by [ErrorNode], with all the errors as a
+	// [scanner.ErrorList].
+	Recover bool
+
+	// Tracer receives the matching attempts of rules, choices and repetitions,
+	// eg. a [matcher.Recorder] records them as a tree.
+	Tracer matcher.Tracer
+
+	// MaxLookahead is the maximum number of bytes buffered to match an item
+	// of a [Stream], which is 1MB by default.
+	MaxLookahead int
 }
 
 // ParseExpr parses an expression.
@@ -182,6 +237,9 @@ func (p *Compiler) ParseExpr(x string, conf *Config) (result any, err error) {
 // ParseExprFrom parses an expression from a file.
 func (p *Compiler) ParseExprFrom(filename string, src any, conf *Config) (result any, err error) {
 	ms, result, err := p.Match(filename, src, conf)
+	defer func() {
+		err = ms.errorList(err)
+	}()
 	if err != nil {
 		return
 	}
@@ -196,6 +254,9 @@ func (p *Compiler) ParseExprFrom(filename string, src any, conf *Config) (result
 // Parse parses a source file.
 func (p *Compiler) Parse(filename string, src any, conf *Config) (result any, err error) {
 	ms, result, err := p.Match(filename, src, conf)
+	defer func() {
+		err = ms.errorList(err)
+	}()
 	if err != nil {
 		return
 	}
@@ -211,6 +272,33 @@ type MatchState struct {
 	Toks []*Token
 	Ctx  *matcher.Context
 	N    int
+
+	// source and tokens, to reparse it incrementally (see Compiler.Reparse)
+	src  []byte
+	file *token.File
+	all  []*Token
+}
+
+// errorList returns the errors recovered from (see [Config.Recover]) and err
+// as a scanner.ErrorList. It returns err if there are no recovered errors.
+func (p *MatchState) errorList(err error) error {
+	if p.Ctx == nil || len(p.Ctx.Errors()) == 0 {
+		return err
+	}
+	var list scanner.ErrorList
+	fset := p.Ctx.Fset
+	for _, e := range p.Ctx.Errors() {
+		list.Add(fset.Position(e.Pos), e.Msg)
+	}
+	if err != nil {
+		if e, ok := err.(*matcher.Error); ok {
+			list.Add(fset.Position(e.Pos), e.Msg)
+		} else {
+			list.Add(token.Position{}, err.Error())
+		}
+	}
+	list.Sort()
+	return list
 }
 
 // Next returns the next token.
@@ -233,14 +321,20 @@ func (p *Compiler) Match(filename string, src any, conf *Config) (ms MatchState,
 	}
 	s := conf.Scanner
 	if s == nil {
-		s = new(scanner.Scanner)
+		ts := new(scanner.Scanner)
+		ts.SetTokens(p.Tokens)
+		s = ts
+	}
+	mode := conf.ScanMode
+	if p.Scannerless {
+		mode |= scanner.ScanChars
 	}
 	fset := conf.Fset
 	if fset == nil {
 		fset = token.NewFileSet()
 	}
 	f := fset.AddFile(filename, fset.Base(), len(b))
-	s.Init(f, b, conf.ScanErrorHandler, conf.ScanMode)
+	s.Init(f, b, conf.ScanErrorHandler, mode)
 	n := (len(b) >> 3) &^ 7
 	if n < 8 {
 		n = 8
@@ -253,7 +347,26 @@ func (p *Compiler) Match(filename string, src any, conf *Config) (ms MatchState,
 		}
 		toks = append(toks, &t)
 	}
+	return p.match(fset, f, b, toks, conf, nil)
+}
+
+// match matches toks scanned from b. If reuse isn't nil, it's called to reuse
+// the memoized results of a previous matching (see Compiler.Reparse).
+func (p *Compiler) match(fset *token.FileSet, f *token.File, b []byte, toks []*Token, conf *Config, reuse func(ctx *matcher.Context)) (ms MatchState, result any, err error) {
+	ms.src, ms.file, ms.all = b, f, toks
 	ms.Ctx = matcher.NewContext(fset, token.Pos(f.Base()+len(b)), toks)
+	if conf.Memo {
+		ms.Ctx.EnableMemo()
+	}
+	if conf.Recover {
+		ms.Ctx.EnableRecover()
+	}
+	if conf.Tracer != nil {
+		ms.Ctx.SetTracer(conf.Tracer)
+	}
+	if reuse != nil {
+		reuse(ms.Ctx)
+	}
 	ms.N, result, err = p.Doc.Match(toks, ms.Ctx)
 	ms.Ctx.SetLastError(len(toks)-ms.N, err)
 	if err != nil {
@@ -350,6 +463,8 @@ retry:
 			}
 			fmt.Print(prefix, "]\n")
 		}
+	case *ErrorNode:
+		fmt.Fprint(w, prefix, "ERROR ", result.Err.Msg, "\n")
 	case nil:
 		fmt.Fprint(w, prefix, "nil\n")
 	default:
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl_test
+
+import (
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+	"github.com/goplus/gop/tpl/token"
+)
+
+// compile compiles a grammar without reporting conflicts, where params are
+// pairs of a rule name and its RetProc.
+func compile(t testing.TB, src string, params ...any) tpl.Compiler {
+	conf := &cl.Config{
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+		RetProcs:   make(map[string]any),
+	}
+	for i := 0; i < len(params); i += 2 {
+		conf.RetProcs[params[i].(string)] = params[i+1]
+	}
+	c, err := tpl.FromFile(nil, "", src, conf)
+	if err != nil {
+		t.Fatal("tpl.FromFile:", err)
+	}
+	return c
+}
+
+func TestTokenRules(t *testing.T) {
+	c := compile(t, `
+doc = *(key "=" value ";")
+
+key = NAME
+
+value = VERSION | NAME
+
+VERSION = /v[0-9]+(\.[0-9]+)*/
+
+NAME = /[a-zA-Z_][\w.\/-]*/
+`, "value", func(self any) any {
+		return self.(*tpl.Token).Tok
+	})
+	ret, err := c.Parse("", "module = github.com/goplus/gop\nversion = v1.2.3\n", nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	items := ret.([]any)
+	if len(items) != 2 {
+		t.Fatal("Parse:", ret)
+	}
+	key := items[0].([]any)[0].(*tpl.Token)
+	if key.Tok != token.USER_BEG+1 || key.Lit != "module" {
+		t.Fatal("key:", key)
+	}
+	if tok := items[0].([]any)[2]; tok != token.USER_BEG+1 {
+		t.Fatal("value:", tok)
+	}
+	if tok := items[1].([]any)[2]; tok != token.USER_BEG { // VERSION is defined first
+		t.Fatal("value:", tok)
+	}
+	if _, err = c.Parse("", "module = 1\n", nil); err == nil || err.Error() != "1:10: unexpected token: 1" {
+		t.Fatal("Parse:", err)
+	}
+	c = compile(t, `
+assign = NAME "=" NAME
+
+NAME = /[a-z]+/
+`)
+	if _, err = c.ParseExprFrom("", "x = 1", nil); err == nil || err.Error() != "1:5: expect `NAME`, but got `1`" {
+		t.Fatal("Parse:", err)
+	}
+	c = compile(t, `
+doc = "if" WORD
+
+WORD = /[a-z]+/
+`)
+	if ret, err = c.ParseExprFrom("", "if abc", nil); err != nil {
+		t.Fatal("Parse:", err)
+	}
+	if kw := ret.([]any)[0].(*tpl.Token); kw.Tok != token.USER_BEG || kw.Lit != "if" { // a keyword scanned by WORD
+		t.Fatal("keyword:", kw)
+	}
+	if _, err = c.ParseExprFrom("", "else abc", nil); err == nil || err.Error() != "1:1: expect `if`, but got `else`" {
+		t.Fatal("Parse:", err)
+	}
+	_, err = tpl.FromFile(nil, "", `
+doc = "x"
+
+num = /[0-9]+/ "x"
+
+BAD = /a(b/
+`, nil)
+	if err == nil || err.Error() != "6:7: invalid regexp /a(b/: error parsing regexp: missing closing ): `a(b`\n"+
+		"4:7: regexp /[0-9]+/ must be the whole expression of a token rule, such as NUMBER = /[0-9]+/" {
+		t.Fatal("tpl.FromFile:", err)
+	}
+}
+
+func TestScannerless(t *testing.T) {
+	c, err := tpl.FromFile(nil, "", `
+doc = *(line '\n')
+
+line = key ?" " "=" ?" " value
+
+key = NAME
+
+value = NUMBER | NAME
+
+NAME = /[a-z]+/
+
+NUMBER = /[0-9]+(\.[0-9]+)?/
+`, &cl.Config{
+		Scannerless: true,
+		RetProcs: map[string]any{
+			"line": func(self []any) any {
+				return self[0].(*tpl.Token).Lit + "=" + self[4].(*tpl.Token).Lit
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal("tpl.FromFile:", err)
+	}
+	ret, err := c.Parse("", "x = 1.5\ny=abc\n", nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	if v := ret.([]any); len(v) != 2 || v[0].([]any)[0] != "x=1.5" || v[1].([]any)[0] != "y=abc" {
+		t.Fatal("Parse:", ret)
+	}
+	if _, err = c.Parse("", "x  = 1\n", nil); err == nil || err.Error() != "1:3: unexpected token:  " {
+		t.Fatal("Parse:", err)
+	}
+	if _, err = c.Parse("", "x = 1", nil); err == nil || err.Error() != "1:6: unexpected token: EOF" {
+		t.Fatal("Parse:", err)
+	}
+	_, err = tpl.FromFile(nil, "", `doc = IDENT`, &cl.Config{Scannerless: true})
+	if err == nil || err.Error() != "1:7: `IDENT` is undefined, token classes aren't available in the scannerless mode" {
+		t.Fatal("tpl.FromFile:", err)
+	}
+}
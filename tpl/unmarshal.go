@@ -0,0 +1,420 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goplus/gop/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// Unmarshaler is implemented by types that decode a matching result
+// themselves.
+type Unmarshaler interface {
+	UnmarshalTPL(result any) error
+}
+
+var variants sync.Map // map[reflect.Type][]reflect.Type
+
+// RegisterVariants registers the variant types of an interface type, which
+// are the types of results of a choice. iface is a nil pointer to the
+// interface type, eg.
+//
+//	tpl.RegisterVariants((*Expr)(nil), &BinaryExpr{}, &Ident{}, &IntLit{})
+//
+// To decode a result into a field of the interface type, Unmarshal tries the
+// variants in order, and the first one decoded without error is used.
+func RegisterVariants(iface any, vars ...any) {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic("tpl.RegisterVariants: iface should be a nil pointer to an interface type")
+	}
+	t = t.Elem()
+	types := make([]reflect.Type, len(vars))
+	for i, v := range vars {
+		vt := reflect.TypeOf(v)
+		if vt == nil || !vt.Implements(t) {
+			panic(fmt.Sprintf("tpl.RegisterVariants: %v doesn't implement %v", vt, t))
+		}
+		types[i] = vt
+	}
+	variants.Store(t, types)
+}
+
+// Unmarshal decodes a matching result (see [Compiler.Parse]) into v, which
+// must be a non-nil pointer. A result is decoded according to the type:
+//
+//   - a struct: the result of a sequence (R1 R2 ...), whose items are decoded
+//     into the fields in order. A field tagged `tpl:"N"` is decoded from the
+//     N-th item (from 0), and the fields after it from the next items. Fields
+//     tagged `tpl:"-"` are skipped. The items after the last field must be
+//     operators, eg. ")" and ";", so a sequence isn't decoded into a struct
+//     of fewer fields. A result other than a sequence is decoded as a sequence
+//     of one item.
+//   - a slice: the result of *R or +R, or of R % sep if the field is tagged
+//     with the option list, eg. `tpl:"2,list"`.
+//   - a pointer: the result of ?R, which is nil if R isn't matched.
+//   - an interface: the result of a choice, which is decoded into one of the
+//     variant types of the interface (see RegisterVariants). An empty
+//     interface gets the result as it is.
+//   - *Token or Token: a token.
+//   - a string, an integer, a floating-point number or a bool: the literal of
+//     a token (or the operator itself, eg. "+"). A string is unquoted if the
+//     field is tagged with the option unquote.
+//   - token.Pos: the position of the first token of the result, if the field
+//     is tagged with the option pos, eg. `tpl:",pos"`. It doesn't take an
+//     item of the sequence.
+//
+// Options tok=KIND and lit=TEXT require the token of a field to be of the
+// kind (eg. INT) and the literal, which are used to tell variants of a choice
+// apart. A result (eg. of a RetProc) which is assignable to the type is
+// stored as it is, and a type implementing Unmarshaler decodes it itself.
+func Unmarshal(result any, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("tpl.Unmarshal: v should be a non-nil pointer")
+	}
+	return decode(result, rv.Elem(), nil, "")
+}
+
+// UnmarshalError represents an error of decoding a matching result.
+type UnmarshalError struct {
+	Path string // path of the field, eg. .Stmts[1].Value
+	Msg  string
+
+	badTag bool // the error is of a tag, rather than of the result
+}
+
+func (e *UnmarshalError) Error() string {
+	if e.Path == "" {
+		return "tpl.Unmarshal: " + e.Msg
+	}
+	return "tpl.Unmarshal " + e.Path + ": " + e.Msg
+}
+
+type fieldOpts struct {
+	list    bool
+	unquote bool
+	pos     bool
+	tok     string
+	lit     *string
+}
+
+func parseTag(tag string) (index string, opts *fieldOpts, err error) {
+	parts := strings.Split(tag, ",")
+	opts = new(fieldOpts)
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "list":
+			opts.list = true
+		case opt == "unquote":
+			opts.unquote = true
+		case opt == "pos":
+			opts.pos = true
+		case strings.HasPrefix(opt, "tok="):
+			opts.tok = opt[4:]
+		case strings.HasPrefix(opt, "lit="):
+			lit := opt[4:]
+			opts.lit = &lit
+		default:
+			return "", nil, errors.New("unknown option " + opt)
+		}
+	}
+	return parts[0], opts, nil
+}
+
+var (
+	tyToken    = reflect.TypeOf(Token{})
+	tyTokenPtr = reflect.TypeOf((*Token)(nil))
+	tyPos      = reflect.TypeOf(token.Pos(0))
+	tyUnmarsh  = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+)
+
+func decode(result any, v reflect.Value, opts *fieldOpts, path string) error {
+	fail := func(format string, args ...any) error {
+		return &UnmarshalError{Path: path, Msg: fmt.Sprintf(format, args...)}
+	}
+	t := v.Type()
+	if opts != nil && opts.pos {
+		if t != tyPos {
+			return fail("option pos requires type token.Pos, but got %v", t)
+		}
+		v.SetInt(int64(firstPos(result)))
+		return nil
+	}
+	if reflect.PtrTo(t).Implements(tyUnmarsh) {
+		return v.Addr().Interface().(Unmarshaler).UnmarshalTPL(result)
+	}
+	if result != nil && t != tyTokenPtr && t.Kind() != reflect.Interface && reflect.TypeOf(result).AssignableTo(t) {
+		v.Set(reflect.ValueOf(result))
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		if result == nil {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		if t == tyTokenPtr {
+			tok, err := checkToken(result, opts, fail)
+			if err == nil {
+				v.Set(reflect.ValueOf(tok))
+			}
+			return err
+		}
+		elem := reflect.New(t.Elem())
+		if err := decode(result, elem.Elem(), opts, path); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Struct:
+		if t == tyToken {
+			tok, err := checkToken(result, opts, fail)
+			if err == nil {
+				v.Set(reflect.ValueOf(*tok))
+			}
+			return err
+		}
+		return decodeStruct(result, v, path)
+	case reflect.Slice:
+		if result == nil {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		items, ok := result.([]any)
+		if !ok {
+			return fail("cannot decode %s into %v", typeName(result), t)
+		}
+		if opts != nil && opts.list {
+			if len(items) != 2 {
+				return fail("cannot decode %s into %v as a list", typeName(result), t)
+			}
+			if _, ok := items[1].([]any); !ok {
+				return fail("cannot decode %s into %v as a list", typeName(result), t)
+			}
+			items = List(items)
+		}
+		s := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if err := decode(item, s.Index(i), nil, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			if result != nil {
+				v.Set(reflect.ValueOf(result))
+			}
+			return nil
+		}
+		if result == nil {
+			return fail("cannot decode nil into %v", t)
+		}
+		vars, ok := variants.Load(t)
+		if !ok {
+			return fail("no variants of %v registered", t)
+		}
+		for _, vt := range vars.([]reflect.Type) {
+			x := reflect.New(vt).Elem()
+			err := decode(result, x, nil, path)
+			if err == nil {
+				v.Set(x)
+				return nil
+			}
+			if e, ok := err.(*UnmarshalError); ok && e.badTag {
+				return err
+			}
+		}
+		return fail("cannot decode %s into any variant of %v", typeName(result), t)
+	default:
+		tok, err := checkToken(result, opts, fail)
+		if err != nil {
+			return err
+		}
+		return decodeLit(tok, v, opts, fail)
+	}
+	return nil
+}
+
+type field struct {
+	index int // index of the item, or -1 for option pos
+	opts  *fieldOpts
+}
+
+// fieldsOf returns the fields of a struct, and the number of items they take.
+func fieldsOf(t reflect.Type, path string) (fields []field, n int, err error) {
+	fields = make([]field, t.NumField())
+	next := 0
+	for i := range fields {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("tpl")
+		if f.PkgPath != "" || tag == "-" { // unexported or skipped
+			fields[i].index = -2
+			continue
+		}
+		if hasTag {
+			var index string
+			if index, fields[i].opts, err = parseTag(tag); err != nil {
+				return nil, 0, &UnmarshalError{Path: path + "." + f.Name, Msg: err.Error(), badTag: true}
+			}
+			if fields[i].opts.pos {
+				fields[i].index = -1
+				continue
+			}
+			if index != "" {
+				idx, err := strconv.Atoi(index)
+				if err != nil || idx < 0 {
+					return nil, 0, &UnmarshalError{Path: path + "." + f.Name, Msg: "invalid index " + index, badTag: true}
+				}
+				next = idx
+			}
+		}
+		fields[i].index = next
+		next++
+		if next > n {
+			n = next
+		}
+	}
+	return
+}
+
+func decodeStruct(result any, v reflect.Value, path string) error {
+	items, ok := result.([]any)
+	if !ok {
+		items = []any{result}
+	}
+	t := v.Type()
+	fields, n, err := fieldsOf(t, path)
+	if err != nil {
+		return err
+	}
+	if n > len(items) {
+		return &UnmarshalError{Path: path, Msg: fmt.Sprintf("cannot decode %s into %v: expect %d items", typeName(result), t, n)}
+	}
+	for i, f := range fields {
+		var item any
+		switch f.index {
+		case -2:
+			continue
+		case -1:
+			item = result
+		default:
+			item = items[f.index]
+		}
+		if err := decode(item, v.Field(i), f.opts, path+"."+t.Field(i).Name); err != nil {
+			return err
+		}
+	}
+	for _, item := range items[n:] {
+		if tok, ok := item.(*Token); !ok || tok.Tok.Len() == 0 {
+			return &UnmarshalError{Path: path, Msg: fmt.Sprintf("cannot decode %s into %v: %s left", typeName(result), t, typeName(item))}
+		}
+	}
+	return nil
+}
+
+func checkToken(result any, opts *fieldOpts, fail func(format string, args ...any) error) (*Token, error) {
+	tok, ok := result.(*Token)
+	if !ok {
+		return nil, fail("cannot decode %s into a token", typeName(result))
+	}
+	if opts != nil {
+		if opts.tok != "" && tok.Tok.String() != opts.tok {
+			return nil, fail("expect token %s, but got %v", opts.tok, tok.Tok)
+		}
+		if opts.lit != nil && tok.String() != *opts.lit {
+			return nil, fail("expect `%s`, but got `%s`", *opts.lit, tok)
+		}
+	}
+	return tok, nil
+}
+
+func decodeLit(tok *Token, v reflect.Value, opts *fieldOpts, fail func(format string, args ...any) error) error {
+	lit := tok.String()
+	t := v.Type()
+	switch t.Kind() {
+	case reflect.String:
+		if opts != nil && opts.unquote {
+			s, err := strconv.Unquote(lit)
+			if err != nil {
+				return fail("cannot unquote %s", lit)
+			}
+			lit = s
+		}
+		v.SetString(lit)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(lit, 0, t.Bits()); err == nil {
+			v.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(lit, 0, t.Bits()); err == nil {
+			v.SetUint(n)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(lit, t.Bits()); err == nil {
+			v.SetFloat(f)
+			return nil
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(lit); err == nil {
+			v.SetBool(b)
+			return nil
+		}
+	default:
+		return fail("unsupported type %v", t)
+	}
+	return fail("cannot decode `%s` into %v", lit, t)
+}
+
+func firstPos(result any) token.Pos {
+	switch v := result.(type) {
+	case *Token:
+		return v.Pos
+	case []any:
+		for _, item := range v {
+			if pos := firstPos(item); pos != token.NoPos {
+				return pos
+			}
+		}
+	}
+	return token.NoPos
+}
+
+func typeName(result any) string {
+	switch v := result.(type) {
+	case nil:
+		return "nil"
+	case *Token:
+		return "token `" + v.String() + "`"
+	case []any:
+		return "[]any of " + strconv.Itoa(len(v)) + " items"
+	}
+	return reflect.TypeOf(result).String()
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl_test
+
+import (
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/token"
+)
+
+type (
+	uExpr interface{ expr() }
+
+	uBinary struct {
+		X  uExpr
+		Op *tpl.Token `tpl:",tok=+"`
+		Y  uExpr
+	}
+	uCall struct {
+		Pos  token.Pos `tpl:",pos"`
+		Fn   string    `tpl:",tok=IDENT"`
+		Args []uExpr   `tpl:"2,list"`
+	}
+	uParen struct {
+		X uExpr `tpl:"1"`
+	}
+	uIdent struct {
+		Name string `tpl:",tok=IDENT"`
+	}
+	uInt struct {
+		Val int `tpl:",tok=INT"`
+	}
+	uStr struct {
+		Val string `tpl:",tok=STRING,unquote"`
+	}
+
+	uStmt struct {
+		Name  string
+		Type  *string `tpl:"1"`
+		Value uExpr   `tpl:"3"`
+	}
+)
+
+func (*uBinary) expr() {}
+func (*uCall) expr()   {}
+func (*uParen) expr()  {}
+func (*uIdent) expr()  {}
+func (*uInt) expr()    {}
+func (*uStr) expr()    {}
+
+func init() {
+	tpl.RegisterVariants((*uExpr)(nil), &uBinary{}, &uCall{}, &uParen{}, &uIdent{}, &uInt{}, &uStr{})
+}
+
+func TestUnmarshal(t *testing.T) {
+	c := compile(t, `
+doc = *stmt
+
+stmt = IDENT ?IDENT "=" expr ";"
+
+expr = operand "+" expr | operand
+
+operand = IDENT "(" ?(expr % ",") ")" | "(" expr ")" | IDENT | INT | STRING
+`)
+	ret, err := c.Parse("", "a int = 1 + (b);\nc = f(x, \"y\");\nd = g();\n", nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	var stmts []*uStmt
+	if err = tpl.Unmarshal(ret, &stmts); err != nil {
+		t.Fatal("Unmarshal:", err)
+	}
+	if len(stmts) != 3 || stmts[0].Name != "a" || *stmts[0].Type != "int" || stmts[1].Type != nil {
+		t.Fatal("Unmarshal:", stmts)
+	}
+	bin := stmts[0].Value.(*uBinary)
+	if bin.X.(*uInt).Val != 1 || bin.Op.Tok != '+' || bin.Y.(*uParen).X.(*uIdent).Name != "b" {
+		t.Fatal("Unmarshal:", bin)
+	}
+	call := stmts[1].Value.(*uCall)
+	if call.Fn != "f" || call.Pos != 22 || len(call.Args) != 2 || call.Args[1].(*uStr).Val != "y" {
+		t.Fatal("Unmarshal:", call)
+	}
+	if call := stmts[2].Value.(*uCall); call.Fn != "g" || call.Args != nil {
+		t.Fatal("Unmarshal:", call)
+	}
+
+	var n int
+	if err = tpl.Unmarshal(ret, &n); err == nil || err.Error() != "tpl.Unmarshal: cannot decode []any of 3 items into a token" {
+		t.Fatal("Unmarshal:", err)
+	}
+	var bad []struct{ Name, Type, Eq, Value string }
+	if err = tpl.Unmarshal(ret, &bad); err == nil || err.Error() != "tpl.Unmarshal [0].Value: cannot decode []any of 3 items into a token" {
+		t.Fatal("Unmarshal:", err)
+	}
+	var ints []struct{ Name int }
+	if err = tpl.Unmarshal(ret, &ints); err == nil || err.Error() != "tpl.Unmarshal [0].Name: cannot decode `a` into int" {
+		t.Fatal("Unmarshal:", err)
+	}
+	if err = tpl.Unmarshal(ret, stmts); err == nil {
+		t.Fatal("Unmarshal: no error")
+	}
+}
+
+type (
+	vExpr  interface{ vexpr() }
+	vIdent struct {
+		Name string `tpl:",tok=IDENT"`
+	}
+	vBin struct {
+		X  string `tpl:",tok=IDENT"`
+		Op string
+		Y  string `tpl:",tok=IDENT"`
+	}
+)
+
+func (*vIdent) vexpr() {}
+func (*vBin) vexpr()   {}
+
+func init() {
+	tpl.RegisterVariants((*vExpr)(nil), &vIdent{}, &vBin{})
+}
+
+func TestUnmarshalVariants(t *testing.T) {
+	c := compile(t, `expr = IDENT "+" IDENT | IDENT`)
+	ret, err := c.ParseExpr("a + b", nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	var x vExpr
+	if err = tpl.Unmarshal(ret, &x); err != nil {
+		t.Fatal("Unmarshal:", err)
+	}
+	if bin, ok := x.(*vBin); !ok || bin.X != "a" || bin.Y != "b" {
+		t.Fatal("Unmarshal:", x)
+	}
+	var id vIdent
+	if err = tpl.Unmarshal(ret, &id); err == nil || err.Error() != "tpl.Unmarshal: cannot decode []any of 3 items into tpl_test.vIdent: token `b` left" {
+		t.Fatal("Unmarshal:", err)
+	}
+}
+
+func TestUnmarshalBadTag(t *testing.T) {
+	c := compile(t, `expr = IDENT`)
+	ret, err := c.ParseExpr("a", nil)
+	if err != nil {
+		t.Fatal("Parse:", err)
+	}
+	var opt struct {
+		Name string `tpl:",foo"`
+	}
+	if err = tpl.Unmarshal(ret, &opt); err == nil || err.Error() != "tpl.Unmarshal .Name: unknown option foo" {
+		t.Fatal("Unmarshal:", err)
+	}
+	var idx struct {
+		Name string `tpl:"-1"`
+	}
+	if err = tpl.Unmarshal(ret, &idx); err == nil || err.Error() != "tpl.Unmarshal .Name: invalid index -1" {
+		t.Fatal("Unmarshal:", err)
+	}
+}
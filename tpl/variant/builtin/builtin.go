@@ -17,7 +17,9 @@
 package buitin
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
 
 	"github.com/goplus/gop/tpl/variant"
 )
@@ -34,10 +36,72 @@ func CastInt(args ...any) any {
 		return int(v)
 	case int:
 		return v
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
 	}
 	panic("can't convert to int")
 }
 
+// CastFloat converts a value to float64.
+func CastFloat(args ...any) any {
+	if len(args) != 1 {
+		panic("float: arity mismatch")
+	}
+	switch v := variant.Eval(args[0]).(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	panic("can't convert to float")
+}
+
+// CastBool converts a value to bool.
+func CastBool(args ...any) any {
+	if len(args) != 1 {
+		panic("bool: arity mismatch")
+	}
+	switch v := variant.Eval(args[0]).(type) {
+	case bool:
+		return v
+	case int:
+		return v != 0
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	panic("can't convert to bool")
+}
+
+// CastStr converts a value to string.
+func CastStr(args ...any) any {
+	if len(args) != 1 {
+		panic("str: arity mismatch")
+	}
+	switch v := variant.Eval(args[0]).(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 // -----------------------------------------------------------------------------
 
 // Type returns the type of an value.
@@ -53,6 +117,9 @@ func Type(args ...any) any {
 func init() {
 	mod := variant.NewModule("builtin")
 	mod.Insert("int", CastInt)
+	mod.Insert("float", CastFloat)
+	mod.Insert("bool", CastBool)
+	mod.Insert("str", CastStr)
 	mod.Insert("type", Type)
 }
 
@@ -17,6 +17,9 @@
 package delay
 
 import (
+	"errors"
+
+	"github.com/goplus/gop/tpl/matcher"
 	"github.com/goplus/gop/tpl/token"
 	"github.com/goplus/gop/tpl/variant"
 )
@@ -113,6 +116,22 @@ func CallObject(needList bool, fn any, arglist any) any {
 	}
 }
 
+// CallAt delays to call a function, whose errors are reported at pos (see
+// variant.CallAt).
+func CallAt(pos token.Pos, needList bool, name string, arglist any) any {
+	return func() any {
+		return variant.CallAt(pos, needList, name, arglist)
+	}
+}
+
+// CallObjectAt delays to call a function object, whose errors are reported at
+// pos (see variant.CallObjectAt).
+func CallObjectAt(pos token.Pos, needList bool, name string, fn any, arglist any) any {
+	return func() any {
+		return variant.CallObjectAt(pos, needList, name, fn, arglist)
+	}
+}
+
 // -----------------------------------------------------------------------------
 
 // ValueOf delays to get a value.
@@ -144,11 +163,14 @@ func ChgValue(name string, chgval func(string, func(oldv any) any), chg func(old
 
 // -----------------------------------------------------------------------------
 
-// StmtList delays a statement list.
+// StmtList delays a statement list. It stops at a return, break or continue
+// statement (see Return, Break and Continue).
 func StmtList(stmts []any) any {
 	return func() any {
 		for _, stmt := range stmts {
-			Eval(stmt)
+			if c, ok := Eval(stmt).(*control); ok {
+				return c
+			}
 		}
 		return nil
 	}
@@ -157,30 +179,40 @@ func StmtList(stmts []any) any {
 // IfElse delays an if-else statement.
 func IfElse(cond, ifBody, elseStmt any, elseBodyAt int) any {
 	return func() any {
+		var v any
 		if Eval(cond).(bool) {
-			Eval(ifBody)
+			v = Eval(ifBody)
 		} else if elseStmt != nil {
-			Eval(elseStmt.([]any)[elseBodyAt])
+			v = Eval(elseStmt.([]any)[elseBodyAt])
+		}
+		if c, ok := v.(*control); ok {
+			return c
 		}
 		return nil
 	}
 }
 
-// While delays a while loop.
+// While delays a while loop, whose body can contain break and continue
+// statements.
 func While(cond, body any) any {
 	return func() any {
 		for Eval(cond).(bool) {
-			Eval(body)
+			if exit, ret := loopCtrl(Eval(body)); exit {
+				return ret
+			}
 		}
 		return nil
 	}
 }
 
-// RepeatUntil delays a repeat-until loop.
+// RepeatUntil delays a repeat-until loop, whose body can contain break and
+// continue statements.
 func RepeatUntil(body, cond any) any {
 	return func() any {
 		for {
-			Eval(body)
+			if exit, ret := loopCtrl(Eval(body)); exit {
+				return ret
+			}
 			if Eval(cond).(bool) {
 				break
 			}
@@ -189,6 +221,33 @@ func RepeatUntil(body, cond any) any {
 	}
 }
 
+// Run evaluates a delayed value, and returns the runtime error reported at a
+// position (see CallAt) as err, whose Fset is set to fset if it's nil. If v
+// is a statement list, ret is the value of its return statement, and a break
+// or continue statement outside a loop is an error.
+func Run(fset *token.FileSet, v any) (ret any, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			me, ok := e.(*matcher.Error)
+			if !ok {
+				panic(e)
+			}
+			if me.Fset == nil {
+				me.Fset = fset
+			}
+			err = me
+		}
+	}()
+	ret = Eval(v)
+	if c, ok := ret.(*control); ok { // return at the top level
+		if c.kind != ctrlReturn {
+			return nil, errors.New(c.kind.String() + " is not in a loop")
+		}
+		ret = c.val
+	}
+	return
+}
+
 // -----------------------------------------------------------------------------
 
 // InitUniverse initializes the universe module with the specified modules.
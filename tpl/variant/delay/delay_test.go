@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delay_test
+
+import (
+	"testing"
+
+	"github.com/goplus/gop/tpl/token"
+	"github.com/goplus/gop/tpl/variant"
+	"github.com/goplus/gop/tpl/variant/delay"
+)
+
+func val(env *delay.Env, name string) any {
+	return delay.ValueOf(name, env.Lookup)
+}
+
+func call(env *delay.Env, name string, args ...any) any {
+	return delay.CallEnv(env, token.NoPos, false, name, args)
+}
+
+func TestClosure(t *testing.T) {
+	env := delay.NewEnv()
+	// def counter() { var i = 0; return fn() { i = i + 1; return i } }
+	counter := delay.SetValue("counter", env.Define, delay.Func(env, nil, delay.StmtList([]any{
+		delay.SetValue("i", env.Define, 0),
+		delay.Return(delay.Func(env, nil, delay.StmtList([]any{
+			delay.SetValue("i", env.Set, delay.MathOp(token.ADD, val(env, "i"), 1)),
+			delay.Return(val(env, "i")),
+		}))),
+	})))
+	// var a = counter(); var b = counter(); a(); return a() * 10 + b()
+	prog := delay.StmtList([]any{
+		counter,
+		delay.SetValue("a", env.Define, call(env, "counter")),
+		delay.SetValue("b", env.Define, call(env, "counter")),
+		call(env, "a"),
+		delay.Return(delay.MathOp(token.ADD, delay.MathOp(token.MUL, call(env, "a"), 10), call(env, "b"))),
+	})
+	if v, err := delay.Run(nil, prog); err != nil || v != 21 {
+		t.Fatal("Run:", v, err)
+	}
+	if _, ok := env.Lookup("i"); ok {
+		t.Fatal("Lookup: i is visible in the global scope")
+	}
+}
+
+func TestRecursion(t *testing.T) {
+	env := delay.NewEnv()
+	n := val(env, "n")
+	// def fact(n) { if n <= 1 { return 1 }; return n * fact(n - 1) }
+	fact := delay.SetValue("fact", env.Define, delay.Func(env, []string{"n"}, delay.StmtList([]any{
+		delay.IfElse(delay.Compare(token.LE, n, 1), delay.Return(1), nil, 0),
+		delay.Return(delay.MathOp(token.MUL, n, call(env, "fact", delay.MathOp(token.SUB, n, 1)))),
+	})))
+	if v, err := delay.Run(nil, delay.StmtList([]any{fact, delay.Return(call(env, "fact", 5))})); err != nil || v != 120 {
+		t.Fatal("Run:", v, err)
+	}
+}
+
+func TestLoop(t *testing.T) {
+	env := delay.NewEnv()
+	i, sum := val(env, "i"), val(env, "sum")
+	// var i = 0; var sum = 0
+	// while true { i = i + 1; if i % 2 == 0 { continue }; if i > 9 { break }; { var i = 100 }; sum = sum + i }
+	prog := delay.StmtList([]any{
+		delay.SetValue("i", env.Define, 0),
+		delay.SetValue("sum", env.Define, 0),
+		delay.While(true, delay.Block(env, delay.StmtList([]any{
+			delay.SetValue("i", env.Set, delay.MathOp(token.ADD, i, 1)),
+			delay.IfElse(delay.Compare(token.EQ, delay.MathOp(token.REM, i, 2), 0), delay.Continue(), nil, 0),
+			delay.IfElse(delay.Compare(token.GT, i, 9), delay.Break(), nil, 0),
+			delay.Block(env, delay.SetValue("i", env.Define, 100)),
+			delay.SetValue("sum", env.Set, delay.MathOp(token.ADD, sum, i)),
+		}))),
+		delay.Return(sum),
+	})
+	if v, err := delay.Run(nil, prog); err != nil || v != 25 {
+		t.Fatal("Run:", v, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	env := delay.NewEnv()
+	fset := token.NewFileSet()
+	f := fset.AddFile("foo.txt", -1, 10)
+	prog := delay.StmtList([]any{
+		delay.SetValue("f", env.Define, delay.Func(env, []string{"x"}, delay.Break())),
+		delay.CallEnv(env, f.Pos(2), false, "f", []any{1, 2}),
+	})
+	if _, err := delay.Run(fset, prog); err == nil || err.Error() != "foo.txt:1:3: f: arity mismatch" {
+		t.Fatal("Run:", err)
+	}
+	env = delay.NewEnv()
+	prog = delay.StmtList([]any{
+		delay.SetValue("f", env.Define, delay.Func(env, nil, delay.Break())),
+		delay.CallEnv(env, f.Pos(0), false, "f", nil),
+	})
+	if _, err := delay.Run(fset, prog); err == nil || err.Error() != "foo.txt:1:1: f: break is not in a loop" {
+		t.Fatal("Run:", err)
+	}
+	prog = delay.StmtList([]any{delay.Continue()})
+	if _, err := delay.Run(fset, prog); err == nil || err.Error() != "continue is not in a loop" {
+		t.Fatal("Run:", err)
+	}
+	defer func() {
+		if e := recover(); e != "x is undefined" {
+			t.Fatal("Set:", e)
+		}
+	}()
+	variant.Eval(delay.SetValue("x", delay.NewEnv().Set, 1))
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delay
+
+import (
+	"github.com/goplus/gop/tpl/token"
+	"github.com/goplus/gop/tpl/variant"
+)
+
+// -----------------------------------------------------------------------------
+
+// Scope represents a lexical scope.
+type Scope struct {
+	parent *Scope
+	vars   map[string]any
+}
+
+// NewScope creates a new scope nested in parent (can be nil).
+func NewScope(parent *Scope) *Scope {
+	return &Scope{parent: parent, vars: make(map[string]any)}
+}
+
+// Parent returns the parent scope.
+func (p *Scope) Parent() *Scope {
+	return p.parent
+}
+
+func (p *Scope) lookup(name string) (*Scope, any) {
+	for s := p; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return s, v
+		}
+	}
+	return nil, nil
+}
+
+// Env represents an environment of evaluation: a chain of lexical scopes,
+// whose innermost scope changes when a block or a function (see Block and
+// Func) is evaluated.
+//
+// The methods of Env can be used as callbacks of ValueOf, SetValue and
+// ChgValue:
+//
+//	delay.ValueOf(name, env.Lookup)
+//	delay.SetValue(name, env.Define, expr) // var name = expr
+//	delay.SetValue(name, env.Set, expr)    // name = expr
+//	delay.ChgValue(name, env.Change, chg)
+type Env struct {
+	cur *Scope
+}
+
+// NewEnv creates a new environment with a global scope.
+func NewEnv() *Env {
+	return &Env{cur: NewScope(nil)}
+}
+
+// Scope returns the current scope.
+func (p *Env) Scope() *Scope {
+	return p.cur
+}
+
+// Lookup looks up a name from the current scope to the global scope.
+func (p *Env) Lookup(name string) (v any, ok bool) {
+	s, v := p.cur.lookup(name)
+	return v, s != nil
+}
+
+// Define defines a name in the current scope. It panics if the name exists in
+// the current scope.
+func (p *Env) Define(name string, v any) {
+	vars := p.cur.vars
+	if _, ok := vars[name]; ok {
+		panic(name + " redefined")
+	}
+	vars[name] = v
+}
+
+// Set sets the value of a name in the innermost scope defining it. It panics
+// if the name is undefined.
+func (p *Env) Set(name string, v any) {
+	s, _ := p.cur.lookup(name)
+	if s == nil {
+		panic(name + " is undefined")
+	}
+	s.vars[name] = v
+}
+
+// Change changes the value of a name by chg(oldv) in the innermost scope
+// defining it. It panics if the name is undefined.
+func (p *Env) Change(name string, chg func(oldv any) any) {
+	s, oldv := p.cur.lookup(name)
+	if s == nil {
+		panic(name + " is undefined")
+	}
+	s.vars[name] = chg(oldv)
+}
+
+// -----------------------------------------------------------------------------
+
+// Block delays a block, whose body is evaluated in a new scope.
+func Block(env *Env, body any) any {
+	return func() any {
+		old := env.cur
+		env.cur = NewScope(old)
+		defer func() {
+			env.cur = old
+		}()
+		return Eval(body)
+	}
+}
+
+// Func delays a function definition. Its value is a closure, which captures
+// the current scope when the definition is evaluated, and can be called by
+// CallObject (or CallEnv). When the closure is called, body is evaluated in a
+// new scope nested in the captured one, where params are defined as the
+// arguments. The result of the call is the value of Return, or nil if body
+// doesn't return a value.
+//
+// To define a recursive function, define its name in the scope before its
+// closure is evaluated, eg. delay.SetValue(name, env.Define, delay.Func(...)).
+func Func(env *Env, params []string, body any) any {
+	return func() any {
+		closure := env.cur
+		return func(args ...any) any {
+			if len(args) != len(params) {
+				panic("arity mismatch")
+			}
+			scope := NewScope(closure)
+			for i, name := range params {
+				scope.vars[name] = Eval(args[i]) // evaluated in the caller's scope
+			}
+			old := env.cur
+			env.cur = scope
+			defer func() {
+				env.cur = old
+			}()
+			switch c := Eval(body).(type) {
+			case *control:
+				if c.kind != ctrlReturn {
+					panic(c.kind.String() + " is not in a loop")
+				}
+				return c.val
+			}
+			return nil
+		}
+	}
+}
+
+// CallEnv delays to call a function by name. The name is looked up in env
+// first, and in the universe of functions if it's undefined in env (see
+// variant.Call). Errors of the call are reported at pos (see variant.CallAt).
+func CallEnv(env *Env, pos token.Pos, needList bool, name string, arglist any) any {
+	return func() any {
+		if fn, ok := env.Lookup(name); ok {
+			return variant.CallObjectAt(pos, needList, name, fn, arglist)
+		}
+		return variant.CallAt(pos, needList, name, arglist)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+type ctrlKind int
+
+const (
+	ctrlReturn ctrlKind = iota
+	ctrlBreak
+	ctrlContinue
+)
+
+func (k ctrlKind) String() string {
+	switch k {
+	case ctrlBreak:
+		return "break"
+	case ctrlContinue:
+		return "continue"
+	}
+	return "return"
+}
+
+// control is the value of a return, break or continue statement, which
+// leaves the enclosing statements until a loop or a function handles it.
+type control struct {
+	kind ctrlKind
+	val  any
+}
+
+var (
+	breakCtrl    = &control{kind: ctrlBreak}
+	continueCtrl = &control{kind: ctrlContinue}
+)
+
+// Return delays a return statement of a function (see Func). expr can be nil
+// if no value is returned.
+func Return(expr any) any {
+	return func() any {
+		return &control{kind: ctrlReturn, val: Eval(expr)}
+	}
+}
+
+// Break delays a break statement, which leaves the innermost loop.
+func Break() any {
+	return func() any {
+		return breakCtrl
+	}
+}
+
+// Continue delays a continue statement, which starts the next iteration of
+// the innermost loop.
+func Continue() any {
+	return func() any {
+		return continueCtrl
+	}
+}
+
+// loopCtrl handles the value of a loop body, and reports whether to leave the
+// loop, and the value of the loop.
+func loopCtrl(v any) (exit bool, ret any) {
+	if c, ok := v.(*control); ok {
+		switch c.kind {
+		case ctrlBreak:
+			return true, nil
+		case ctrlReturn:
+			return true, c
+		}
+	}
+	return false, nil
+}
+
+// -----------------------------------------------------------------------------
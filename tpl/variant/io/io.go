@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package io
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/goplus/gop/tpl/variant"
+)
+
+// -----------------------------------------------------------------------------
+
+var (
+	stdin  = bufio.NewReader(os.Stdin)
+	stdout = io.Writer(os.Stdout)
+)
+
+// SetInput sets the reader that input reads from, which is os.Stdin by default.
+func SetInput(r io.Reader) {
+	stdin = bufio.NewReader(r)
+}
+
+// SetOutput sets the writer that print writes to, which is os.Stdout by
+// default.
+func SetOutput(w io.Writer) {
+	stdout = w
+}
+
+// Print writes the arguments separated by spaces, followed by a newline.
+func Print(args ...any) any {
+	vals := make([]any, len(args))
+	for i, v := range args {
+		vals[i] = variant.Eval(v)
+	}
+	fmt.Fprintln(stdout, vals...)
+	return nil
+}
+
+// Input reads a line, and returns it without the line ending: input([prompt]).
+// The prompt is written before reading if it's specified.
+func Input(args ...any) any {
+	variant.Arity(args, 0, 1)
+	if len(args) == 1 {
+		fmt.Fprint(stdout, variant.String(args[0]))
+	}
+	line, err := stdin.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		panic(err.Error())
+	}
+	return strings.TrimRight(line, "\r\n")
+}
+
+// -----------------------------------------------------------------------------
+
+func init() {
+	mod := variant.NewModule("io")
+	mod.Insert("print", Print)
+	mod.Insert("input", Input)
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lists
+
+import (
+	"github.com/goplus/gop/tpl/variant"
+)
+
+// -----------------------------------------------------------------------------
+
+// List returns a list of the arguments: list(v1, v2, ...).
+func List(args ...any) any {
+	ret := make([]any, len(args))
+	for i, v := range args {
+		ret[i] = variant.Eval(v)
+	}
+	return ret
+}
+
+// Append returns a new list of the elements of a list followed by the other
+// arguments: append(list, v1, v2, ...).
+func Append(args ...any) any {
+	variant.Arity(args, 1, -1)
+	list := variant.Slice(args[0])
+	ret := make([]any, len(list), len(list)+len(args)-1)
+	copy(ret, list)
+	for _, v := range args[1:] {
+		ret = append(ret, variant.Eval(v))
+	}
+	return ret
+}
+
+// Slice returns the elements of a list in [start, end): slice(list, start[, end]).
+func Slice(args ...any) any {
+	variant.Arity(args, 2, 3)
+	list := variant.Slice(args[0])
+	start, end := variant.Int(args[1]), len(list)
+	if len(args) == 3 {
+		end = variant.Int(args[2])
+	}
+	if start < 0 || start > end || end > len(list) {
+		panic("index out of range")
+	}
+	return list[start:end:end]
+}
+
+// Map returns a list of the results of calling fn with each element of a
+// list: map(list, fn).
+func Map(args ...any) any {
+	variant.Arity(args, 2, 2)
+	list, fn := variant.Slice(args[0]), args[1]
+	ret := make([]any, len(list))
+	for i, v := range list {
+		ret[i] = variant.Eval(variant.CallObject(false, fn, []any{v}))
+	}
+	return ret
+}
+
+// Filter returns a list of the elements of a list for which fn returns true:
+// filter(list, fn).
+func Filter(args ...any) any {
+	variant.Arity(args, 2, 2)
+	list, fn := variant.Slice(args[0]), args[1]
+	var ret []any
+	for _, v := range list {
+		if variant.Bool(variant.CallObject(false, fn, []any{v})) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// Reduce combines the elements of a list from left to right by calling
+// fn(acc, elem), starting from init: reduce(list, fn, init).
+func Reduce(args ...any) any {
+	variant.Arity(args, 3, 3)
+	list, fn := variant.Slice(args[0]), args[1]
+	acc := variant.Eval(args[2])
+	for _, v := range list {
+		acc = variant.Eval(variant.CallObject(false, fn, []any{acc, v}))
+	}
+	return acc
+}
+
+// Reverse returns a new list of the elements of a list in reverse order.
+func Reverse(args ...any) any {
+	variant.Arity(args, 1, 1)
+	list := variant.Slice(args[0])
+	ret := make([]any, len(list))
+	for i, v := range list {
+		ret[len(list)-1-i] = v
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+
+func init() {
+	mod := variant.NewModule("lists")
+	mod.Insert("list", List)
+	mod.Insert("append", Append)
+	mod.Insert("slice", Slice)
+	mod.Insert("map", Map)
+	mod.Insert("filter", Filter)
+	mod.Insert("reduce", Reduce)
+	mod.Insert("reverse", Reverse)
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maps
+
+import (
+	"sort"
+
+	"github.com/goplus/gop/tpl/variant"
+)
+
+// -----------------------------------------------------------------------------
+
+// Record returns a record of the key-value pairs: record(k1, v1, k2, v2, ...).
+// Keys must be strings.
+func Record(args ...any) any {
+	if len(args)&1 != 0 {
+		panic("arity mismatch")
+	}
+	ret := make(map[string]any, len(args)>>1)
+	for i := 0; i < len(args); i += 2 {
+		ret[variant.String(args[i])] = variant.Eval(args[i+1])
+	}
+	return ret
+}
+
+// Get returns the value of a key of a record, or def if the key doesn't exist:
+// get(rec, key[, def]). It panics if the key doesn't exist and there is no
+// def.
+func Get(args ...any) any {
+	variant.Arity(args, 2, 3)
+	rec, key := variant.Map(args[0]), variant.String(args[1])
+	if v, ok := rec[key]; ok {
+		return v
+	}
+	if len(args) == 3 {
+		return variant.Eval(args[2])
+	}
+	panic("key not found: " + key)
+}
+
+// Set sets the value of a key of a record, and returns the record:
+// set(rec, key, val).
+func Set(args ...any) any {
+	variant.Arity(args, 3, 3)
+	rec := variant.Map(args[0])
+	rec[variant.String(args[1])] = variant.Eval(args[2])
+	return rec
+}
+
+// Has reports whether a key exists in a record: has(rec, key).
+func Has(args ...any) any {
+	variant.Arity(args, 2, 2)
+	_, ok := variant.Map(args[0])[variant.String(args[1])]
+	return ok
+}
+
+// Delete deletes a key from a record, and returns the record: delete(rec, key).
+func Delete(args ...any) any {
+	variant.Arity(args, 2, 2)
+	rec := variant.Map(args[0])
+	delete(rec, variant.String(args[1]))
+	return rec
+}
+
+func sortedKeys(rec map[string]any) []string {
+	keys := make([]string, 0, len(rec))
+	for k := range rec {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Keys returns the keys of a record in sorted order.
+func Keys(args ...any) any {
+	variant.Arity(args, 1, 1)
+	keys := sortedKeys(variant.Map(args[0]))
+	ret := make([]any, len(keys))
+	for i, k := range keys {
+		ret[i] = k
+	}
+	return ret
+}
+
+// Values returns the values of a record in the sorted order of keys.
+func Values(args ...any) any {
+	variant.Arity(args, 1, 1)
+	rec := variant.Map(args[0])
+	keys := sortedKeys(rec)
+	ret := make([]any, len(keys))
+	for i, k := range keys {
+		ret[i] = rec[k]
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+
+func init() {
+	mod := variant.NewModule("maps")
+	mod.Insert("record", Record)
+	mod.Insert("get", Get)
+	mod.Insert("set", Set)
+	mod.Insert("has", Has)
+	mod.Insert("delete", Delete)
+	mod.Insert("keys", Keys)
+	mod.Insert("values", Values)
+}
+
+// -----------------------------------------------------------------------------
@@ -17,7 +17,11 @@
 package variant
 
 import (
+	"strings"
+
 	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/matcher"
+	"github.com/goplus/gop/tpl/token"
 )
 
 // -----------------------------------------------------------------------------
@@ -101,6 +105,35 @@ func CallObject(needList bool, fn any, arglist any) any {
 	panic("call of non function")
 }
 
+// CallAt calls a function like Call. Errors of the call, such as arity or
+// type mismatch, are reported as a *matcher.Error at pos, eg. the position of
+// the function name.
+func CallAt(pos token.Pos, needList bool, name string, arglist any) any {
+	defer recoverAt(pos, name)
+	return Call(needList, name, arglist)
+}
+
+// CallObjectAt calls a function object like CallObject. Errors of the call are
+// reported as a *matcher.Error at pos. name is the name of the function object
+// in error messages, which can be empty.
+func CallObjectAt(pos token.Pos, needList bool, name string, fn any, arglist any) any {
+	defer recoverAt(pos, name)
+	return CallObject(needList, fn, arglist)
+}
+
+func recoverAt(pos token.Pos, name string) {
+	if e := recover(); e != nil {
+		msg, ok := e.(string)
+		if !ok {
+			panic(e)
+		}
+		if name != "" && !strings.HasPrefix(msg, name+":") {
+			msg = name + ": " + msg
+		}
+		panic(&matcher.Error{Pos: pos, Msg: msg, Dyn: true})
+	}
+}
+
 // -----------------------------------------------------------------------------
 
 // InitUniverse initializes the universe module with the specified modules.
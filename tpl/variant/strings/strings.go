@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package strings
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goplus/gop/tpl/variant"
+)
+
+// -----------------------------------------------------------------------------
+
+// Len returns the number of characters of a string, or the number of elements
+// of a list or a record.
+func Len(args ...any) any {
+	variant.Arity(args, 1, 1)
+	switch v := variant.Eval(args[0]).(type) {
+	case string:
+		return utf8.RuneCountInString(v)
+	case []any:
+		return len(v)
+	case map[string]any:
+		return len(v)
+	}
+	panic("invalid argument")
+}
+
+// Substr returns the substring of s starting at the start-th character (from
+// 0), with n characters or all the rest: substr(s, start[, n]).
+func Substr(args ...any) any {
+	variant.Arity(args, 2, 3)
+	s := []rune(variant.String(args[0]))
+	start, end := variant.Int(args[1]), len(s)
+	if len(args) == 3 {
+		end = start + variant.Int(args[2])
+	}
+	if start < 0 || start > end || end > len(s) {
+		panic("index out of range")
+	}
+	return string(s[start:end])
+}
+
+// Index returns the index of the first character of sub in s, or -1 if sub
+// isn't in s.
+func Index(args ...any) any {
+	variant.Arity(args, 2, 2)
+	s, sub := variant.String(args[0]), variant.String(args[1])
+	pos := strings.Index(s, sub)
+	if pos < 0 {
+		return -1
+	}
+	return utf8.RuneCountInString(s[:pos])
+}
+
+// Split splits a string into a list of substrings separated by sep.
+func Split(args ...any) any {
+	variant.Arity(args, 2, 2)
+	parts := strings.Split(variant.String(args[0]), variant.String(args[1]))
+	ret := make([]any, len(parts))
+	for i, part := range parts {
+		ret[i] = part
+	}
+	return ret
+}
+
+// Join joins a list of strings with sep.
+func Join(args ...any) any {
+	variant.Arity(args, 2, 2)
+	list := variant.Slice(args[0])
+	parts := make([]string, len(list))
+	for i, v := range list {
+		parts[i] = variant.String(v)
+	}
+	return strings.Join(parts, variant.String(args[1]))
+}
+
+// Replace replaces all occurrences of old in s with new: replace(s, old, new).
+func Replace(args ...any) any {
+	variant.Arity(args, 3, 3)
+	return strings.ReplaceAll(variant.String(args[0]), variant.String(args[1]), variant.String(args[2]))
+}
+
+// Repeat returns a string of n copies of s: repeat(s, n).
+func Repeat(args ...any) any {
+	variant.Arity(args, 2, 2)
+	n := variant.Int(args[1])
+	if n < 0 {
+		panic("negative count")
+	}
+	return strings.Repeat(variant.String(args[0]), n)
+}
+
+// Format formats according to a format specifier like fmt.Sprintf:
+// format(fmt, args...).
+func Format(args ...any) any {
+	variant.Arity(args, 1, -1)
+	vals := make([]any, len(args)-1)
+	for i, v := range args[1:] {
+		vals[i] = variant.Eval(v)
+	}
+	return fmt.Sprintf(variant.String(args[0]), vals...)
+}
+
+// -----------------------------------------------------------------------------
+
+type namedFn struct {
+	name string
+	fn   func(...any) any
+}
+
+type str1 struct {
+	name string
+	fn   func(string) string
+}
+
+type strPred struct {
+	name string
+	fn   func(s, sub string) bool
+}
+
+var fnsStr1 = [...]str1{
+	{"upper", strings.ToUpper},
+	{"lower", strings.ToLower},
+	{"trim", strings.TrimSpace},
+}
+
+var fnsStrPred = [...]strPred{
+	{"contains", strings.Contains},
+	{"hasPrefix", strings.HasPrefix},
+	{"hasSuffix", strings.HasSuffix},
+}
+
+var fnsNamed = [...]namedFn{
+	{"len", Len},
+	{"substr", Substr},
+	{"index", Index},
+	{"split", Split},
+	{"join", Join},
+	{"replace", Replace},
+	{"repeat", Repeat},
+	{"format", Format},
+}
+
+func init() {
+	mod := variant.NewModule("strings")
+	for _, m := range fnsStr1 {
+		fn := m.fn
+		mod.Insert(m.name, func(args ...any) any {
+			variant.Arity(args, 1, 1)
+			return fn(variant.String(args[0]))
+		})
+	}
+	for _, m := range fnsStrPred {
+		fn := m.fn
+		mod.Insert(m.name, func(args ...any) any {
+			variant.Arity(args, 2, 2)
+			return fn(variant.String(args[0]), variant.String(args[1]))
+		})
+	}
+	for _, m := range fnsNamed {
+		mod.Insert(m.name, m.fn)
+	}
+}
+
+// -----------------------------------------------------------------------------
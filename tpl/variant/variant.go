@@ -87,6 +87,46 @@ func Int(v any) int {
 	panic("not an int")
 }
 
+// String ensures a value is string.
+func String(v any) string {
+	if v, ok := Eval(v).(string); ok {
+		return v
+	}
+	panic("not a string")
+}
+
+// Bool ensures a value is bool.
+func Bool(v any) bool {
+	if v, ok := Eval(v).(bool); ok {
+		return v
+	}
+	panic("not a bool")
+}
+
+// Slice ensures a value is a list ([]any).
+func Slice(v any) []any {
+	if v, ok := Eval(v).([]any); ok {
+		return v
+	}
+	panic("not a list")
+}
+
+// Map ensures a value is a record (map[string]any).
+func Map(v any) map[string]any {
+	if v, ok := Eval(v).(map[string]any); ok {
+		return v
+	}
+	panic("not a record")
+}
+
+// Arity checks the number of arguments of a function call is in [min, max].
+// max < 0 means there is no upper limit.
+func Arity(args []any, min, max int) {
+	if n := len(args); n < min || (max >= 0 && n > max) {
+		panic("arity mismatch")
+	}
+}
+
 // -----------------------------------------------------------------------------
 
 func cmpInt(op token.Token, x, y int) bool {
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variant_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/goplus/gop/tpl"
+	"github.com/goplus/gop/tpl/token"
+	"github.com/goplus/gop/tpl/variant"
+	_ "github.com/goplus/gop/tpl/variant/builtin"
+	"github.com/goplus/gop/tpl/variant/delay"
+	"github.com/goplus/gop/tpl/variant/io"
+	_ "github.com/goplus/gop/tpl/variant/lists"
+	_ "github.com/goplus/gop/tpl/variant/maps"
+	_ "github.com/goplus/gop/tpl/variant/strings"
+)
+
+func init() {
+	variant.InitUniverse("builtin", "strings", "lists", "maps", "io")
+}
+
+func call(name string, args ...any) any {
+	return variant.Call(false, name, args)
+}
+
+func TestModules(t *testing.T) {
+	if v := call("substr", "héllo", 1, 3); v != "éll" {
+		t.Fatal("substr:", v)
+	}
+	if v := call("join", call("split", "a,b,c", ","), "-"); v != "a-b-c" {
+		t.Fatal("split/join:", v)
+	}
+	if v := call("len", "héllo"); v != 5 || call("index", "héllo", "l") != 2 {
+		t.Fatal("len/index:", v)
+	}
+	if v := call("format", "%s=%d", call("upper", "x"), 1); v != "X=1" {
+		t.Fatal("format:", v)
+	}
+	double := func(args ...any) any { return variant.Int(args[0]) * 2 }
+	odd := func(args ...any) any { return variant.Int(args[0])%2 == 1 }
+	add := func(args ...any) any { return variant.Int(args[0]) + variant.Int(args[1]) }
+	list := call("append", call("list", 1, 2), 3)
+	if v := call("reduce", call("map", call("filter", list, odd), double), add, 0); v != 8 {
+		t.Fatal("map/filter/reduce:", v)
+	}
+	if v := call("slice", call("reverse", list), 1).([]any); len(v) != 2 || v[0] != 2 {
+		t.Fatal("slice/reverse:", v)
+	}
+	rec := call("set", call("record", "b", 2, "a", 1), "c", 3)
+	if v := call("keys", rec).([]any); len(v) != 3 || v[0] != "a" || call("get", rec, "x", 0) != 0 || call("has", rec, "x") != false {
+		t.Fatal("record:", v)
+	}
+	if call("str", 1.5) != "1.5" || call("float", "2.5") != 2.5 || call("bool", "true") != true || call("int", "3") != 3 {
+		t.Fatal("conversions")
+	}
+	var out bytes.Buffer
+	io.SetInput(strings.NewReader("Tom\n"))
+	io.SetOutput(&out)
+	call("print", "hello", call("input", "name? "))
+	if v := out.String(); v != "name? hello Tom\n" {
+		t.Fatal("print/input:", v)
+	}
+}
+
+func TestCallAt(t *testing.T) {
+	c, err := tpl.New(`
+call = IDENT "(" ?(expr % ",") ")"
+
+expr = INT | STRING | call
+`, "call", func(self []any) any {
+		var args any
+		if v := self[2]; v != nil {
+			args = v
+		}
+		name := self[0].(*tpl.Token)
+		return delay.CallAt(name.Pos, args != nil, name.Lit, args)
+	}, "expr", func(self any) any {
+		if t, ok := self.(*tpl.Token); ok {
+			switch t.Tok {
+			case token.INT:
+				return variant.Eval(call("int", t.Lit))
+			case token.STRING:
+				return t.Lit[1 : len(t.Lit)-1]
+			}
+		}
+		return self
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	fset := token.NewFileSet()
+	ret, err := c.ParseExpr(`upper(substr("hello", 1))`, &tpl.Config{Fset: fset})
+	if err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	if v, err := delay.Run(fset, ret); err != nil || v != "ELLO" {
+		t.Fatal("Run:", v, err)
+	}
+	ret, _ = c.ParseExpr(`upper(substr("hello", 1), 2)`, &tpl.Config{Fset: fset})
+	if _, err = delay.Run(fset, ret); err == nil || err.Error() != "1:1: upper: arity mismatch" {
+		t.Fatal("Run:", err)
+	}
+	ret, _ = c.ParseExpr(`upper(substr(1, 1))`, &tpl.Config{Fset: fset})
+	if _, err = delay.Run(fset, ret); err == nil || err.Error() != "1:7: substr: not a string" {
+		t.Fatal("Run:", err)
+	}
+	ret, _ = c.ParseExpr(`int("x")`, &tpl.Config{Fset: fset})
+	if _, err = delay.Run(fset, ret); err == nil || err.Error() != "1:1: int: can't convert to int" {
+		t.Fatal("Run:", err)
+	}
+}
@@ -163,6 +163,7 @@ func (ctx *formatCtx) leaveBlock(old *types.Scope) {
 
 func formatFile(file *ast.File) {
 	var funcs []*ast.FuncDecl
+	var props []*ast.PropDecl
 	ctx := &formatCtx{
 		imports: make(map[string]*importCtx),
 		scope:   types.NewScope(nil, token.NoPos, token.NoPos, ""),
@@ -172,6 +173,8 @@ func formatFile(file *ast.File) {
 		case *ast.FuncDecl:
 			// delay the process, because package level vars need to be processed first.
 			funcs = append(funcs, v)
+		case *ast.PropDecl:
+			props = append(props, v)
 		case *ast.GenDecl:
 			switch v.Tok {
 			case token.IMPORT:
@@ -198,6 +201,9 @@ func formatFile(file *ast.File) {
 	for _, fn := range funcs {
 		formatFuncDecl(ctx, fn)
 	}
+	for _, prop := range props {
+		formatPropDecl(ctx, prop)
+	}
 	for _, imp := range ctx.imports {
 		if imp.pkgPath == "fmt" && !imp.isUsed {
 			if len(imp.decl.Specs) == 1 {
@@ -233,6 +239,19 @@ func formatFuncDecl(ctx *formatCtx, v *ast.FuncDecl) {
 	formatBlockStmt(ctx, v.Body)
 }
 
+func formatPropDecl(ctx *formatCtx, v *ast.PropDecl) {
+	formatType(ctx, v.Type, &v.Type)
+	for _, acc := range []*ast.PropAccessor{v.Get, v.Set} {
+		switch {
+		case acc == nil:
+		case acc.Body != nil:
+			formatBlockStmt(ctx, acc.Body)
+		default:
+			formatExpr(ctx, acc.X, &acc.X)
+		}
+	}
+}
+
 /*
 func fillVarCtx(ctx *formatCtx, spec *ast.ValueSpec) {
 	for _, name := range spec.Names {
@@ -38,6 +38,10 @@ func formatType(ctx *formatCtx, typ ast.Expr, ref *ast.Expr) {
 		formatType(ctx, t.Value, &t.Value)
 	case *ast.StructType:
 		formatFields(ctx, t.Fields)
+	case *ast.TupleType:
+		for i, elt := range t.Elts {
+			formatType(ctx, elt, &t.Elts[i])
+		}
 	case *ast.ArrayType:
 		formatExpr(ctx, t.Len, &t.Len)
 		formatType(ctx, t.Elt, &t.Elt)
@@ -98,6 +102,8 @@ func formatExpr(ctx *formatCtx, expr ast.Expr, ref *ast.Expr) {
 		formatExpr(ctx, v.Index, &v.Index)
 	case *ast.SliceLit:
 		formatExprs(ctx, v.Elts)
+	case *ast.TupleLit:
+		formatExprs(ctx, v.Elts)
 	case *ast.CompositeLit:
 		formatType(ctx, v.Type, &v.Type)
 		formatExprs(ctx, v.Elts)
@@ -120,6 +126,8 @@ func formatExpr(ctx *formatCtx, expr ast.Expr, ref *ast.Expr) {
 		formatRangeExpr(ctx, v)
 	case *ast.ComprehensionExpr:
 		formatComprehensionExpr(ctx, v)
+	case *ast.AwaitExpr:
+		formatExpr(ctx, v.X, &v.X)
 	case *ast.ErrWrapExpr:
 		formatExpr(ctx, v.X, &v.X)
 		formatExpr(ctx, v.Default, &v.Default)
@@ -169,7 +177,7 @@ func formatCallExpr(ctx *formatCtx, v *ast.CallExpr) {
 	formatExpr(ctx, v.Fun, &v.Fun)
 	fncallStartingLowerCase(v)
 	for i, arg := range v.Args {
-		if fn, ok := arg.(*ast.FuncLit); ok {
+		if fn, ok := arg.(*ast.FuncLit); ok && !fn.Async.IsValid() {
 			funcLitToLambdaExpr(fn, &v.Args[i])
 		}
 	}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gopspec
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/goplus/gop/tpl/gen/fuzz"
+	"github.com/qiniu/x/test"
+)
+
+func newMiniGen(t testing.TB) (*Checker, *fuzz.Generator) {
+	spec, err := Load(nil, "../../doc/spec/mini/mini.gop")
+	if err != nil {
+		t.Fatal("Load:", err)
+	}
+	g, err := fuzz.New(spec.Result, &fuzz.Config{MaxDepth: 8, MaxTokens: 40})
+	if err != nil {
+		t.Fatal("fuzz.New:", err)
+	}
+	return &Checker{Spec: spec}, g
+}
+
+// TestMiniSpecGen checks random sentences of the mini spec, and near misses of
+// them, against the parser. Like TestMiniSpec, the known mismatches are listed
+// in _testdata/gen.expect.
+func TestMiniSpecGen(t *testing.T) {
+	c, g := newMiniGen(t)
+	r := rand.New(rand.NewSource(1))
+	var b bytes.Buffer
+	for i := 0; i < 100; i++ {
+		src, err := g.Generate(r)
+		if err != nil {
+			t.Fatal("Generate:", err)
+		}
+		name := fmt.Sprintf("gen%d.gop", i)
+		if i%2 == 1 {
+			src, name = g.NearMiss(r), fmt.Sprintf("nearmiss%d.gop", i)
+		}
+		m, err := c.CheckFile(name, src)
+		if err != nil {
+			t.Fatal("CheckFile:", err)
+		}
+		if m != nil {
+			b.WriteString(m.String() + "\n")
+		}
+	}
+	expect := readExpect("_testdata/gen.expect")
+	if test.Diff(t, "_testdata/gen.result.txt", b.Bytes(), expect) {
+		t.Fatal("mismatches of generated sentences changed, see _testdata/gen.result.txt")
+	}
+}
+
+// FuzzMiniSpec fuzzes the parser against the mini spec, by sentences of the
+// spec and near misses of them (if the first byte is odd):
+//
+//	go test -fuzz FuzzMiniSpec ./x/gopspec
+//
+// It fails on the first disagreement. The spec rejecting a near miss isn't a
+// disagreement, since the mini spec is a subset of Go+, and a near miss may be
+// out of it but still valid.
+func FuzzMiniSpec(f *testing.F) {
+	c, g := newMiniGen(f)
+	fuzz.AddSeeds(f, 16)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var src []byte
+		nearMiss := len(data) > 0 && data[0]&1 != 0
+		if nearMiss {
+			src = g.NearMiss(fuzz.Bytes(data[1:]))
+		} else {
+			var err error
+			if src, err = g.Generate(fuzz.Bytes(data)); err != nil {
+				t.Fatal("Generate:", err)
+			}
+		}
+		m, err := c.CheckFile("fuzz.gop", src)
+		if err != nil {
+			t.Fatal("CheckFile:", err)
+		}
+		if m != nil && !(m.Kind == SpecRejects && nearMiss) {
+			t.Fatalf("%v\n%s", m, src)
+		}
+	})
+}
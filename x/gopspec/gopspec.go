@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gopspec checks the conformance of the Go+ parser to a grammar spec
+// of Go+ written in TPL, such as the mini spec (doc/spec/mini).
+package gopspec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goplus/gop/ast"
+	"github.com/goplus/gop/parser"
+	"github.com/goplus/gop/parser/iox"
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/tpl"
+	tplast "github.com/goplus/gop/tpl/ast"
+	"github.com/goplus/gop/tpl/cl"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrNoSpec is returned by Load if there is no tpl`...` literal in a Go+
+// source file.
+var ErrNoSpec = errors.New("gopspec: no tpl literal found")
+
+// Load loads a grammar spec from a file. If it's a Go+ source file (.gop),
+// the first tpl`...` literal in it is the spec, eg. doc/spec/mini/mini.gop.
+// Otherwise, it's a .tpl file.
+func Load(fset *token.FileSet, filename string) (spec tpl.Compiler, err error) {
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	conf := &cl.Config{
+		OnConflict: func(fset *token.FileSet, c *tplast.Choice, firsts [][]any, i, at int) {},
+	}
+	if filepath.Ext(filename) != ".gop" {
+		return tpl.FromFile(fset, filename, nil, conf)
+	}
+	f, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return
+	}
+	var g *tplast.File
+	ast.Inspect(f, func(node ast.Node) bool {
+		if lit, ok := node.(*ast.DomainTextLit); ok && g == nil {
+			g, _ = lit.Extra.(*tplast.File)
+		}
+		return g == nil
+	})
+	if g == nil {
+		return spec, ErrNoSpec
+	}
+	spec.Result, err = cl.NewEx(conf, fset, g)
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+// A Kind is the kind of a Mismatch.
+type Kind int
+
+const (
+	// SpecRejects means the parser accepts a file, but the spec rejects it.
+	SpecRejects Kind = iota + 1
+	// ParserRejects means the spec accepts a file, but the parser rejects it.
+	ParserRejects
+)
+
+func (k Kind) String() string {
+	switch k {
+	case SpecRejects:
+		return "spec rejects"
+	case ParserRejects:
+		return "parser rejects"
+	}
+	return "unknown"
+}
+
+// A Mismatch represents a file which is accepted by only one of the parser and
+// the spec.
+type Mismatch struct {
+	File string
+	Kind Kind
+	Err  error // error of the rejecting one, which has positions
+}
+
+// String returns the kind and the error of a mismatch, eg.
+//
+//	spec rejects: a.gop:1:10: unexpected token: ->
+func (p *Mismatch) String() string {
+	return p.Kind.String() + ": " + firstLine(p.Err.Error())
+}
+
+func firstLine(s string) string {
+	if pos := strings.IndexByte(s, '\n'); pos >= 0 {
+		return s[:pos]
+	}
+	return s
+}
+
+// -----------------------------------------------------------------------------
+
+// A Checker checks Go+ source files against a spec.
+type Checker struct {
+	Spec tpl.Compiler
+	Fset *token.FileSet // can be nil
+	Dump io.Writer      // if not nil, the results of the spec are dumped to it
+}
+
+// IsSourceFile reports whether a file is a Go+ source file to check: a .gop
+// file or a classfile (eg. .gox, .spx, .yap).
+func IsSourceFile(filename string) bool {
+	switch filepath.Ext(filename) {
+	case ".gop", ".gox", ".spx", ".gsh", ".gmx", ".yap":
+		return true
+	}
+	return false
+}
+
+// CheckFile parses a file by both the parser and the spec. It returns a
+// Mismatch if only one of them accepts it, or nil otherwise.
+func (p *Checker) CheckFile(filename string, src any) (*Mismatch, error) {
+	b, err := iox.ReadSourceLocal(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	fset := p.Fset
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	var conf parser.Config
+	if filepath.Ext(filename) == ".yap" { // classfiles of the yap framework
+		conf.ClassKind = func(string) (isProj, ok bool) { return true, true }
+	}
+	_, errParser := parser.ParseEntry(fset, filename, b, conf)
+	ret, errSpec := p.Spec.Parse(filename, b, &tpl.Config{Fset: fset, Memo: true})
+	if errSpec == nil && p.Dump != nil {
+		fmt.Fprintf(p.Dump, "==> %s\n", filename)
+		tpl.Fdump(p.Dump, ret, "", "  ", false)
+	}
+	switch {
+	case errParser == nil && errSpec != nil:
+		return &Mismatch{filename, SpecRejects, errSpec}, nil
+	case errParser != nil && errSpec == nil:
+		return &Mismatch{filename, ParserRejects, errParser}, nil
+	}
+	return nil, nil
+}
+
+// CheckDir checks the Go+ source files (see IsSourceFile) in dir, and its
+// subdirectories if recursive is true. Files and subdirectories whose names
+// begin with "_" or "." are ignored. The mismatches are sorted by file name.
+func (p *Checker) CheckDir(dir string, recursive bool) (ret []*Mismatch, err error) {
+	err = filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if file != dir && (strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".")) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if file != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if IsSourceFile(name) {
+			m, err := p.CheckFile(file, nil)
+			if err != nil {
+				return err
+			}
+			if m != nil {
+				ret = append(ret, m)
+			}
+		}
+		return nil
+	})
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].File < ret[j].File
+	})
+	return
+}
+
+// -----------------------------------------------------------------------------
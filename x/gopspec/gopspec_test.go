@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gopspec
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/qiniu/x/test"
+)
+
+// TestMiniSpec checks the test data of the parser and the compiler against the
+// mini spec. The known mismatches are listed in _testdata/mini.expect, so the
+// spec and the parser can't drift apart silently: if they change, check the
+// mismatches in _testdata/result.txt, and update mini.expect with it. Lines
+// of mini.expect starting with # are comments, eg. TODOs of the spec, which
+// are kept by hand.
+func TestMiniSpec(t *testing.T) {
+	const root = "../../"
+	spec, err := Load(nil, root+"doc/spec/mini/mini.gop")
+	if err != nil {
+		t.Fatal("Load:", err)
+	}
+	c := &Checker{Spec: spec}
+	var b bytes.Buffer
+	for _, dir := range []string{"parser/_testdata", "cl/_testgop"} {
+		ret, err := c.CheckDir(root+dir, true)
+		if err != nil {
+			t.Fatal("CheckDir:", err)
+		}
+		for _, m := range ret {
+			b.WriteString(strings.ReplaceAll(filepath.ToSlash(m.String()), root, "") + "\n")
+		}
+	}
+	expect := readExpect("_testdata/mini.expect")
+	if test.Diff(t, "_testdata/result.txt", b.Bytes(), expect) {
+		t.Fatal("mismatches of the mini spec changed, see _testdata/result.txt")
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(dir+"/spec.tpl", []byte(`doc = *(INT INT ";")`), 0644)
+	os.WriteFile(dir+"/nospec.gop", []byte("echo 1\n"), 0644)
+	if _, err := Load(nil, dir+"/nospec.gop"); err != ErrNoSpec {
+		t.Fatal("Load:", err)
+	}
+	spec, err := Load(nil, dir+"/spec.tpl")
+	if err != nil {
+		t.Fatal("Load:", err)
+	}
+	c := &Checker{Spec: spec}
+	for _, e := range []struct {
+		src, mismatch string
+	}{
+		{"1 2\n3 4\n", "parser rejects: a.gop:1:3: expected ';', found 2"},
+		{"x := 1\n", "spec rejects: a.gop:1:1: unexpected token: x"},
+		{"", ""},
+		{"+", ""},
+	} {
+		m, err := c.CheckFile("a.gop", e.src)
+		if err != nil {
+			t.Fatal("CheckFile:", err)
+		}
+		if m == nil && e.mismatch != "" || m != nil && m.String() != e.mismatch {
+			t.Fatal("CheckFile:", e.src, m)
+		}
+	}
+	if !IsSourceFile("a.yap") {
+		t.Fatal("IsSourceFile: a.yap")
+	}
+	if m, err := c.CheckFile("a.yap", ""); err != nil || m != nil {
+		t.Fatal("CheckFile a.yap:", m, err)
+	}
+	var b bytes.Buffer
+	c.Dump = &b
+	if _, err = c.CheckFile("a.gop", "1 2\n"); err != nil {
+		t.Fatal("CheckFile:", err)
+	}
+	if !strings.HasPrefix(b.String(), "==> a.gop\n") {
+		t.Fatal("Dump:", b.String())
+	}
+}
+
+// readExpect reads the expected mismatches in file, without comments.
+func readExpect(file string) []byte {
+	b, _ := os.ReadFile(file)
+	lines := bytes.SplitAfter(b, []byte{'\n'})
+	ret := lines[:0]
+	for _, line := range lines {
+		if !bytes.HasPrefix(line, []byte{'#'}) {
+			ret = append(ret, line)
+		}
+	}
+	return bytes.Join(ret, nil)
+}
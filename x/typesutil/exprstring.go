@@ -119,6 +119,10 @@ func WriteExpr(buf *bytes.Buffer, x ast.Expr) {
 		buf.WriteString(x.Op.String())
 		WriteExpr(buf, x.X)
 
+	case *ast.AwaitExpr:
+		buf.WriteString("await ")
+		WriteExpr(buf, x.X)
+
 	case *ast.BinaryExpr:
 		WriteExpr(buf, x.X)
 		buf.WriteByte(' ')
@@ -134,6 +138,16 @@ func WriteExpr(buf *bytes.Buffer, x ast.Expr) {
 		buf.WriteByte(']')
 		WriteExpr(buf, x.Elt)
 
+	case *ast.TupleType:
+		buf.WriteByte('(')
+		writeExprList(buf, x.Elts)
+		buf.WriteByte(')')
+
+	case *ast.TupleLit:
+		buf.WriteByte('(')
+		writeExprList(buf, x.Elts)
+		buf.WriteByte(')')
+
 	case *ast.StructType:
 		buf.WriteString("struct{")
 		writeFieldList(buf, x.Fields.List, "; ", false)
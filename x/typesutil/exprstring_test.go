@@ -84,6 +84,9 @@ var testExprs = []testEntry{
 
 	dup("x.(map[K]V)"),
 
+	dup("x.((int, string))"),
+	dup("x.([](T, map[K]V))"),
+
 	dup("x.(chan E)"),
 	dup("x.(<-chan E)"),
 	dup("x.(chan<- chan int)"),
@@ -91,6 +94,8 @@ var testExprs = []testEntry{
 	dup("x.(<-chan chan int)"),
 	dup("x.(chan (<-chan int))"),
 
+	dup("(x, y + 1)"),
+	dup("await f(x)"),
 	dup("f()"),
 	dup("f(x)"),
 	dup("int(x)"),
@@ -2491,6 +2491,67 @@ func add = (
 014: 15: 2 | addString           | func (*main.Rect).addString(a string, b string) string`)
 }
 
+func TestGoxPropInfo(t *testing.T) {
+	testSpxInfo(t, "Rect.gox", `
+var (
+	w, h int
+)
+
+prop Width int {
+	get => w
+	set v => {
+		w = v
+	}
+}
+
+func test() {
+	r := &Rect{}
+	r.width = 10
+	echo r.width
+}
+`, `== types ==
+000:  0: 0 | Rect                *ast.Ident                     | type    : main.Rect | type
+001:  3: 7 | int                 *ast.Ident                     | type    : int | type
+002:  6:12 | int                 *ast.Ident                     | type    : int | type
+003:  7: 9 | w                   *ast.Ident                     | var     : int | variable
+004:  9: 3 | w                   *ast.Ident                     | var     : int | variable
+005:  9: 7 | v                   *ast.Ident                     | var     : int | variable
+006: 14: 7 | &Rect{}             *ast.UnaryExpr                 | value   : *main.Rect | value
+007: 14: 8 | Rect                *ast.Ident                     | type    : main.Rect | type
+008: 14: 8 | Rect{}              *ast.CompositeLit              | value   : main.Rect | value
+009: 15: 2 | r                   *ast.Ident                     | var     : *main.Rect | variable
+010: 15: 2 | r.width             *ast.SelectorExpr              | value   : func(v int) | value
+011: 15:12 | 10                  *ast.BasicLit                  | value   : untyped int = 10 | constant
+012: 16: 2 | echo                *ast.Ident                     | value   : func(a ...any) (n int, err error) | value
+013: 16: 2 | echo r.width        *ast.CallExpr                  | value   : (n int, err error) | value
+014: 16: 7 | r                   *ast.Ident                     | var     : *main.Rect | variable
+015: 16: 7 | r.width             *ast.SelectorExpr              | value   : func() int | value
+== defs ==
+000:  0: 0 | this                | var this *main.Rect
+001:  3: 2 | w                   | field w int
+002:  3: 5 | h                   | field h int
+003:  6: 6 | Width               | func (*main.Rect).Width() int
+004:  8: 2 | SetWidth            | func (*main.Rect).SetWidth(v int)
+005:  8: 6 | v                   | var v int
+006: 13: 6 | test                | func (*main.Rect).test()
+007: 14: 2 | r                   | var r *main.Rect
+== uses ==
+000:  0: 0 | Rect                | type main.Rect struct{w int; h int}
+001:  3: 7 | int                 | type int
+002:  6:12 | int                 | type int
+003:  7: 9 | w                   | field w int
+004:  9: 3 | w                   | field w int
+005:  9: 7 | v                   | var v int
+006: 14: 8 | Rect                | type main.Rect struct{w int; h int}
+007: 15: 2 | r                   | var r *main.Rect
+008: 15: 4 | width               | func (*main.Rect).SetWidth(v int)
+009: 16: 2 | echo                | func fmt.Println(a ...any) (n int, err error)
+010: 16: 7 | r                   | var r *main.Rect
+011: 16: 9 | width               | func (*main.Rect).Width() int
+== overloads ==
+000: 16: 2 | echo                | func echo(__gop_overload_args__ interface{_()})`)
+}
+
 func TestTypesAlias(t *testing.T) {
 	testInfo(t, `package main
 import "fmt"
@@ -2505,3 +2566,130 @@ func main() {
 }
 `)
 }
+
+func TestTuple(t *testing.T) {
+	testGopInfo(t, `
+type Pair (string, int)
+
+p := ("a", 1)
+k, v := p
+for (x, y) in [p] {
+	println x, y
+}
+println k, v
+`, ``, `== types ==
+000:  2:11 | (string, int)       *ast.TupleType                 | type    : struct{X_0 string; X_1 int} | type
+001:  2:12 | string              *ast.Ident                     | type    : string | type
+002:  2:20 | int                 *ast.Ident                     | type    : int | type
+003:  4: 6 | ("a", 1)            *ast.TupleLit                  | value   : struct{X_0 string; X_1 int} | value
+004:  4: 7 | "a"                 *ast.BasicLit                  | value   : untyped string = "a" | constant
+005:  4:12 | 1                   *ast.BasicLit                  | value   : untyped int = 1 | constant
+006:  5: 9 | p                   *ast.Ident                     | var     : struct{X_0 string; X_1 int} | variable
+007:  6:16 | p                   *ast.Ident                     | var     : struct{X_0 string; X_1 int} | variable
+008:  7: 2 | println             *ast.Ident                     | value   : func(a ...any) (n int, err error) | value
+009:  7: 2 | println x, y        *ast.CallExpr                  | value   : (n int, err error) | value
+010:  7:10 | x                   *ast.Ident                     | var     : string | variable
+011:  7:13 | y                   *ast.Ident                     | var     : int | variable
+012:  9: 1 | println             *ast.Ident                     | value   : func(a ...any) (n int, err error) | value
+013:  9: 1 | println k, v        *ast.CallExpr                  | value   : (n int, err error) | value
+014:  9: 9 | k                   *ast.Ident                     | var     : string | variable
+015:  9:12 | v                   *ast.Ident                     | var     : int | variable
+== defs ==
+000:  2: 6 | Pair                | type main.Pair struct{X_0 string; X_1 int}
+001:  4: 1 | main                | func main.main()
+002:  4: 1 | p                   | var p struct{X_0 string; X_1 int}
+003:  5: 1 | k                   | var k string
+004:  5: 4 | v                   | var v int
+005:  6: 6 | x                   | var x string
+006:  6: 9 | y                   | var y int
+== uses ==
+000:  2:12 | string              | type string
+001:  2:20 | int                 | type int
+002:  5: 9 | p                   | var p struct{X_0 string; X_1 int}
+003:  6:16 | p                   | var p struct{X_0 string; X_1 int}
+004:  7: 2 | println             | func fmt.Println(a ...any) (n int, err error)
+005:  7:10 | x                   | var x string
+006:  7:13 | y                   | var y int
+007:  9: 1 | println             | func fmt.Println(a ...any) (n int, err error)
+008:  9: 9 | k                   | var k string
+009:  9:12 | v                   | var v int`)
+}
+
+func TestLazyComprehension(t *testing.T) {
+	testGopInfo(t, `
+a := [1, 2]
+s := (x*2 for x in a if x > 1)
+println s
+`, ``, `== types ==
+000:  2: 7 | 1                   *ast.BasicLit                  | value   : untyped int = 1 | constant
+001:  2:10 | 2                   *ast.BasicLit                  | value   : untyped int = 2 | constant
+002:  3: 7 | x                   *ast.Ident                     | var     : int | variable
+003:  3: 7 | x * 2               *ast.BinaryExpr                | value   : int | value
+004:  3: 9 | 2                   *ast.BasicLit                  | value   : untyped int = 2 | constant
+005:  3:20 | a                   *ast.Ident                     | var     : []int | variable
+006:  3:25 | x                   *ast.Ident                     | var     : int | variable
+007:  3:25 | x > 1               *ast.BinaryExpr                | value   : untyped bool | value
+008:  3:29 | 1                   *ast.BasicLit                  | value   : untyped int = 1 | constant
+009:  4: 1 | println             *ast.Ident                     | value   : func(a ...any) (n int, err error) | value
+010:  4: 1 | println s           *ast.CallExpr                  | value   : (n int, err error) | value
+011:  4: 9 | s                   *ast.Ident                     | var     : func(_gop_yield func(int) bool) | variable
+== defs ==
+000:  2: 1 | a                   | var a []int
+001:  2: 1 | main                | func main.main()
+002:  3: 1 | s                   | var s func(_gop_yield func(int) bool)
+003:  3:15 | x                   | var x int
+== uses ==
+000:  3: 7 | x                   | var x int
+001:  3:20 | a                   | var a []int
+002:  3:25 | x                   | var x int
+003:  4: 1 | println             | func fmt.Println(a ...any) (n int, err error)
+004:  4: 9 | s                   | var s func(_gop_yield func(int) bool)`)
+}
+
+func TestAsync(t *testing.T) {
+	testGopInfo(t, `
+async func square(x int) (y int) {
+	y = x * x
+	return
+}
+
+v, err := await square(2)
+println v, err, await square(3)
+`, ``, `== types ==
+000:  2:21 | int                 *ast.Ident                     | type    : int | type
+001:  2:29 | int                 *ast.Ident                     | type    : int | type
+002:  3: 2 | y                   *ast.Ident                     | var     : int | variable
+003:  3: 6 | x                   *ast.Ident                     | var     : int | variable
+004:  3: 6 | x * x               *ast.BinaryExpr                | value   : int | value
+005:  3:10 | x                   *ast.Ident                     | var     : int | variable
+006:  7:11 | await square(2)     *ast.AwaitExpr                 | value   : (int, error) | value
+007:  7:17 | square              *ast.Ident                     | value   : func(x int) *github.com/goplus/gop/builtin/async.Future[int] | value
+008:  7:17 | square(2)           *ast.CallExpr                  | value   : *github.com/goplus/gop/builtin/async.Future[int] | value
+009:  7:24 | 2                   *ast.BasicLit                  | value   : untyped int = 2 | constant
+010:  8: 1 | println             *ast.Ident                     | value   : func(a ...any) (n int, err error) | value
+011:  8: 1 | println v, err, await square(3) *ast.CallExpr                  | value   : (n int, err error) | value
+012:  8: 9 | v                   *ast.Ident                     | var     : int | variable
+013:  8:12 | err                 *ast.Ident                     | var     : error | variable
+014:  8:17 | await square(3)     *ast.AwaitExpr                 | value   : int | value
+015:  8:23 | square              *ast.Ident                     | value   : func(x int) *github.com/goplus/gop/builtin/async.Future[int] | value
+016:  8:23 | square(3)           *ast.CallExpr                  | value   : *github.com/goplus/gop/builtin/async.Future[int] | value
+017:  8:30 | 3                   *ast.BasicLit                  | value   : untyped int = 3 | constant
+== defs ==
+000:  2:12 | square              | func main.square(x int) *github.com/goplus/gop/builtin/async.Future[int]
+001:  2:19 | x                   | var x int
+002:  2:27 | y                   | var y int
+003:  7: 1 | main                | func main.main()
+004:  7: 1 | v                   | var v int
+005:  7: 4 | err                 | var err error
+== uses ==
+000:  2:21 | int                 | type int
+001:  2:29 | int                 | type int
+002:  3: 2 | y                   | var y int
+003:  3: 6 | x                   | var x int
+004:  3:10 | x                   | var x int
+005:  7:17 | square              | func main.square(x int) *github.com/goplus/gop/builtin/async.Future[int]
+006:  8: 1 | println             | func fmt.Println(a ...any) (n int, err error)
+007:  8: 9 | v                   | var v int
+008:  8:12 | err                 | var err error
+009:  8:23 | square              | func main.square(x int) *github.com/goplus/gop/builtin/async.Future[int]`)
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2025 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package typesutil
+
+import (
+	"bytes"
+	"go/types"
+	"strconv"
+
+	"github.com/goplus/gop/cl"
+)
+
+// -----------------------------------------------------------------------------
+
+// TypeString returns the string representation of typ like types.TypeString.
+// Unlike types.TypeString, Go+ tuple types are written as (T0, T1, ...)
+// instead of the anonymous structs they are compiled into.
+func TypeString(typ types.Type, qf types.Qualifier) string {
+	var buf bytes.Buffer
+	WriteType(&buf, typ, qf)
+	return buf.String()
+}
+
+// WriteType writes the string representation of typ to buf like
+// types.WriteType. See TypeString.
+func WriteType(buf *bytes.Buffer, typ types.Type, qf types.Qualifier) {
+	switch t := typ.(type) {
+	case *types.Struct:
+		if fields, ok := cl.TupleFields(t); ok {
+			buf.WriteByte('(')
+			for i, f := range fields {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				WriteType(buf, f.Type(), qf)
+			}
+			buf.WriteByte(')')
+			return
+		}
+	case *types.Pointer:
+		buf.WriteByte('*')
+		WriteType(buf, t.Elem(), qf)
+		return
+	case *types.Slice:
+		buf.WriteString("[]")
+		WriteType(buf, t.Elem(), qf)
+		return
+	case *types.Array:
+		buf.WriteByte('[')
+		buf.WriteString(strconv.FormatInt(t.Len(), 10))
+		buf.WriteByte(']')
+		WriteType(buf, t.Elem(), qf)
+		return
+	case *types.Map:
+		buf.WriteString("map[")
+		WriteType(buf, t.Key(), qf)
+		buf.WriteByte(']')
+		WriteType(buf, t.Elem(), qf)
+		return
+	case *types.Chan:
+		switch t.Dir() {
+		case types.SendRecv:
+			buf.WriteString("chan ")
+			if c, ok := t.Elem().(*types.Chan); ok && c.Dir() == types.RecvOnly {
+				buf.WriteByte('(')
+				WriteType(buf, c, qf)
+				buf.WriteByte(')')
+				return
+			}
+		case types.SendOnly:
+			buf.WriteString("chan<- ")
+		case types.RecvOnly:
+			buf.WriteString("<-chan ")
+		}
+		WriteType(buf, t.Elem(), qf)
+		return
+	case *types.Signature:
+		if t.TypeParams().Len() == 0 {
+			buf.WriteString("func")
+			writeSignature(buf, t, qf)
+			return
+		}
+	}
+	types.WriteType(buf, typ, qf)
+}
+
+func writeSignature(buf *bytes.Buffer, sig *types.Signature, qf types.Qualifier) {
+	writeTuple(buf, sig.Params(), sig.Variadic(), qf)
+	results := sig.Results()
+	switch results.Len() {
+	case 0:
+	case 1:
+		if results.At(0).Name() == "" {
+			buf.WriteByte(' ')
+			WriteType(buf, results.At(0).Type(), qf)
+			break
+		}
+		fallthrough
+	default:
+		buf.WriteByte(' ')
+		writeTuple(buf, results, false, qf)
+	}
+}
+
+func writeTuple(buf *bytes.Buffer, tuple *types.Tuple, variadic bool, qf types.Qualifier) {
+	buf.WriteByte('(')
+	for i, n := 0, tuple.Len(); i < n; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		v := tuple.At(i)
+		if name := v.Name(); name != "" {
+			buf.WriteString(name)
+			buf.WriteByte(' ')
+		}
+		typ := v.Type()
+		if variadic && i == n-1 {
+			if s, ok := typ.(*types.Slice); ok {
+				buf.WriteString("...")
+				typ = s.Elem()
+			}
+		}
+		WriteType(buf, typ, qf)
+	}
+	buf.WriteByte(')')
+}
+
+// -----------------------------------------------------------------------------
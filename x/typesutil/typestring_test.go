@@ -0,0 +1,46 @@
+package typesutil_test
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/goplus/gop/token"
+	"github.com/goplus/gop/x/typesutil"
+)
+
+func TestTypeString(t *testing.T) {
+	fset := token.NewFileSet()
+	pkg, _, err := parseSource(fset, "main.gop", `
+type Pair (string, int)
+
+var a (int, []string)
+var b [](int, (bool, error))
+var c map[string]*(int, int)
+var d [2](int, chan<- (int, int))
+var e func(x (int, int), y ...(int, int)) (int, int)
+var f func() (r (int, int))
+var g Pair
+var h struct{ X_0 int }
+`, 0)
+	if err != nil {
+		t.Fatal("parseSource failed:", err)
+	}
+	qf := types.RelativeTo(pkg)
+	for _, c := range []struct {
+		name, want string
+	}{
+		{"a", "(int, []string)"},
+		{"b", "[](int, (bool, error))"},
+		{"c", "map[string]*(int, int)"},
+		{"d", "[2](int, chan<- (int, int))"},
+		{"e", "func(x (int, int), y ...(int, int)) (int, int)"},
+		{"f", "func() (r (int, int))"},
+		{"g", "Pair"},
+		{"h", "struct{X_0 int}"},
+	} {
+		typ := pkg.Scope().Lookup(c.name).Type()
+		if got := typesutil.TypeString(typ, qf); got != c.want {
+			t.Errorf("TypeString(%s): got %s, want %s", c.name, got, c.want)
+		}
+	}
+}